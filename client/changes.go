@@ -0,0 +1,126 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListChanges returns up to limit changes of movies made after the change since, and
+// the sequence number to pass as since to get the changes that follow. When there are
+// no changes yet, the API waits up to wait for some. Zero values use the defaults of
+// the API.
+func (c *Client) ListChanges(ctx context.Context, since int64, limit int, wait time.Duration, opts ...RequestOption) ([]*MovieChange, int64, error) {
+	query := url.Values{}
+	setInt(query, "since", since)
+	setInt(query, "limit", int64(limit))
+	if wait > 0 {
+		query.Set("wait", wait.String())
+	}
+
+	var resp struct {
+		Changes   []*MovieChange `json:"changes"`
+		NextSince int64          `json:"next_since"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/changes", query: query}, &resp, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.Changes, resp.NextSince, nil
+}
+
+// Event is an event of the stream of movie changes.
+type Event struct {
+	// ID is the sequence number of the change, to resume the stream from.
+	ID int64
+
+	// Type is movie.created, movie.updated or movie.deleted.
+	Type string
+
+	Change MovieChange
+}
+
+// EventStream reads the events of a stream of movie changes. It must be closed.
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// StreamEvents opens a stream of the changes made to movies after the change
+// lastEventID, or after the stream is opened when lastEventID is 0. Cancelling ctx
+// ends the stream.
+func (c *Client) StreamEvents(ctx context.Context, lastEventID int64, opts ...RequestOption) (*EventStream, error) {
+	query := url.Values{}
+	setInt(query, "last_event_id", lastEventID)
+
+	opts = append([]RequestOption{WithHeader("Accept", "text/event-stream")}, opts...)
+
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/v1/events/stream", query: query}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventStream{body: resp.Body, scanner: bufio.NewScanner(resp.Body)}, nil
+}
+
+// Next waits for the next event. It returns io.EOF once the API ends the stream,
+// which it does after a while, so resume with the ID of the last event.
+func (s *EventStream) Next() (*Event, error) {
+	var (
+		event Event
+		data  strings.Builder
+	)
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		if line == "" {
+			// A blank line ends an event. Blank lines after retry fields and
+			// comments end nothing.
+			if data.Len() == 0 {
+				continue
+			}
+
+			err := json.Unmarshal([]byte(data.String()), &event.Change)
+			if err != nil {
+				return nil, err
+			}
+
+			return &event, nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "id":
+			event.ID, _ = strconv.ParseInt(value, 10, 64)
+		case "event":
+			event.Type = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// Close ends the stream.
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,386 @@
+// Package client is the Go client of the gmoapi REST API.
+//
+// A client is created for the base URL of the API, and authenticates either with a
+// token obtained elsewhere or with the credentials of a user:
+//
+//	c, err := client.New("https://gmoapi.ucokman.web.id", client.WithCredentials(email, password))
+//	if err != nil {
+//		return err
+//	}
+//
+//	movie, err := c.GetMovie(ctx, 1)
+//
+// Error responses are returned as the typed errors of this package, see APIError.
+// Requests that hit the rate limit are retried with backoff.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxRetries is how often a request is retried after hitting the rate limit.
+	defaultMaxRetries = 3
+
+	// retryBaseWait and retryMaxWait bound the backoff between retries, when the API
+	// doesn't say how long to wait.
+	retryBaseWait = 500 * time.Millisecond
+	retryMaxWait  = 30 * time.Second
+
+	// tokenRefreshMargin is how long before its expiry a token is replaced, when the
+	// client has the credentials to do so.
+	tokenRefreshMargin = time.Minute
+)
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	maxRetries int
+
+	email    string
+	password string
+
+	mu    sync.Mutex
+	token Token
+
+	// authMu makes concurrent requests wait for a single authentication.
+	authMu sync.Mutex
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests, http.DefaultClient by
+// default. Streams and long polls stay open for a while, so a timeout of the client
+// should leave room for them.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken sets the authentication token sent with requests.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = Token{Plaintext: token}
+	}
+}
+
+// WithCredentials makes the client authenticate as the user when it needs a token, and
+// again when the token expires or is rejected.
+func WithCredentials(email, password string) Option {
+	return func(c *Client) {
+		c.email = email
+		c.password = password
+	}
+}
+
+// WithMaxRetries sets how often a request is retried after hitting the rate limit, 3
+// by default. Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = max(n, 0)
+	}
+}
+
+// New returns a client of the API at baseURL, the URL the /v1 paths are relative to.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("client: base URL must be an absolute http or https URL: %q", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Token returns the authentication token the client sends with requests.
+func (c *Client) Token() Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.token
+}
+
+// SetToken sets the authentication token the client sends with requests. An empty
+// token makes the requests anonymous, unless the client has credentials.
+func (c *Client) SetToken(token Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = token
+}
+
+// RequestOption changes a single request.
+type RequestOption func(*http.Request)
+
+// WithHeader sets a header of the request.
+func WithHeader(key, value string) RequestOption {
+	return func(r *http.Request) {
+		r.Header.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey makes a request that creates a movie, list, webhook or user safe
+// to retry: retries with the same key and body get the response to the first request
+// instead of being handled again. Other requests ignore the key.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader("Idempotency-Key", key)
+}
+
+// WithAcceptLanguage sets the preferred languages for the display titles of movies,
+// for example "de-AT, de;q=0.9, en;q=0.5".
+func WithAcceptLanguage(languages string) RequestOption {
+	return WithHeader("Accept-Language", languages)
+}
+
+// WithRuntimeFormat sets the format of the runtimes in movie responses. Runtimes are
+// decoded from any format.
+func WithRuntimeFormat(format RuntimeFormat) RequestOption {
+	return func(r *http.Request) {
+		query := r.URL.Query()
+		query.Set("runtime_format", string(format))
+		r.URL.RawQuery = query.Encode()
+	}
+}
+
+// request describes a call to the API.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+
+	// body is sent as JSON, unless rawBody is set.
+	body        any
+	rawBody     []byte
+	contentType string
+
+	// anonymous requests are sent without a token.
+	anonymous bool
+}
+
+// do sends the request and decodes the JSON response into dst, unless dst is nil.
+func (c *Client) do(ctx context.Context, req request, dst any, opts []RequestOption) error {
+	resp, err := c.send(ctx, req, opts)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return ErrNotModified
+	}
+
+	if dst == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// send sends the request and returns the response if it succeeded, leaving the body to
+// be read and closed by the caller. Requests that hit the rate limit are retried, and
+// requests whose token is rejected are retried once with a new token when the client
+// has credentials.
+func (c *Client) send(ctx context.Context, req request, opts []RequestOption) (*http.Response, error) {
+	body := req.rawBody
+	contentType := req.contentType
+
+	if req.body != nil && body == nil {
+		js, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, err
+		}
+		body = js
+		contentType = "application/json"
+	}
+
+	reauthenticated := false
+
+	for attempt := 0; ; attempt++ {
+		token, err := c.requestToken(ctx, req.anonymous)
+		if err != nil {
+			return nil, err
+		}
+
+		httpReq, err := c.newRequest(ctx, req, body, contentType, token, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		}
+
+		errBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		err = parseError(resp.StatusCode, resp.Header, errBody)
+
+		var rateLimitErr *RateLimitError
+		var authErr *AuthError
+
+		switch {
+		case errors.As(err, &rateLimitErr) && attempt < c.maxRetries:
+			wait := rateLimitErr.RetryAfter
+			if wait == 0 {
+				wait = backoff(attempt)
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+
+		case errors.As(err, &authErr) && authErr.StatusCode == http.StatusUnauthorized &&
+			token != "" && c.email != "" && !reauthenticated:
+			// The token was revoked or expired early, get a new one.
+			c.discardToken(token)
+			reauthenticated = true
+
+		default:
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, req request, body []byte, contentType, token string, opts []RequestOption) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += req.path
+	u.RawQuery = req.query.Encode()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	for _, opt := range opts {
+		opt(httpReq)
+	}
+
+	return httpReq, nil
+}
+
+// requestToken returns the token to send with a request, authenticating first if the
+// client has credentials and no token that is still valid.
+func (c *Client) requestToken(ctx context.Context, anonymous bool) (string, error) {
+	if anonymous {
+		return "", nil
+	}
+
+	if token, ok := c.validToken(); ok || c.email == "" {
+		return token, nil
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	// Another request may have authenticated while this one was waiting.
+	if token, ok := c.validToken(); ok {
+		return token, nil
+	}
+
+	token, err := c.Authenticate(ctx, c.email, c.password)
+	if err != nil {
+		return "", err
+	}
+
+	return token.Plaintext, nil
+}
+
+// validToken returns the current token, and whether it can be used for a while yet.
+// Tokens without an expiry, such as those set with WithToken, are always assumed valid.
+func (c *Client) validToken() (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.Plaintext == "" {
+		return "", false
+	}
+
+	return c.token.Plaintext, c.token.Expiry.IsZero() || time.Until(c.token.Expiry) > tokenRefreshMargin
+}
+
+// discardToken forgets the token, unless another request has replaced it already.
+func (c *Client) discardToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.Plaintext == token {
+		c.token = Token{}
+	}
+}
+
+// backoff returns the wait before the given retry, doubling with every attempt and
+// spread out by up to half to keep clients from retrying in lockstep.
+func backoff(attempt int) time.Duration {
+	wait := min(retryBaseWait<<attempt, retryMaxWait)
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// retryAfter reads the Retry-After header, in seconds, or returns 0.
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// idPath formats a path with the ids in place of the %d verbs.
+func idPath(format string, ids ...int64) string {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return fmt.Sprintf(format, args...)
+}
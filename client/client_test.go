@@ -0,0 +1,418 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client of a test server that handles requests with handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+func TestNew(t *testing.T) {
+	for _, baseURL := range []string{"", "localhost:4000", "ftp://example.com", "http://"} {
+		if _, err := New(baseURL); err == nil {
+			t.Errorf("New(%q) accepted an invalid base URL", baseURL)
+		}
+	}
+
+	c, err := New("https://example.com/api/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.baseURL.Path != "/api" {
+		t.Errorf("base path = %q, want the trailing slash trimmed", c.baseURL.Path)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnprocessableEntity, `{"error":{"title":"must be provided","year":"must not be in the future"}}`)
+	})
+
+	_, err := c.CreateMovie(context.Background(), MovieInput{})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want a *ValidationError", err)
+	}
+
+	want := map[string]string{"title": "must be provided", "year": "must not be in the future"}
+	if len(validationErr.Fields) != len(want) {
+		t.Fatalf("Fields = %v, want %v", validationErr.Fields, want)
+	}
+	for field, message := range want {
+		if validationErr.Fields[field] != message {
+			t.Errorf("Fields[%q] = %q, want %q", field, validationErr.Fields[field], message)
+		}
+	}
+
+	if got := err.Error(); got != "gmoapi: the request failed validation: title must be provided, year must not be in the future" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestEditConflictError(t *testing.T) {
+	var requests atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1/movies/7" {
+			t.Errorf("request = %s %s, want PATCH /v1/movies/7", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusConflict, `{"error":"unable to update the record due to an edit conflict, please try again"}`)
+	})
+
+	title := "Alien"
+	_, err := c.UpdateMovie(context.Background(), 7, MovieUpdate{Title: &title})
+
+	var conflictErr *EditConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("err = %v, want an *EditConflictError", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests sent, edit conflicts must be left to the caller to retry", n)
+	}
+}
+
+func TestErrorTypes(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, `{"error":"the requested resource could not be found"}`, IsNotFound},
+		{http.StatusForbidden, `{"error":"forbidden"}`, func(err error) bool {
+			var authErr *AuthError
+			return errors.As(err, &authErr) && authErr.StatusCode == http.StatusForbidden
+		}},
+		{http.StatusConflict, `{"error":"a similar movie already exists","candidates":[3,4]}`, func(err error) bool {
+			var dupErr *DuplicateMovieError
+			return errors.As(err, &dupErr) && len(dupErr.Candidates) == 2 && dupErr.Candidates[1] == 4
+		}},
+		{http.StatusInternalServerError, `not json`, func(err error) bool {
+			var apiErr *APIError
+			return errors.As(err, &apiErr) && apiErr.Message == "internal server error"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			_, err := c.GetMovie(context.Background(), 1)
+			if !tt.check(err) {
+				t.Errorf("unexpected error %#v", err)
+			}
+		})
+	}
+}
+
+func TestRateLimitRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	var first time.Time
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			writeJSON(w, http.StatusTooManyRequests, `{"error":"rate limit exceeded"}`)
+			return
+		}
+
+		if wait := time.Since(first); wait < time.Second {
+			t.Errorf("retried after %s, want at least the 1s of Retry-After", wait)
+		}
+		writeJSON(w, http.StatusOK, `{"movie":{"id":1,"title":"Alien"}}`)
+	})
+
+	movie, err := c.GetMovie(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movie.Title != "Alien" {
+		t.Errorf("Title = %q, want Alien", movie.Title)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d requests sent, want 2", n)
+	}
+}
+
+func TestRateLimitGivesUp(t *testing.T) {
+	var requests atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, http.StatusTooManyRequests, `{"error":"rate limit exceeded"}`)
+	}, WithMaxRetries(2))
+
+	_, err := c.GetMovie(context.Background(), 1)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("err = %v, want a *RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 0 {
+		t.Errorf("RetryAfter = %s, want 0 without the header", rateLimitErr.RetryAfter)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("%d requests sent, want the request and 2 retries", n)
+	}
+}
+
+func TestRateLimitRespectsContext(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		writeJSON(w, http.StatusTooManyRequests, `{"error":"rate limit exceeded"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetMovie(ctx, 1)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the context's error", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("the retry wait ignored the context")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := range 10 {
+		wait := min(retryBaseWait<<attempt, retryMaxWait)
+
+		for range 20 {
+			got := backoff(attempt)
+			if got < wait/2 || got > wait {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", attempt, got, wait/2, wait)
+			}
+		}
+	}
+}
+
+func TestReauthenticateOnUnauthorized(t *testing.T) {
+	var logins atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tokens/authentication":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("authentication request sent with a token")
+			}
+
+			var body struct{ Email, Password string }
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Email != "alice@example.com" || body.Password != "pa55word" {
+				t.Errorf("credentials = %q %q", body.Email, body.Password)
+			}
+
+			n := logins.Add(1)
+			expiry := time.Now().Add(time.Hour).Format(time.RFC3339)
+			writeJSON(w, http.StatusCreated, fmt.Sprintf(`{"authentication_token":{"token":"TOKEN%d","expiry":%q}}`, n, expiry))
+
+		case "/v1/movies/1":
+			// The first token has been revoked.
+			if r.Header.Get("Authorization") != "Bearer TOKEN2" {
+				writeJSON(w, http.StatusUnauthorized, `{"error":"invalid or missing authentication token"}`)
+				return
+			}
+			writeJSON(w, http.StatusOK, `{"movie":{"id":1,"title":"Alien"}}`)
+		}
+	}, WithCredentials("alice@example.com", "pa55word"))
+
+	movie, err := c.GetMovie(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movie.ID != 1 {
+		t.Errorf("ID = %d, want 1", movie.ID)
+	}
+	if n := logins.Load(); n != 2 {
+		t.Errorf("authenticated %d times, want 2", n)
+	}
+	if token := c.Token().Plaintext; token != "TOKEN2" {
+		t.Errorf("token = %q, want the new token", token)
+	}
+}
+
+func TestReauthenticateOnlyOnce(t *testing.T) {
+	var logins, requests atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/tokens/authentication" {
+			logins.Add(1)
+			writeJSON(w, http.StatusCreated, `{"authentication_token":{"token":"TOKEN","expiry":"2100-01-01T00:00:00Z"}}`)
+			return
+		}
+
+		requests.Add(1)
+		writeJSON(w, http.StatusUnauthorized, `{"error":"invalid or missing authentication token"}`)
+	}, WithCredentials("alice@example.com", "pa55word"))
+
+	_, err := c.GetMovie(context.Background(), 1)
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("err = %v, want a 401 *AuthError", err)
+	}
+	if n := logins.Load(); n != 2 {
+		t.Errorf("authenticated %d times, want 2", n)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d requests sent, want 2", n)
+	}
+}
+
+func TestNoReauthenticationWithoutCredentials(t *testing.T) {
+	var requests atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, http.StatusUnauthorized, `{"error":"invalid or missing authentication token"}`)
+	}, WithToken("STATIC"))
+
+	_, err := c.GetMovie(context.Background(), 1)
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("err = %v, want an *AuthError", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests sent, want 1", n)
+	}
+}
+
+func TestMoviesIterator(t *testing.T) {
+	const total, pageSize = 7, 3
+	lastPage := (total + pageSize - 1) / pageSize
+
+	var pages []int
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, page)
+
+		if size := r.URL.Query().Get("page_size"); size != strconv.Itoa(pageSize) {
+			t.Errorf("page_size = %q, want %d", size, pageSize)
+		}
+		if title := r.URL.Query().Get("title"); title != "alien" {
+			t.Errorf("title = %q, the filter was lost across pages", title)
+		}
+
+		var movies []map[string]any
+		for id := (page-1)*pageSize + 1; id <= min(page*pageSize, total); id++ {
+			movies = append(movies, map[string]any{"id": id, "title": "Alien " + strconv.Itoa(id)})
+		}
+
+		js, _ := json.Marshal(map[string]any{
+			"movies": movies,
+			"metadata": Metadata{
+				CurrentPage:  page,
+				PageSize:     pageSize,
+				FirstPage:    1,
+				LastPage:     lastPage,
+				TotalRecords: total,
+			},
+		})
+		writeJSON(w, http.StatusOK, string(js))
+	})
+
+	filter := MovieFilter{Title: "alien", PageFilter: PageFilter{PageSize: pageSize}}
+
+	var ids []int64
+	for movie, err := range c.Movies(context.Background(), filter) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, movie.ID)
+	}
+
+	if len(ids) != total {
+		t.Fatalf("got %d movies, want %d: %v", len(ids), total, ids)
+	}
+	for i, id := range ids {
+		if id != int64(i+1) {
+			t.Fatalf("movies out of order: %v", ids)
+		}
+	}
+	if len(pages) != lastPage || pages[0] != 1 || pages[lastPage-1] != lastPage {
+		t.Errorf("fetched pages %v, want 1 to %d", pages, lastPage)
+	}
+}
+
+func TestMoviesIteratorStopsEarly(t *testing.T) {
+	var requests atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, http.StatusOK, `{"movies":[{"id":1},{"id":2}],"metadata":{"current_page":1,"last_page":5}}`)
+	})
+
+	for movie, err := range c.Movies(context.Background(), MovieFilter{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if movie.ID == 1 {
+			break
+		}
+	}
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d pages fetched after breaking out of the loop, want 1", n)
+	}
+}
+
+func TestMoviesIteratorError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(w, http.StatusInternalServerError, `{"error":"the server encountered a problem"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"movies":[{"id":1}],"metadata":{"current_page":1,"last_page":3}}`)
+	})
+
+	var ids []int64
+	var errs []error
+	for movie, err := range c.Movies(context.Background(), MovieFilter{}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, movie.ID)
+	}
+
+	if len(ids) != 1 || len(errs) != 1 {
+		t.Errorf("got movies %v and errors %v, want one of each", ids, errs)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrNotModified is returned for a conditional request, such as a GetMovie with an
+// If-Modified-Since header, when the resource hasn't changed.
+var ErrNotModified = errors.New("gmoapi: not modified")
+
+// APIError is an error response of the API that isn't covered by one of the more
+// specific error types below.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gmoapi: %s (%d)", e.Message, e.StatusCode)
+}
+
+// ValidationError is returned when the API rejects the input of a request, with a
+// message per invalid field.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	for i, field := range fields {
+		fields[i] = field + " " + e.Fields[field]
+	}
+
+	return "gmoapi: the request failed validation: " + strings.Join(fields, ", ")
+}
+
+// EditConflictError is returned when a record was changed by someone else while it was
+// being updated. The update can be retried.
+type EditConflictError struct {
+	Message string
+}
+
+func (e *EditConflictError) Error() string {
+	return "gmoapi: " + e.Message
+}
+
+// DuplicateMovieError is returned when a new movie looks like a movie that is already
+// in the catalog. Candidates are the ids of the likely duplicates.
+type DuplicateMovieError struct {
+	Message    string
+	Candidates []int64
+}
+
+func (e *DuplicateMovieError) Error() string {
+	return fmt.Sprintf("gmoapi: %s: %v", e.Message, e.Candidates)
+}
+
+// RateLimitError is returned when a request hit the rate limit, and still did after
+// the retries of the client.
+type RateLimitError struct {
+	Message string
+
+	// RetryAfter is how long the API asked to wait before retrying, 0 if it didn't.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return "gmoapi: " + e.Message
+}
+
+// AuthError is returned when a request is missing authentication, has an invalid
+// token or credentials (401), or isn't permitted for the user (403).
+type AuthError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("gmoapi: %s (%d)", e.Message, e.StatusCode)
+}
+
+// IsNotFound reports whether err says that the requested resource doesn't exist.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// parseError turns an error response into the matching error type. Error responses
+// carry either a message or a message per field in "error", GraphQL requests that
+// can't be executed carry "errors" instead.
+func parseError(status int, header http.Header, body []byte) error {
+	var env struct {
+		Error      json.RawMessage `json:"error"`
+		Candidates []int64         `json:"candidates"`
+		Errors     GraphQLErrors   `json:"errors"`
+	}
+	_ = json.Unmarshal(body, &env)
+
+	var (
+		message string
+		fields  map[string]string
+	)
+	if err := json.Unmarshal(env.Error, &message); err != nil {
+		_ = json.Unmarshal(env.Error, &fields)
+	}
+	if message == "" {
+		message = strings.ToLower(http.StatusText(status))
+	}
+
+	switch {
+	case status == http.StatusUnprocessableEntity && fields != nil:
+		return &ValidationError{Fields: fields}
+	case status == http.StatusConflict && env.Candidates != nil:
+		return &DuplicateMovieError{Message: message, Candidates: env.Candidates}
+	case status == http.StatusConflict && strings.Contains(message, "edit conflict"):
+		return &EditConflictError{Message: message}
+	case status == http.StatusTooManyRequests:
+		return &RateLimitError{Message: message, RetryAfter: retryAfter(header)}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &AuthError{StatusCode: status, Message: message}
+	case len(env.Errors) > 0:
+		return env.Errors
+	default:
+		return &APIError{StatusCode: status, Message: message}
+	}
+}
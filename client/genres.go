@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// GenreInput is a new genre.
+type GenreInput struct {
+	Slug    string   `json:"slug"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// GenreUpdate changes the fields of a genre that aren't nil. Aliases replaces all the
+// aliases of the genre.
+type GenreUpdate struct {
+	Slug    *string  `json:"slug,omitempty"`
+	Name    *string  `json:"name,omitempty"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// ListGenres returns all genres.
+func (c *Client) ListGenres(ctx context.Context, opts ...RequestOption) ([]*Genre, error) {
+	var resp struct {
+		Genres []*Genre `json:"genres"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/genres"}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Genres, nil
+}
+
+// GetGenre returns the genre.
+func (c *Client) GetGenre(ctx context.Context, id int64, opts ...RequestOption) (*Genre, error) {
+	var resp struct {
+		Genre *Genre `json:"genre"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/genres/%d", id)}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Genre, nil
+}
+
+// CreateGenre adds a genre to the taxonomy.
+func (c *Client) CreateGenre(ctx context.Context, input GenreInput, opts ...RequestOption) (*Genre, error) {
+	var resp struct {
+		Genre *Genre `json:"genre"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/genres", body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Genre, nil
+}
+
+// UpdateGenre changes the genre.
+func (c *Client) UpdateGenre(ctx context.Context, id int64, input GenreUpdate, opts ...RequestOption) (*Genre, error) {
+	var resp struct {
+		Genre *Genre `json:"genre"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: idPath("/v1/genres/%d", id), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Genre, nil
+}
+
+// DeleteGenre removes the genre from the taxonomy.
+func (c *Client) DeleteGenre(ctx context.Context, id int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/genres/%d", id)}, nil, opts)
+}
+
+// MergeGenre merges the genre id into the genre into, and returns the latter.
+func (c *Client) MergeGenre(ctx context.Context, id, into int64, opts ...RequestOption) (*Genre, error) {
+	body := struct {
+		Into int64 `json:"into"`
+	}{into}
+
+	var resp struct {
+		Genre *Genre `json:"genre"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: idPath("/v1/genres/%d/merge", id), body: body}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Genre, nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// GraphQLRequest is a GraphQL query or mutation.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLError is an error of a GraphQL operation. The code in the extensions tells
+// the kind of error, for example FORBIDDEN or FAILED_VALIDATION.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors are the errors of a GraphQL operation.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+
+	return "gmoapi: graphql: " + strings.Join(messages, "; ")
+}
+
+// GraphQL runs the operation and decodes its data into dst, unless dst is nil. The
+// errors of the fields that failed are returned as GraphQLErrors, along with the data
+// of the fields that didn't.
+func (c *Client) GraphQL(ctx context.Context, req GraphQLRequest, dst any, opts ...RequestOption) error {
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/graphql", body: req}, &resp, opts)
+	if err != nil {
+		return err
+	}
+
+	if dst != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		err = json.Unmarshal(resp.Data, dst)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+
+	return nil
+}
+
+// BatchRequest is one of the requests of a batch. Body is sent as JSON.
+type BatchRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    any               `json:"body,omitempty"`
+}
+
+// BatchResponse is the response to one of the requests of a batch.
+type BatchResponse struct {
+	Status  int             `json:"status"`
+	Headers http.Header     `json:"headers"`
+	Body    json.RawMessage `json:"body"`
+}
+
+// Decode decodes the body of a successful response into dst, and returns the error of
+// a failed one like the other methods of the client do.
+func (r *BatchResponse) Decode(dst any) error {
+	if r.Status >= http.StatusBadRequest {
+		return parseError(r.Status, r.Headers, r.Body)
+	}
+
+	if dst == nil || len(r.Body) == 0 || string(r.Body) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(r.Body, dst)
+}
+
+// Batch sends the requests in one round trip, one after the other, or all at once if
+// parallel is set, and returns their responses in order. The requests are sent with
+// the token of the client.
+func (c *Client) Batch(ctx context.Context, requests []BatchRequest, parallel bool, opts ...RequestOption) ([]BatchResponse, error) {
+	body := struct {
+		Requests []BatchRequest `json:"requests"`
+		Parallel bool           `json:"parallel"`
+	}{requests, parallel}
+
+	var resp struct {
+		Responses []BatchResponse `json:"responses"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/batch", body: body}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Responses, nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Health is the status of the API.
+type Health struct {
+	Status     string `json:"status"`
+	SystemInfo struct {
+		Environment string `json:"environment"`
+		Version     string `json:"version"`
+	} `json:"system_info"`
+}
+
+// Healthcheck returns the status of the API.
+func (c *Client) Healthcheck(ctx context.Context, opts ...RequestOption) (*Health, error) {
+	var health Health
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1", anonymous: true}, &health, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
+// Metrics returns the metrics of the API by name.
+func (c *Client) Metrics(ctx context.Context, opts ...RequestOption) (map[string]json.RawMessage, error) {
+	var metrics map[string]json.RawMessage
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/debug/vars"}, &metrics, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
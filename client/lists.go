@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ListInput is a new movie list.
+type ListInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Visibility is private, unlisted or public.
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// ListUpdate changes the fields of a list that aren't nil.
+type ListUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Visibility  *string `json:"visibility,omitempty"`
+}
+
+// ListItemInput adds a movie to a list, at Position (1-based), or at the end of the
+// list when Position is 0.
+type ListItemInput struct {
+	MovieID  int64  `json:"movie_id"`
+	Position int    `json:"position,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+// ListItemUpdate changes the fields of a list item that aren't nil.
+type ListItemUpdate struct {
+	Position *int    `json:"position,omitempty"`
+	Note     *string `json:"note,omitempty"`
+}
+
+// ListLists returns a page of the lists of the user.
+func (c *Client) ListLists(ctx context.Context, filter PageFilter, opts ...RequestOption) ([]*List, Metadata, error) {
+	var resp struct {
+		Lists    []*List  `json:"lists"`
+		Metadata Metadata `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/lists", query: filter.values()}, &resp, opts)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return resp.Lists, resp.Metadata, nil
+}
+
+// CreateList adds a list for the user.
+func (c *Client) CreateList(ctx context.Context, input ListInput, opts ...RequestOption) (*List, error) {
+	var resp struct {
+		List *List `json:"list"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/lists", body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.List, nil
+}
+
+// GetList returns the list. The share token is needed for unlisted lists of other
+// users, and can be empty otherwise.
+func (c *Client) GetList(ctx context.Context, id int64, shareToken string, opts ...RequestOption) (*List, error) {
+	query := url.Values{}
+	setString(query, "share_token", shareToken)
+
+	var resp struct {
+		List *List `json:"list"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/lists/%d", id), query: query}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.List, nil
+}
+
+// UpdateList changes the list.
+func (c *Client) UpdateList(ctx context.Context, id int64, input ListUpdate, opts ...RequestOption) (*List, error) {
+	var resp struct {
+		List *List `json:"list"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: idPath("/v1/lists/%d", id), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.List, nil
+}
+
+// DeleteList removes the list.
+func (c *Client) DeleteList(ctx context.Context, id int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/lists/%d", id)}, nil, opts)
+}
+
+// ListListItems returns the items of the list in order. The share token is needed for
+// unlisted lists of other users, and can be empty otherwise.
+func (c *Client) ListListItems(ctx context.Context, id int64, shareToken string, opts ...RequestOption) ([]*ListItem, error) {
+	query := url.Values{}
+	setString(query, "share_token", shareToken)
+
+	var resp struct {
+		Items []*ListItem `json:"items"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/lists/%d/items", id), query: query}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Items, nil
+}
+
+// AddListItem adds a movie to the list.
+func (c *Client) AddListItem(ctx context.Context, id int64, input ListItemInput, opts ...RequestOption) (*ListItem, error) {
+	var resp struct {
+		Item *ListItem `json:"item"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: idPath("/v1/lists/%d/items", id), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Item, nil
+}
+
+// UpdateListItem moves the movie within the list or changes its note.
+func (c *Client) UpdateListItem(ctx context.Context, id, movieID int64, input ListItemUpdate, opts ...RequestOption) (*ListItem, error) {
+	var resp struct {
+		Item *ListItem `json:"item"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: idPath("/v1/lists/%d/items/%d", id, movieID), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Item, nil
+}
+
+// RemoveListItem removes the movie from the list.
+func (c *Client) RemoveListItem(ctx context.Context, id, movieID int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/lists/%d/items/%d", id, movieID)}, nil, opts)
+}
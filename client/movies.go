@@ -0,0 +1,391 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MovieFilter selects the movies of ListMovies. Zero values leave a filter out.
+type MovieFilter struct {
+	// Title matches movies whose title contains it, ignoring case.
+	Title    string
+	Genres   []string
+	Statuses []string
+
+	// CertificationMax is the most restrictive certification to include in Country.
+	CertificationMax string
+	Country          string
+
+	IMDb     string
+	TMDb     int64
+	Wikidata string
+
+	// UpdatedSince only includes movies updated at or after it.
+	UpdatedSince time.Time
+
+	PageFilter
+}
+
+func (f MovieFilter) values() url.Values {
+	query := f.PageFilter.values()
+	setString(query, "title", f.Title)
+	setCSV(query, "genres", f.Genres)
+	setCSV(query, "status", f.Statuses)
+	setString(query, "certification_max", f.CertificationMax)
+	setString(query, "country", f.Country)
+	setString(query, "imdb", f.IMDb)
+	setInt(query, "tmdb", f.TMDb)
+	setString(query, "wikidata", f.Wikidata)
+	if !f.UpdatedSince.IsZero() {
+		query.Set("updated_since", f.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	return query
+}
+
+// MovieInput is a new movie.
+type MovieInput struct {
+	Title       string       `json:"title"`
+	Year        int32        `json:"year,omitempty"`
+	Runtime     Runtime      `json:"runtime,omitempty"`
+	Genres      []string     `json:"genres"`
+	Status      string       `json:"status,omitempty"`
+	ReleaseDate *Date        `json:"release_date,omitempty"`
+	ExternalIDs *ExternalIDs `json:"external_ids,omitempty"`
+
+	// Force creates the movie even if it looks like a movie already in the catalog.
+	Force bool `json:"-"`
+}
+
+// MovieUpdate changes the fields of a movie that aren't nil. ExternalIDs replaces all
+// the external ids of the movie.
+type MovieUpdate struct {
+	Title       *string      `json:"title,omitempty"`
+	Year        *int32       `json:"year,omitempty"`
+	Runtime     *Runtime     `json:"runtime,omitempty"`
+	Genres      []string     `json:"genres,omitempty"`
+	Status      *string      `json:"status,omitempty"`
+	ReleaseDate *Date        `json:"release_date,omitempty"`
+	ExternalIDs *ExternalIDs `json:"external_ids,omitempty"`
+
+	// ClearReleaseDate removes the release date of the movie. ReleaseDate is ignored
+	// when it is set.
+	ClearReleaseDate bool `json:"-"`
+}
+
+func (u MovieUpdate) MarshalJSON() ([]byte, error) {
+	type update MovieUpdate
+	if !u.ClearReleaseDate {
+		return json.Marshal(update(u))
+	}
+
+	// The release_date field outside of the embedded update wins, and is sent as null.
+	return json.Marshal(struct {
+		update
+		ReleaseDate *Date `json:"release_date"`
+	}{update: update(u)})
+}
+
+// MovieReleaseInput is a new release of a movie.
+type MovieReleaseInput struct {
+	Country       string `json:"country"`
+	Type          string `json:"type"`
+	ReleaseDate   Date   `json:"release_date"`
+	Certification string `json:"certification,omitempty"`
+}
+
+// MovieTitleInput is a new alternate title of a movie.
+type MovieTitleInput struct {
+	Title    string `json:"title"`
+	Language string `json:"language"`
+	Region   string `json:"region,omitempty"`
+	Type     string `json:"type"`
+}
+
+// ListMovies returns a page of the movies that match the filter.
+func (c *Client) ListMovies(ctx context.Context, filter MovieFilter, opts ...RequestOption) ([]*Movie, Metadata, error) {
+	var resp struct {
+		Movies   []*Movie `json:"movies"`
+		Metadata Metadata `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/movies", query: filter.values()}, &resp, opts)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return resp.Movies, resp.Metadata, nil
+}
+
+// Movies iterates over all the movies that match the filter, starting at filter.Page,
+// and fetches the pages as it goes. Iteration stops after the first error. Movies that
+// are added or removed while iterating can shift the pages, which can make a movie be
+// skipped or seen twice unless the movies are sorted by id.
+func (c *Client) Movies(ctx context.Context, filter MovieFilter, opts ...RequestOption) iter.Seq2[*Movie, error] {
+	return func(yield func(*Movie, error) bool) {
+		filter.Page = max(filter.Page, 1)
+
+		for {
+			movies, metadata, err := c.ListMovies(ctx, filter, opts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, movie := range movies {
+				if !yield(movie, nil) {
+					return
+				}
+			}
+
+			if len(movies) == 0 || metadata.CurrentPage >= metadata.LastPage {
+				return
+			}
+
+			filter.Page++
+		}
+	}
+}
+
+// GetMovie returns the movie. A movie that was merged into another one returns the
+// other movie. With an If-Modified-Since header, ErrNotModified is returned if the
+// movie hasn't changed since.
+func (c *Client) GetMovie(ctx context.Context, id int64, opts ...RequestOption) (*Movie, error) {
+	var resp struct {
+		Movie *Movie `json:"movie"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/movies/%d", id)}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movie, nil
+}
+
+// LookupMovie returns the movie with the external ids that are set.
+func (c *Client) LookupMovie(ctx context.Context, ids ExternalIDs, opts ...RequestOption) (*Movie, error) {
+	query := url.Values{}
+	setString(query, "imdb", ids.IMDb)
+	setInt(query, "tmdb", ids.TMDb)
+	setString(query, "wikidata", ids.Wikidata)
+
+	var resp struct {
+		Movie *Movie `json:"movie"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/movies/lookup", query: query}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movie, nil
+}
+
+// CreateMovie adds the movie to the catalog. A movie that looks like one already in
+// the catalog is rejected with a DuplicateMovieError, unless input.Force is set.
+func (c *Client) CreateMovie(ctx context.Context, input MovieInput, opts ...RequestOption) (*Movie, error) {
+	query := url.Values{}
+	if input.Force {
+		query.Set("force", "true")
+	}
+
+	var resp struct {
+		Movie *Movie `json:"movie"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/movies", query: query, body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movie, nil
+}
+
+// UpdateMovie changes the movie.
+func (c *Client) UpdateMovie(ctx context.Context, id int64, input MovieUpdate, opts ...RequestOption) (*Movie, error) {
+	var resp struct {
+		Movie *Movie `json:"movie"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: idPath("/v1/movies/%d", id), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movie, nil
+}
+
+// DeleteMovie removes the movie from the catalog.
+func (c *Client) DeleteMovie(ctx context.Context, id int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/movies/%d", id)}, nil, opts)
+}
+
+// ListSimilarMovies returns up to limit movies similar to the movie, leaving out the
+// excluded ones. A zero limit uses the default of the API.
+func (c *Client) ListSimilarMovies(ctx context.Context, id int64, limit int, exclude []int64, opts ...RequestOption) ([]*Movie, error) {
+	query := url.Values{}
+	setInt(query, "limit", int64(limit))
+	if len(exclude) > 0 {
+		ids := make([]string, len(exclude))
+		for i, id := range exclude {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+		query.Set("exclude", strings.Join(ids, ","))
+	}
+
+	var resp struct {
+		Movies []*Movie `json:"movies"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/movies/%d/similar", id), query: query}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movies, nil
+}
+
+// MergeMovie merges the duplicate movie id into the movie into, and returns the latter.
+func (c *Client) MergeMovie(ctx context.Context, id, into int64, opts ...RequestOption) (*Movie, error) {
+	body := struct {
+		Into int64 `json:"into"`
+	}{into}
+
+	var resp struct {
+		Movie *Movie `json:"movie"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: idPath("/v1/movies/%d/merge", id), body: body}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movie, nil
+}
+
+// UploadMoviePoster replaces the poster of the movie with the JPEG or PNG image read
+// from image.
+func (c *Client) UploadMoviePoster(ctx context.Context, id int64, filename string, image io.Reader, opts ...RequestOption) (*Movie, error) {
+	return c.uploadMovieImage(ctx, idPath("/v1/movies/%d/poster", id), filename, image, opts)
+}
+
+// UploadMovieBackdrop replaces the backdrop of the movie with the JPEG or PNG image
+// read from image.
+func (c *Client) UploadMovieBackdrop(ctx context.Context, id int64, filename string, image io.Reader, opts ...RequestOption) (*Movie, error) {
+	return c.uploadMovieImage(ctx, idPath("/v1/movies/%d/backdrop", id), filename, image, opts)
+}
+
+func (c *Client) uploadMovieImage(ctx context.Context, path, filename string, image io.Reader, opts []RequestOption) (*Movie, error) {
+	// The form is buffered so that it can be sent again when the request is retried.
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile("image", filename)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(part, image)
+	if err != nil {
+		return nil, err
+	}
+
+	err = form.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Movie *Movie `json:"movie"`
+	}
+
+	req := request{
+		method:      http.MethodPut,
+		path:        path,
+		rawBody:     body.Bytes(),
+		contentType: form.FormDataContentType(),
+	}
+
+	err = c.do(ctx, req, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Movie, nil
+}
+
+// ListMovieReleases returns the releases of the movie.
+func (c *Client) ListMovieReleases(ctx context.Context, movieID int64, opts ...RequestOption) ([]*MovieRelease, error) {
+	var resp struct {
+		Releases []*MovieRelease `json:"releases"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/movies/%d/releases", movieID)}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Releases, nil
+}
+
+// CreateMovieRelease adds a release to the movie.
+func (c *Client) CreateMovieRelease(ctx context.Context, movieID int64, input MovieReleaseInput, opts ...RequestOption) (*MovieRelease, error) {
+	var resp struct {
+		Release *MovieRelease `json:"release"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: idPath("/v1/movies/%d/releases", movieID), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Release, nil
+}
+
+// DeleteMovieRelease removes a release of the movie.
+func (c *Client) DeleteMovieRelease(ctx context.Context, movieID, releaseID int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/movies/%d/releases/%d", movieID, releaseID)}, nil, opts)
+}
+
+// ListMovieTitles returns the alternate titles of the movie.
+func (c *Client) ListMovieTitles(ctx context.Context, movieID int64, opts ...RequestOption) ([]*MovieTitle, error) {
+	var resp struct {
+		Titles []*MovieTitle `json:"titles"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/movies/%d/titles", movieID)}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Titles, nil
+}
+
+// CreateMovieTitle adds an alternate title to the movie.
+func (c *Client) CreateMovieTitle(ctx context.Context, movieID int64, input MovieTitleInput, opts ...RequestOption) (*MovieTitle, error) {
+	var resp struct {
+		Title *MovieTitle `json:"title"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: idPath("/v1/movies/%d/titles", movieID), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Title, nil
+}
+
+// DeleteMovieTitle removes an alternate title of the movie.
+func (c *Client) DeleteMovieTitle(ctx context.Context, movieID, titleID int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/movies/%d/titles/%d", movieID, titleID)}, nil, opts)
+}
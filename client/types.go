@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+)
+
+// The resources of the API are the types the API itself encodes them from, so that
+// they decode the same way, runtimes and dates included.
+type (
+	Movie           = data.Movie
+	Runtime         = data.Runtime
+	RuntimeFormat   = data.RuntimeFormat
+	Date            = data.Date
+	ExternalIDs     = data.ExternalIDs
+	ImageURLs       = data.ImageURLs
+	Metadata        = data.Metadata
+	MovieRelease    = data.MovieRelease
+	MovieTitle      = data.MovieTitle
+	MovieChange     = data.MovieChange
+	Genre           = data.Genre
+	List            = data.List
+	ListItem        = data.ListItem
+	User            = data.User
+	Token           = data.Token
+	Webhook         = data.Webhook
+	WebhookDelivery = data.WebhookDelivery
+)
+
+// The formats of runtimes in responses, see WithRuntimeFormat.
+const (
+	RuntimeMins       = data.RuntimeMins
+	RuntimeMinutesInt = data.RuntimeMinutesInt
+	RuntimeISO8601    = data.RuntimeISO8601
+	RuntimeHuman      = data.RuntimeHuman
+)
+
+// NewDate returns the date that t falls on.
+func NewDate(t time.Time) Date {
+	return data.NewDate(t)
+}
+
+// Ptr returns a pointer to v, for the optional fields of updates.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// PageFilter selects a page of a paginated listing. Zero values use the defaults of the
+// API.
+type PageFilter struct {
+	Page     int
+	PageSize int
+
+	// Sort is the field to sort by, prefixed with "-" for descending order.
+	Sort string
+}
+
+func (f PageFilter) values() url.Values {
+	query := url.Values{}
+	setInt(query, "page", int64(f.Page))
+	setInt(query, "page_size", int64(f.PageSize))
+	setString(query, "sort", f.Sort)
+	return query
+}
+
+func setString(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func setInt(query url.Values, key string, value int64) {
+	if value != 0 {
+		query.Set(key, strconv.FormatInt(value, 10))
+	}
+}
+
+func setCSV(query url.Values, key string, values []string) {
+	if len(values) > 0 {
+		query.Set(key, strings.Join(values, ","))
+	}
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// RegisterUserInput is a new user account.
+type RegisterUserInput struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RegisterUser creates a user account, and sends the activation token to its email
+// address. Send WithIdempotencyKey to make retries safe.
+func (c *Client) RegisterUser(ctx context.Context, input RegisterUserInput, opts ...RequestOption) (*User, error) {
+	var resp struct {
+		User *User `json:"user"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/users/register", body: input, anonymous: true}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.User, nil
+}
+
+// ActivateUser activates the account the activation token was sent for.
+func (c *Client) ActivateUser(ctx context.Context, token string, opts ...RequestOption) (*User, error) {
+	body := struct {
+		Token string `json:"token"`
+	}{token}
+
+	var resp struct {
+		User *User `json:"user"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPut, path: "/v1/users/activated", body: body, anonymous: true}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.User, nil
+}
+
+// ResetPassword sets a new password for the account the password reset token was sent
+// for.
+func (c *Client) ResetPassword(ctx context.Context, token, password string, opts ...RequestOption) error {
+	body := struct {
+		Password string `json:"password"`
+		Token    string `json:"token"`
+	}{password, token}
+
+	return c.do(ctx, request{method: http.MethodPut, path: "/v1/users/password", body: body, anonymous: true}, nil, opts)
+}
+
+// CurrentUserUpdate changes the authenticated user. Nil fields are left unchanged.
+type CurrentUserUpdate struct {
+	Name *string `json:"name,omitempty"`
+
+	// Version is the version of the user the update is based on, as returned by
+	// GetCurrentUser. When the user has changed since, the update fails with an
+	// EditConflictError.
+	Version int `json:"version"`
+}
+
+// GetCurrentUser returns the authenticated user and the codes of their permissions.
+func (c *Client) GetCurrentUser(ctx context.Context, opts ...RequestOption) (*User, []string, error) {
+	var resp struct {
+		User        *User    `json:"user"`
+		Permissions []string `json:"permissions"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/users/me"}, &resp, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.User, resp.Permissions, nil
+}
+
+// UpdateCurrentUser changes the authenticated user.
+func (c *Client) UpdateCurrentUser(ctx context.Context, input CurrentUserUpdate, opts ...RequestOption) (*User, error) {
+	var resp struct {
+		User *User `json:"user"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: "/v1/users/me", body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.User, nil
+}
+
+// ChangePassword changes the password of the authenticated user, who must know their
+// current password. A wrong current password fails with a ValidationError. The other
+// authentication tokens of the user are revoked, the token of the client stays valid.
+func (c *Client) ChangePassword(ctx context.Context, currentPassword, password string, opts ...RequestOption) error {
+	body := struct {
+		CurrentPassword string `json:"current_password"`
+		Password        string `json:"password"`
+	}{currentPassword, password}
+
+	err := c.do(ctx, request{method: http.MethodPut, path: "/v1/users/me/password", body: body}, nil, opts)
+	if err != nil {
+		return err
+	}
+
+	// Authenticate with the new password from now on. The password is only read while
+	// authenticating, under authMu.
+	c.authMu.Lock()
+	if c.email != "" {
+		c.password = password
+	}
+	c.authMu.Unlock()
+
+	return nil
+}
+
+// ChangeEmail starts changing the email address of the authenticated user. The address
+// changes once the token sent to it is passed to ConfirmEmailChange.
+func (c *Client) ChangeEmail(ctx context.Context, email string, opts ...RequestOption) error {
+	body := struct {
+		Email string `json:"email"`
+	}{email}
+
+	return c.do(ctx, request{method: http.MethodPost, path: "/v1/users/me/email", body: body}, nil, opts)
+}
+
+// ConfirmEmailChange confirms the change of email address that the token was sent for,
+// and returns the user with their new address.
+func (c *Client) ConfirmEmailChange(ctx context.Context, token string, opts ...RequestOption) (*User, error) {
+	body := struct {
+		Token string `json:"token"`
+	}{token}
+
+	var resp struct {
+		User *User `json:"user"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPut, path: "/v1/users/me/email/confirm", body: body}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authenticate with the new address from now on, as with ChangePassword.
+	c.authMu.Lock()
+	if c.email != "" && resp.User != nil {
+		c.email = resp.User.Email
+	}
+	c.authMu.Unlock()
+
+	return resp.User, nil
+}
+
+// Authenticate exchanges the credentials of a user for an authentication token, which
+// the client sends with the requests that follow.
+func (c *Client) Authenticate(ctx context.Context, email, password string, opts ...RequestOption) (*Token, error) {
+	body := struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}{email, password}
+
+	var resp struct {
+		Token *Token `json:"authentication_token"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/tokens/authentication", body: body, anonymous: true}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Token == nil {
+		return nil, errors.New("client: response has no authentication token")
+	}
+
+	c.SetToken(*resp.Token)
+
+	return resp.Token, nil
+}
+
+// CreatePasswordResetToken sends a password reset token to the email address of an
+// activated account.
+func (c *Client) CreatePasswordResetToken(ctx context.Context, email string, opts ...RequestOption) error {
+	body := struct {
+		Email string `json:"email"`
+	}{email}
+
+	return c.do(ctx, request{method: http.MethodPost, path: "/v1/tokens/password-reset", body: body, anonymous: true}, nil, opts)
+}
+
+// CreateActivationToken sends a new activation token to the email address of an
+// account that isn't activated yet.
+func (c *Client) CreateActivationToken(ctx context.Context, email string, opts ...RequestOption) error {
+	body := struct {
+		Email string `json:"email"`
+	}{email}
+
+	return c.do(ctx, request{method: http.MethodPost, path: "/v1/tokens/activation", body: body, anonymous: true}, nil, opts)
+}
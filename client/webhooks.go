@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// WebhookInput is a new webhook.
+type WebhookInput struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookUpdate changes the fields of a webhook that aren't nil. Events replaces all
+// the events of the webhook.
+type WebhookUpdate struct {
+	URL    *string  `json:"url,omitempty"`
+	Events []string `json:"events,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// ListWebhooks returns a page of the webhooks.
+func (c *Client) ListWebhooks(ctx context.Context, filter PageFilter, opts ...RequestOption) ([]*Webhook, Metadata, error) {
+	var resp struct {
+		Webhooks []*Webhook `json:"webhooks"`
+		Metadata Metadata   `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/webhooks", query: filter.values()}, &resp, opts)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return resp.Webhooks, resp.Metadata, nil
+}
+
+// CreateWebhook adds a webhook. The returned webhook carries the secret the deliveries
+// are signed with, which isn't returned again.
+func (c *Client) CreateWebhook(ctx context.Context, input WebhookInput, opts ...RequestOption) (*Webhook, error) {
+	var resp struct {
+		Webhook *Webhook `json:"webhook"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/webhooks", body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Webhook, nil
+}
+
+// GetWebhook returns the webhook.
+func (c *Client) GetWebhook(ctx context.Context, id int64, opts ...RequestOption) (*Webhook, error) {
+	var resp struct {
+		Webhook *Webhook `json:"webhook"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/webhooks/%d", id)}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Webhook, nil
+}
+
+// UpdateWebhook changes the webhook.
+func (c *Client) UpdateWebhook(ctx context.Context, id int64, input WebhookUpdate, opts ...RequestOption) (*Webhook, error) {
+	var resp struct {
+		Webhook *Webhook `json:"webhook"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: idPath("/v1/webhooks/%d", id), body: input}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Webhook, nil
+}
+
+// DeleteWebhook removes the webhook.
+func (c *Client) DeleteWebhook(ctx context.Context, id int64, opts ...RequestOption) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: idPath("/v1/webhooks/%d", id)}, nil, opts)
+}
+
+// ListWebhookDeliveries returns a page of the deliveries of the webhook.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id int64, filter PageFilter, opts ...RequestOption) ([]*WebhookDelivery, Metadata, error) {
+	var resp struct {
+		Deliveries []*WebhookDelivery `json:"deliveries"`
+		Metadata   Metadata           `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: idPath("/v1/webhooks/%d/deliveries", id), query: filter.values()}, &resp, opts)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return resp.Deliveries, resp.Metadata, nil
+}
+
+// RedeliverWebhook queues a new delivery of the payload of a delivery, and returns it.
+func (c *Client) RedeliverWebhook(ctx context.Context, id, deliveryID int64, opts ...RequestOption) (*WebhookDelivery, error) {
+	var resp struct {
+		Delivery *WebhookDelivery `json:"delivery"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: idPath("/v1/webhooks/%d/deliveries/%d/redeliver", id, deliveryID)}, &resp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Delivery, nil
+}
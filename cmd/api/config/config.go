@@ -21,10 +21,16 @@ type Config struct {
 	Port int         `env:"GMOAPI_PORT" envDefault:"4000"`
 	Env  Environment `env:"GMOAPI_ENV" envDefault:"development"`
 
-	DB      DatabaseConfig
-	Limiter LimiterConfig
-	SMTP    SMTPConfig
-	Cors    CorsConfig
+	DB          DatabaseConfig
+	Limiter     LimiterConfig
+	SMTP        SMTPConfig
+	Cors        CorsConfig
+	Storage     StorageConfig
+	Webhooks    WebhooksConfig
+	GraphQL     GraphQLConfig
+	Batch       BatchConfig
+	Idempotency IdempotencyConfig
+	GRPC        GRPCConfig
 }
 
 // Validate validates the entire configuration
@@ -54,11 +60,46 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	// Validate storage configuration
+	if err := c.Storage.Validate(); err != nil {
+		return err
+	}
+
+	// Validate webhooks configuration
+	if err := c.Webhooks.Validate(); err != nil {
+		return err
+	}
+
+	// Validate graphql configuration
+	if err := c.GraphQL.Validate(); err != nil {
+		return err
+	}
+
+	// Validate batch configuration
+	if err := c.Batch.Validate(); err != nil {
+		return err
+	}
+
+	// Validate idempotency configuration
+	if err := c.Idempotency.Validate(); err != nil {
+		return err
+	}
+
+	// Validate grpc configuration
+	if err := c.GRPC.Validate(); err != nil {
+		return err
+	}
+
+	if c.GRPC.Port == c.Port {
+		return errors.New("grpc port must differ from the api port")
+	}
+
 	return nil
 }
 
-// NewConfig creates and validates a new configuration instance
-func NewConfig() (Config, error) {
+// FromEnv reads the configuration from environment variables only, for tools that
+// define flags of their own. The configuration is not validated.
+func FromEnv() (Config, error) {
 	cfg := Config{}
 
 	// Parse environment variables
@@ -66,8 +107,19 @@ func NewConfig() (Config, error) {
 		return cfg, fmt.Errorf("failed to parse environment variables: %w", err)
 	}
 
+	return cfg, nil
+}
+
+// NewConfig creates and validates a new configuration instance
+func NewConfig() (Config, error) {
+	cfg, err := FromEnv()
+	if err != nil {
+		return cfg, err
+	}
+
 	// Define command-line flags
 	flag.IntVar(&cfg.Port, "port", cfg.Port, "API server port")
+	flag.IntVar(&cfg.GRPC.Port, "grpc-port", cfg.GRPC.Port, "gRPC server port")
 	flag.Func("env", "Environment (development|staging|production)", func(s string) error {
 		env := Environment(strings.TrimSpace(strings.ToLower(s)))
 		if !env.IsValid() {
@@ -81,6 +133,7 @@ func NewConfig() (Config, error) {
 	flag.IntVar(&cfg.DB.MaxOpenConn, "db-max-open-conn", cfg.DB.MaxOpenConn, "PostgreSQL max open connections")
 	flag.IntVar(&cfg.DB.MaxIdleConn, "db-max-idle-conn", cfg.DB.MaxIdleConn, "PostgreSQL max idle connections")
 	flag.DurationVar(&cfg.DB.MaxIdleTime, "db-max-idle-time", cfg.DB.MaxIdleTime, "PostgreSQL max connection idle time")
+	flag.BoolVar(&cfg.DB.MigrateOnStart, "migrate-on-start", cfg.DB.MigrateOnStart, "Apply pending database migrations on start")
 
 	flag.Float64Var(&cfg.Limiter.Rps, "limiter-rps", cfg.Limiter.Rps, "Rate limiter maximum requests per second")
 	flag.IntVar(&cfg.Limiter.Burst, "limiter-burst", cfg.Limiter.Burst, "Rate limiter maximum burst")
@@ -98,11 +151,25 @@ func NewConfig() (Config, error) {
 		return nil
 	})
 
+	flag.StringVar(&cfg.Storage.Dir, "storage-dir", cfg.Storage.Dir, "Directory for uploaded files")
+	flag.StringVar(&cfg.Storage.BaseURL, "storage-base-url", cfg.Storage.BaseURL, "Public base URL of uploaded files")
+	flag.Int64Var(&cfg.Storage.MaxUploadSize, "storage-max-upload-size", cfg.Storage.MaxUploadSize, "Maximum upload size in bytes")
+
+	flag.DurationVar(&cfg.Webhooks.Timeout, "webhooks-timeout", cfg.Webhooks.Timeout, "Webhook delivery timeout")
+	flag.DurationVar(&cfg.Webhooks.PollInterval, "webhooks-poll-interval", cfg.Webhooks.PollInterval, "Interval between checks for due webhook deliveries")
+
+	flag.IntVar(&cfg.GraphQL.MaxDepth, "graphql-max-depth", cfg.GraphQL.MaxDepth, "Maximum nesting depth of GraphQL queries")
+	flag.IntVar(&cfg.GraphQL.MaxComplexity, "graphql-max-complexity", cfg.GraphQL.MaxComplexity, "Maximum complexity of GraphQL queries")
+
+	flag.IntVar(&cfg.Batch.MaxSize, "batch-max-size", cfg.Batch.MaxSize, "Maximum number of sub-requests in a batch request")
+
+	flag.DurationVar(&cfg.Idempotency.TTL, "idempotency-ttl", cfg.Idempotency.TTL, "How long idempotency keys and their responses are kept")
+
 	// Create a new version boolean flag with the default value of false.
 	displayVersion := flag.Bool("version", false, "Display version and exit")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS] [migrate up|down [VERSION]|status|version | seed [-seed N] [-movies N] [-users N]]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Configuration can be provided via environment variables or command-line flags.\n")
 		fmt.Fprintf(os.Stderr, "Command-line flags override environment variables.\n\n")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
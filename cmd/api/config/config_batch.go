@@ -0,0 +1,16 @@
+package config
+
+import "errors"
+
+type BatchConfig struct {
+	// MaxSize is the most sub-requests a batch request may contain.
+	MaxSize int `env:"GMOAPI_BATCH_MAX_SIZE" envDefault:"20"`
+}
+
+func (c *BatchConfig) Validate() error {
+	if c.MaxSize < 1 {
+		return errors.New("batch max size must be positive")
+	}
+
+	return nil
+}
@@ -10,6 +10,9 @@ type DatabaseConfig struct {
 	MaxOpenConn int           `env:"GMOAPI_DB_MAX_OPEN_CONN" envDefault:"25"`
 	MaxIdleConn int           `env:"GMOAPI_DB_MAX_IDLE_CONN" envDefault:"25"`
 	MaxIdleTime time.Duration `env:"GMOAPI_DB_MAX_IDLE_TIME" envDefault:"15m"`
+
+	// MigrateOnStart applies pending migrations when the server starts.
+	MigrateOnStart bool `env:"GMOAPI_DB_MIGRATE_ON_START" envDefault:"false"`
 }
 
 func (c *DatabaseConfig) Validate() error {
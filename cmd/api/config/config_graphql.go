@@ -0,0 +1,24 @@
+package config
+
+import "errors"
+
+type GraphQLConfig struct {
+	// MaxDepth is how deeply the fields of a GraphQL query may be nested.
+	MaxDepth int `env:"GMOAPI_GRAPHQL_MAX_DEPTH" envDefault:"6"`
+
+	// MaxComplexity is the most fields a GraphQL query may resolve, counting the
+	// fields of list items once per item that may be returned.
+	MaxComplexity int `env:"GMOAPI_GRAPHQL_MAX_COMPLEXITY" envDefault:"5000"`
+}
+
+func (c *GraphQLConfig) Validate() error {
+	if c.MaxDepth < 1 {
+		return errors.New("graphql max depth must be positive")
+	}
+
+	if c.MaxComplexity < 1 {
+		return errors.New("graphql max complexity must be positive")
+	}
+
+	return nil
+}
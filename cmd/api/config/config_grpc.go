@@ -0,0 +1,16 @@
+package config
+
+import "errors"
+
+type GRPCConfig struct {
+	// Port is the port of the gRPC server, which runs next to the REST API.
+	Port int `env:"GMOAPI_GRPC_PORT" envDefault:"4001"`
+}
+
+func (c *GRPCConfig) Validate() error {
+	if c.Port < 1 || c.Port > 65535 {
+		return errors.New("grpc port must be between 1 and 65535")
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type IdempotencyConfig struct {
+	// TTL is how long an idempotency key and the stored response are kept.
+	TTL time.Duration `env:"GMOAPI_IDEMPOTENCY_TTL" envDefault:"24h"`
+}
+
+func (c *IdempotencyConfig) Validate() error {
+	if c.TTL <= 0 {
+		return errors.New("idempotency ttl must be positive")
+	}
+
+	return nil
+}
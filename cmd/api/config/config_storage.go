@@ -0,0 +1,27 @@
+package config
+
+import "errors"
+
+type StorageConfig struct {
+	// Dir is the directory in which uploaded files are stored.
+	Dir string `env:"GMOAPI_STORAGE_DIR" envDefault:"./uploads"`
+
+	// BaseURL is the public URL under which the stored files are served. When empty,
+	// it is derived from the host and port of the API.
+	BaseURL string `env:"GMOAPI_STORAGE_BASE_URL"`
+
+	// MaxUploadSize is the maximum size in bytes of a single upload request.
+	MaxUploadSize int64 `env:"GMOAPI_STORAGE_MAX_UPLOAD_SIZE" envDefault:"10485760"`
+}
+
+func (c *StorageConfig) Validate() error {
+	if c.Dir == "" {
+		return errors.New("storage directory is required")
+	}
+
+	if c.MaxUploadSize < 1 {
+		return errors.New("storage max upload size must be positive")
+	}
+
+	return nil
+}
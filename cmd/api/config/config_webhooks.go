@@ -0,0 +1,26 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type WebhooksConfig struct {
+	// Timeout is how long a receiver has to respond to a delivery.
+	Timeout time.Duration `env:"GMOAPI_WEBHOOKS_TIMEOUT" envDefault:"10s"`
+
+	// PollInterval is how often the queue is checked for deliveries that are due.
+	PollInterval time.Duration `env:"GMOAPI_WEBHOOKS_POLL_INTERVAL" envDefault:"5s"`
+}
+
+func (c *WebhooksConfig) Validate() error {
+	if c.Timeout <= 0 {
+		return errors.New("webhooks timeout must be positive")
+	}
+
+	if c.PollInterval <= 0 {
+		return errors.New("webhooks poll interval must be positive")
+	}
+
+	return nil
+}
@@ -9,18 +9,43 @@ import (
 
 type contextKey string
 
-const userContextKey = contextKey("user")
+const (
+	userContextKey  = contextKey("user")
+	tokenContextKey = contextKey("token")
+)
 
 func (app *application) contextSetUser(r *http.Request, user *data.User) *http.Request {
-	ctx := context.WithValue(r.Context(), userContextKey, user)
-	return r.WithContext(ctx)
+	return r.WithContext(contextWithUser(r.Context(), user))
 }
 
 // The contextGetUser() retrieves the User struct from the request context.
 func (app *application) contextGetUser(r *http.Request) *data.User {
-	user, ok := r.Context().Value(userContextKey).(*data.User)
+	return userFromContext(r.Context())
+}
+
+// contextWithUser and userFromContext do the same for a plain context, as used by the
+// gRPC server.
+func contextWithUser(ctx context.Context, user *data.User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+func userFromContext(ctx context.Context) *data.User {
+	user, ok := ctx.Value(userContextKey).(*data.User)
 	if !ok {
 		panic("missing user value in request context")
 	}
 	return user
 }
+
+// contextSetToken stores the plaintext of the authentication token of the request, so
+// that handlers can tell it apart from the other tokens of the user.
+func (app *application) contextSetToken(r *http.Request, token string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), tokenContextKey, token))
+}
+
+// contextGetToken returns the authentication token of the request, or "" for anonymous
+// requests.
+func (app *application) contextGetToken(r *http.Request) string {
+	token, _ := r.Context().Value(tokenContextKey).(string)
+	return token
+}
@@ -70,14 +70,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/movies": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a paginated list of movies with optional filtering by title (full-text search) and genres. Supports sorting by multiple fields.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `\n\n**Filtering:**\n- Title: Partial match using PostgreSQL full-text search\n- Genres: Multiple genres can be specified (comma-separated)\n\n**Sorting:**\n- Prefix with ` + "`" + `-` + "`" + ` for descending order (e.g., ` + "`" + `-year` + "`" + `)\n- Available fields: id, title, year, runtime",
+        "/batch": {
+            "post": {
+                "description": "Run several API requests in one round trip. Every sub-request goes through the same routing, authentication, permission checks and rate limiting as if it had been sent on its own, with the identity of the batch request: the ` + "`" + `Authorization` + "`" + ` header of the batch is used for every sub-request and can't be overridden per sub-request.\n\nSub-requests run one after the other in the order given, so later ones see the changes of earlier ones. Set ` + "`" + `parallel` + "`" + ` to run them all at once instead. The batch succeeds even if some sub-requests fail, the outcome of each is reported by its ` + "`" + `status` + "`" + `. Bodies of JSON responses are embedded as JSON, other bodies as strings.\n\n**Limits:** A batch holds at most 20 sub-requests by default. Each sub-request counts against the rate limit, the batch itself doesn't. Paths must start with ` + "`" + `/v1` + "`" + `, and batches and event streams can't be nested in a batch.",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,102 +80,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Batch"
                 ],
-                "summary": "List All Movies",
+                "summary": "Batch Requests",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "Godfather",
-                        "description": "Filter by movie title (partial match, case-insensitive)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "drama,crime",
-                        "description": "Filter by genres (comma-separated)",
-                        "name": "genres",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 10000000,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (minimum: 1, maximum: 10,000,000)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page (minimum: 1, maximum: 100)",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "id",
-                            "title",
-                            "year",
-                            "runtime",
-                            "-id",
-                            "-title",
-                            "-year",
-                            "-runtime"
-                        ],
-                        "type": "string",
-                        "default": "id",
-                        "description": "Sort field",
-                        "name": "sort",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of movies with pagination metadata",
+                        "description": "Sub-requests to run",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
                             "type": "object",
                             "properties": {
-                                " metadata": {
-                                    "$ref": "#/definitions/data.Metadata"
+                                " parallel": {
+                                    "type": "boolean"
                                 },
-                                "movies": {
+                                "requests": {
                                     "type": "array",
                                     "items": {
-                                        "$ref": "#/definitions/data.Movie"
+                                        "type": "object",
+                                        "properties": {
+                                            " body": {
+                                                "type": "object"
+                                            },
+                                            " headers": {
+                                                "type": "object",
+                                                "additionalProperties": {
+                                                    "type": "string"
+                                                }
+                                            },
+                                            " path": {
+                                                "type": "string"
+                                            },
+                                            "method": {
+                                                "type": "string"
+                                            }
+                                        }
                                     }
                                 }
                             }
                         }
-                    },
-                    "400": {
-                        "description": "Bad request - invalid query parameters",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication token",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Responses in the order of the sub-requests",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "error": {
-                                    "type": "string"
+                                "responses": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "object",
+                                        "properties": {
+                                            " body": {
+                                                "type": "object"
+                                            },
+                                            " headers": {
+                                                "type": "object",
+                                                "additionalProperties": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "type": "string"
+                                                    }
+                                                }
+                                            },
+                                            "status": {
+                                                "type": "integer"
+                                            }
+                                        }
+                                    }
                                 }
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - user account not activated or insufficient permissions",
+                    "400": {
+                        "description": "Bad request - malformed JSON",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -204,17 +179,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "429": {
-                        "description": "Too many requests - rate limit exceeded",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -227,78 +191,58 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new movie entry in the catalog. All fields are required.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Validation Rules:**\n- Title: Required, max 500 characters\n- Year: Required, between 1888 and current year\n- Runtime: Required, positive integer, format \"123 mins\"\n- Genres: Required, 1-5 unique genres",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/changes": {
+            "get": {
+                "description": "Retrieve the log of movies created, updated and deleted, for replicating the catalog. Every change has a sequence number that increases in the order the changes were made. The log starts with every movie that existed when it was introduced.\n\nTo replicate, start with ` + "`" + `since=0` + "`" + ` and pass the ` + "`" + `next_since` + "`" + ` of each response as ` + "`" + `since` + "`" + ` of the next request. Fetch a created or updated movie with ` + "`" + `GET /v1/movies/{id}` + "`" + `; the ` + "`" + `version` + "`" + ` of a change is the movie's version right after it. Several changes of the same movie may be listed.\n\n**Long Polling:** When there are no changes after ` + "`" + `since` + "`" + `, ` + "`" + `wait` + "`" + ` (at most 60s) holds the request open until a change is made or the wait runs out, in which case an empty list is returned.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Changes"
                 ],
-                "summary": "Create New Movie (require movies:write permission)",
+                "summary": "List Movie Changes",
                 "parameters": [
                     {
-                        "description": "Movie creation data",
-                        "name": "movie",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                " genres": {
-                                    "type": "array",
-                                    "items": {
-                                        "type": "string"
-                                    }
-                                },
-                                " runtime": {
-                                    "type": "string"
-                                },
-                                " year": {
-                                    "type": "integer",
-                                    "format": "int32"
-                                },
-                                "title": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Only changes with a greater sequence number",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of changes (minimum: 1, maximum: 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "30s",
+                        "description": "How long to wait for changes when there are none yet",
+                        "name": "wait",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Movie created successfully",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "movie": {
-                                    "$ref": "#/definitions/data.Movie"
-                                }
-                            }
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "URL of the created movie"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request - malformed JSON or invalid data types",
+                    "200": {
+                        "description": "Changes ordered by sequence number",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "error": {
-                                    "type": "string"
+                                " next_since": {
+                                    "type": "integer"
+                                },
+                                "changes": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.MovieChange"
+                                    }
                                 }
                             }
                         }
@@ -361,45 +305,45 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/movies/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieve detailed information about a specific movie by its unique ID.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
+                ]
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of movies being created, updated and deleted. Every event is named after the change (` + "`" + `movie.created` + "`" + `, ` + "`" + `movie.updated` + "`" + ` or ` + "`" + `movie.deleted` + "`" + `), carries the sequence number of the change log as its ` + "`" + `id` + "`" + `, and the change as JSON ` + "`" + `data` + "`" + `: ` + "`" + `{\"seq\": 42, \"created_at\": \"...\", \"movie_id\": 1, \"type\": \"updated\", \"version\": 3}` + "`" + `.\n\nA new stream starts with the changes made after it was opened. To resume after a disconnect, send the id of the last event received in the ` + "`" + `Last-Event-ID` + "`" + ` header (browsers do this when reconnecting) or the ` + "`" + `last_event_id` + "`" + ` query parameter.\n\n**Limits:** An idle stream receives a ` + "`" + `: heartbeat` + "`" + ` comment every 15 seconds. A stream is closed after 30 minutes, clients are expected to reconnect. A user may have at most 3 streams open at once. A stream is also closed when the user loses the ` + "`" + `movies:read` + "`" + ` permission or their authentication tokens are revoked.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Movies"
+                    "Changes"
                 ],
-                "summary": "Get Movie by ID",
+                "summary": "Stream Movie Events",
                 "parameters": [
                     {
-                        "minimum": 1,
                         "type": "integer",
-                        "example": 1,
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": 42,
+                        "description": "Sequence number of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 42,
+                        "description": "Same as the Last-Event-ID header, for clients that can't set headers",
+                        "name": "last_event_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movie details",
+                        "description": "Stream of events",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "movie": {
-                                    "$ref": "#/definitions/data.Movie"
-                                }
-                            }
+                            "type": "string"
                         }
                     },
                     "401": {
@@ -424,19 +368,22 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Movie not found",
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "error": {
-                                    "type": "string"
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
                                 }
                             }
                         }
                     },
                     "429": {
-                        "description": "Too many requests - rate limit exceeded",
+                        "description": "Too many requests - rate limit exceeded or too many open streams",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -457,41 +404,35 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Permanently delete a movie from the catalog by its ID. This action cannot be undone.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `",
+                ]
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Retrieve the genre taxonomy. Movies are tagged with genre slugs, and the names and aliases of a genre are accepted wherever a genre is expected.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
-                ],
-                "summary": "Delete Movie (require movies:write permission)",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Genres"
                 ],
+                "summary": "List All Genres",
                 "responses": {
                     "200": {
-                        "description": "Movie deleted successfully",
+                        "description": "List of genres ordered by slug",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
-                                    "type": "string"
+                                "genres": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.Genre"
+                                    }
                                 }
                             }
                         }
@@ -518,19 +459,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Movie not found",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    },
-                    "429": {
-                        "description": "Too many requests - rate limit exceeded",
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -551,15 +481,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update an existing movie using partial update (PATCH). Only provided fields will be updated. Uses optimistic locking to prevent concurrent modification conflicts.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Validation Rules:** Same as create operation\n\n**Concurrency Control:** Uses version field for optimistic locking. If the movie has been modified by another request, a 409 Conflict will be returned.",
+                ]
+            },
+            "post": {
+                "description": "Add a genre to the taxonomy.\n\n**Permissions Required:** ` + "`" + `genres:write` + "`" + `\n\n**Validation Rules:**\n- Slug: Required, lowercase letters, digits and single hyphens, max 100 characters, unique\n- Name: Required, max 100 characters\n- Aliases: Optional, max 20 unique values\n- The slug, name and aliases must not already refer to another genre",
                 "consumes": [
                     "application/json"
                 ],
@@ -567,41 +497,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Genres"
                 ],
-                "summary": "Update Movie (require movies:write permission)",
+                "summary": "Create Genre (require genres:write permission)",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Movie update data (all fields optional)",
-                        "name": "movie",
+                        "description": "Genre creation data",
+                        "name": "genre",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
                             "properties": {
-                                " genres": {
+                                " aliases": {
                                     "type": "array",
                                     "items": {
                                         "type": "string"
                                     }
                                 },
-                                " runtime": {
+                                " name": {
                                     "type": "string"
                                 },
-                                " year": {
-                                    "type": "integer",
-                                    "format": "int32"
-                                },
-                                "title": {
+                                "slug": {
                                     "type": "string"
                                 }
                             }
@@ -609,15 +526,21 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Movie updated successfully",
+                    "201": {
+                        "description": "Genre created successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "movie": {
-                                    "$ref": "#/definitions/data.Movie"
+                                "genre": {
+                                    "$ref": "#/definitions/data.Genre"
                                 }
                             }
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created genre"
+                            }
                         }
                     },
                     "400": {
@@ -653,28 +576,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Movie not found",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    },
-                    "409": {
-                        "description": "Edit conflict - movie has been modified by another request",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable entity - validation errors",
                         "schema": {
@@ -711,52 +612,60 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/tokens/activation": {
-            "post": {
-                "description": "Request a new activation token to be sent via email. Useful if the original token expired or was lost. The token is valid for 3 days. This endpoint cannot be used if the account is already activated.\n\n**Email Delivery:** Token is sent to the email address registered in the system (not the one provided in request).\n\n**Token Lifetime:** 3 days",
-                "consumes": [
-                    "application/json"
-                ],
+        "/genres/{id}": {
+            "get": {
+                "description": "Retrieve a single genre of the taxonomy.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "Genres"
                 ],
-                "summary": "Resend Activation Token",
+                "summary": "Get Genre by ID",
                 "parameters": [
                     {
-                        "description": "User email address",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Genre details",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "email": {
-                                    "type": "string"
+                                "genre": {
+                                    "$ref": "#/definitions/data.Genre"
                                 }
                             }
                         }
-                    }
-                ],
-                "responses": {
-                    "202": {
-                        "description": "Activation email will be sent",
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad request - malformed JSON",
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -766,16 +675,13 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity - email not found or already activated",
+                    "404": {
+                        "description": "Genre not found",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "error": {
-                                    "type": "object",
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    }
+                                    "type": "string"
                                 }
                             }
                         }
@@ -802,63 +708,58 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/tokens/authentication": {
-            "post": {
-                "description": "Generate a bearer token for authentication. The token is valid for 24 hours and must be included in the Authorization header for protected endpoints.\n\n**Usage:** Include the token in subsequent requests as: ` + "`" + `Authorization: Bearer {token}` + "`" + `\n\n**Token Lifetime:** 24 hours",
-                "consumes": [
-                    "application/json"
-                ],
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove a genre from the taxonomy. Genres that are still used by movies cannot be deleted; merge them into another genre instead.\n\n**Permissions Required:** ` + "`" + `genres:write` + "`" + `",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "Genres"
                 ],
-                "summary": "Generate Authentication Token",
+                "summary": "Delete Genre (require genres:write permission)",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Genre deleted successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                " password": {
-                                    "type": "string"
-                                },
-                                "email": {
+                                "message": {
                                     "type": "string"
                                 }
                             }
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Token generated successfully",
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "authentication_token": {
-                                    "type": "object",
-                                    "properties": {
-                                        " expiry": {
-                                            "type": "string"
-                                        },
-                                        "token": {
-                                            "type": "string"
-                                        }
-                                    }
+                                "error": {
+                                    "type": "string"
                                 }
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad request - malformed JSON",
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -868,8 +769,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid email or password",
+                    "404": {
+                        "description": "Genre not found",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -879,16 +780,13 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity - validation errors",
+                    "409": {
+                        "description": "Conflict - genre is still used by movies",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "error": {
-                                    "type": "object",
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    }
+                                    "type": "string"
                                 }
                             }
                         }
@@ -915,12 +813,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/tokens/password-reset": {
-            "post": {
-                "description": "Request a password reset token to be sent via email. The token is valid for 45 minutes and can only be used once. The user account must be activated to receive a reset token.\n\n**Email Delivery:** Token is sent to the email address registered in the system (not the one provided in request).\n\n**Token Lifetime:** 45 minutes",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Rename a genre or change its aliases. Only provided fields will be updated. Changing the slug retags every movie with the new slug, and the old slug is kept as an alias.\n\n**Permissions Required:** ` + "`" + `genres:write` + "`" + `\n\n**Validation Rules:** Same as create operation",
                 "consumes": [
                     "application/json"
                 ],
@@ -928,19 +829,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "Genres"
                 ],
-                "summary": "Request Password Reset Token",
+                "summary": "Update Genre (require genres:write permission)",
                 "parameters": [
                     {
-                        "description": "User email address",
-                        "name": "email",
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre update data (all fields optional)",
+                        "name": "genre",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "email": {
+                                " aliases": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                " name": {
+                                    "type": "string"
+                                },
+                                "slug": {
                                     "type": "string"
                                 }
                             }
@@ -948,19 +867,19 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Password reset email will be sent",
+                    "200": {
+                        "description": "Genre updated successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
-                                    "type": "string"
+                                "genre": {
+                                    "$ref": "#/definitions/data.Genre"
                                 }
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad request - malformed JSON",
+                        "description": "Bad request - malformed JSON or invalid data types",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -970,22 +889,19 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable entity - email not found or account not activated",
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "error": {
-                                    "type": "object",
-                                    "additionalProperties": {
-                                        "type": "string"
-                                    }
+                                    "type": "string"
                                 }
                             }
                         }
                     },
-                    "429": {
-                        "description": "Too many requests - rate limit exceeded",
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -995,81 +911,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "error": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/users/activated": {
-            "put": {
-                "description": "Activate a user account using the token received via email. The token is single-use and expires after 3 days. Once activated, all activation tokens for this user are deleted.\n\n**Token Format:** 26-character alphanumeric string",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Activate User Account",
-                "parameters": [
-                    {
-                        "description": "Activation token (26 characters)",
-                        "name": "token",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "token": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Account activated successfully",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "user": {
-                                    "type": "object",
-                                    "properties": {
-                                        " activated": {
-                                            "type": "boolean"
-                                        },
-                                        " created_at": {
-                                            "type": "string"
-                                        },
-                                        " email": {
-                                            "type": "string"
-                                        },
-                                        " name": {
-                                            "type": "string"
-                                        },
-                                        "id": {
-                                            "type": "integer",
-                                            "format": "int64"
-                                        }
-                                    }
-                                }
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request - malformed JSON",
+                    "404": {
+                        "description": "Genre not found",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1080,7 +923,7 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Conflict - account has been modified during activation",
+                        "description": "Edit conflict - genre has been modified by another request",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1091,7 +934,7 @@ const docTemplate = `{
                         }
                     },
                     "422": {
-                        "description": "Unprocessable entity - invalid or expired token",
+                        "description": "Unprocessable entity - validation errors",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1126,12 +969,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/users/password": {
-            "put": {
-                "description": "Reset a user's password using a password reset token. The token must be obtained via the ` + "`" + `/v1/tokens/password-reset` + "`" + ` endpoint and is valid for 45 minutes. Once used, all password reset tokens for this user are deleted.\n\n**Validation Rules:**\n- Password: Required, 8-72 characters\n- Token: Required, 26-character alphanumeric string",
+        "/genres/{id}/merge": {
+            "post": {
+                "description": "Fold a genre into another one. Every movie tagged with the merged genre is retagged with the target genre, the slug, name and aliases of the merged genre become aliases of the target, and the merged genre is deleted.\n\n**Permissions Required:** ` + "`" + `genres:write` + "`" + `",
                 "consumes": [
                     "application/json"
                 ],
@@ -1139,23 +987,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Genres"
                 ],
-                "summary": "Reset User Password",
+                "summary": "Merge Genre (require genres:write permission)",
                 "parameters": [
                     {
-                        "description": "New password and reset token",
-                        "name": "data",
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "ID of the genre to merge",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID of the genre to merge into",
+                        "name": "merge",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
                             "properties": {
-                                " token": {
-                                    "type": "string"
-                                },
-                                "password": {
-                                    "type": "string"
+                                "into": {
+                                    "type": "integer",
+                                    "format": "int64"
                                 }
                             }
                         }
@@ -1163,18 +1018,51 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password reset successfully",
+                        "description": "Genres merged successfully, returns the target genre",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
-                                    "type": "string"
+                                "genre": {
+                                    "$ref": "#/definitions/data.Genre"
                                 }
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad request - malformed JSON",
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1185,7 +1073,7 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Conflict - account has been modified during password reset",
+                        "description": "Edit conflict - a genre has been modified by another request",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1196,7 +1084,7 @@ const docTemplate = `{
                         }
                     },
                     "422": {
-                        "description": "Unprocessable entity - invalid/expired token or weak password",
+                        "description": "Unprocessable entity - validation errors",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1231,12 +1119,17 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/users/register": {
+        "/graphql": {
             "post": {
-                "description": "Register a new user account. Upon successful registration, an activation email will be sent containing a token valid for 3 days. The account must be activated before it can be used.\n\n**Validation Rules:**\n- Name: Required, max 500 characters\n- Email: Required, valid email format, must be unique\n- Password: Required, 8-72 characters\n\n**Default Permissions:** New users receive ` + "`" + `movies:read` + "`" + ` permission by default.",
+                "description": "Run a GraphQL query or mutation. The schema covers the movies, with the same filters, sorting and paging as ` + "`" + `GET /v1/movies` + "`" + `, the current user with their permissions, and mutations to create, update and delete movies. Use introspection to explore it.\n\n**Permissions Required:** Checked per field. ` + "`" + `movies` + "`" + ` and ` + "`" + `movie` + "`" + ` require ` + "`" + `movies:read` + "`" + `, the mutations require ` + "`" + `movies:write` + "`" + `, and ` + "`" + `me` + "`" + ` is null for anonymous requests. A field the user may not access resolves to null with an error whose ` + "`" + `extensions.code` + "`" + ` is ` + "`" + `UNAUTHENTICATED` + "`" + ` or ` + "`" + `FORBIDDEN` + "`" + `. Validation errors carry the code ` + "`" + `FAILED_VALIDATION` + "`" + ` and the messages per input field in ` + "`" + `extensions.errors` + "`" + `.\n\n**Limits:** Queries may nest fields at most 6 levels deep and have a complexity of at most 5000 by default. The complexity is the number of fields the query resolves, where the fields of a page of movies count once per movie of the page. Queries over the limits are rejected with 400 Bad Request.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1244,66 +1137,85 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "GraphQL"
                 ],
-                "summary": "Register New User",
+                "summary": "GraphQL Query",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "user",
+                        "description": "GraphQL request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
                             "properties": {
-                                " email": {
+                                " operationName": {
                                     "type": "string"
                                 },
-                                " password": {
-                                    "type": "string"
+                                " variables": {
+                                    "type": "object"
                                 },
-                                "name": {
+                                "query": {
                                     "type": "string"
                                 }
                             }
                         }
+                    },
+                    {
+                        "type": "string",
+                        "example": "de-AT, de;q=0.9, en;q=0.5",
+                        "description": "Preferred languages for the displayTitle of movies",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Registration successful - activation email sent",
+                    "200": {
+                        "description": "Result of the operation, with the errors of any fields that failed",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                " user": {
-                                    "type": "object",
-                                    "properties": {
-                                        " activated": {
-                                            "type": "boolean"
-                                        },
-                                        " created_at": {
-                                            "type": "string"
-                                        },
-                                        " email": {
-                                            "type": "string"
-                                        },
-                                        " name": {
-                                            "type": "string"
-                                        },
-                                        "id": {
-                                            "type": "integer",
-                                            "format": "int64"
+                                " errors": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "object",
+                                        "properties": {
+                                            " extensions": {
+                                                "type": "object"
+                                            },
+                                            "message": {
+                                                "type": "string"
+                                            }
                                         }
                                     }
                                 },
-                                "message": {
-                                    "type": "string"
+                                "data": {
+                                    "type": "object"
                                 }
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad request - malformed JSON or invalid data types",
+                        "description": "Bad request - malformed JSON, or a query that doesn't parse, validate or fit the limits",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "errors": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "object",
+                                        "properties": {
+                                            "message": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid authentication token",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1314,7 +1226,7 @@ const docTemplate = `{
                         }
                     },
                     "422": {
-                        "description": "Unprocessable entity - validation errors (e.g., duplicate email, weak password)",
+                        "description": "Unprocessable entity - missing query",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1351,78 +1263,5777 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "data.Metadata": {
-            "type": "object",
-            "properties": {
-                "current_page": {
-                    "type": "integer"
-                },
-                "first_page": {
-                    "type": "integer"
-                },
-                "last_page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "total_records": {
-                    "type": "integer"
-                }
-            }
         },
-        "data.Movie": {
-            "type": "object",
-            "properties": {
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "runtime": {
-                    "type": "integer"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "version": {
-                    "description": "The version number starts at 1 and will be incremented\neach time the movie information is updated",
-                    "type": "integer"
-                },
-                "year": {
-                    "type": "integer"
-                }
-            }
-        }
-    },
-    "securityDefinitions": {
-        "BearerAuth": {
-            "description": "Enter your bearer token in the format: Bearer {token}",
-            "type": "apiKey",
-            "name": "Authorization",
-            "in": "header"
-        }
-    },
-    "tags": [
-        {
-            "description": "System health check and version information",
+        "/lists": {
+            "get": {
+                "description": "Retrieve a paginated list of the movie lists owned by the authenticated user, regardless of their visibility.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "List My Movie Lists",
+                "parameters": [
+                    {
+                        "maximum": 10000000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (minimum: 1, maximum: 10,000,000)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (minimum: 1, maximum: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "created_at",
+                            "-id",
+                            "-name",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of movie lists with pagination metadata",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " metadata": {
+                                    "$ref": "#/definitions/data.Metadata"
+                                },
+                                "lists": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.List"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new named movie list owned by the authenticated user.\n\n**Visibility:**\n- ` + "`" + `private` + "`" + `: only visible to the owner (default)\n- ` + "`" + `unlisted` + "`" + `: visible to anyone holding the generated ` + "`" + `share_token` + "`" + `\n- ` + "`" + `public` + "`" + `: visible to everyone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Create Movie List",
+                "parameters": [
+                    {
+                        "description": "List creation data",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " description": {
+                                    "type": "string"
+                                },
+                                " visibility": {
+                                    "type": "string"
+                                },
+                                "name": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "List created successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "list": {
+                                    "$ref": "#/definitions/data.List"
+                                }
+                            }
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created list"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lists/{id}": {
+            "get": {
+                "description": "Retrieve a movie list. Private lists are only visible to their owner, unlisted lists additionally require the ` + "`" + `share_token` + "`" + ` query parameter, and public lists are visible to everyone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Get Movie List by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share token of an unlisted list",
+                        "name": "share_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List details",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "list": {
+                                    "$ref": "#/definitions/data.List"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Permanently delete a list owned by the authenticated user, together with all of its items.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Delete Movie List",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or not the owner of the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Update the name, description or visibility of a list owned by the authenticated user. Only provided fields will be updated. Switching a list to ` + "`" + `unlisted` + "`" + ` generates a new share token, and switching away from ` + "`" + `unlisted` + "`" + ` revokes it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Update Movie List",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List update data (all fields optional)",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " description": {
+                                    "type": "string"
+                                },
+                                " visibility": {
+                                    "type": "string"
+                                },
+                                "name": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "list": {
+                                    "$ref": "#/definitions/data.List"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or not the owner of the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Edit conflict - list has been modified by another request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lists/{id}/items": {
+            "get": {
+                "description": "Retrieve the movies in a list in their explicit order. The same visibility rules as for reading the list apply.\n\nThe items embed the full movie records only for activated users with the ` + "`" + `movies:read` + "`" + ` permission. Everyone else gets the movie ids alone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "List Movie List Items",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share token of an unlisted list",
+                        "name": "share_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ordered list items",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "items": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.ListItem"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a movie to a list owned by the authenticated user. The movie is inserted at ` + "`" + `position` + "`" + ` (1-based), shifting later items down. When ` + "`" + `position` + "`" + ` is omitted the movie is appended to the end of the list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Add Movie to List",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List item data",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " note": {
+                                    "type": "string"
+                                },
+                                " position": {
+                                    "type": "integer"
+                                },
+                                "movie_id": {
+                                    "type": "integer",
+                                    "format": "int64"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Movie added to the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "item": {
+                                    "$ref": "#/definitions/data.ListItem"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or not the owner of the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors (e.g., unknown movie, movie already in list)",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lists/{id}/items/{movie_id}": {
+            "delete": {
+                "description": "Remove a movie from a list owned by the authenticated user. The items after it move up to close the gap.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Remove Movie from List",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie removed from the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or not the owner of the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List or list item not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Move an item to a new position and/or change its note. Only provided fields will be updated. Positions beyond the ends of the list are clamped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lists"
+                ],
+                "summary": "Update Movie List Item",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "List ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List item update data (all fields optional)",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " note": {
+                                    "type": "string"
+                                },
+                                "position": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List item updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "item": {
+                                    "$ref": "#/definitions/data.ListItem"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or not the owner of the list",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "List or list item not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies": {
+            "get": {
+                "description": "Retrieve a paginated list of movies with optional filtering by title (full-text search) and genres. Supports sorting by multiple fields. The ` + "`" + `display_title` + "`" + ` of each movie is picked from its alternate titles according to the ` + "`" + `Accept-Language` + "`" + ` header.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `\n\n**Filtering:**\n- Title: Partial match using PostgreSQL full-text search, on the title and the alternate titles\n- Genres: Multiple genres can be specified (comma-separated), by slug, name or alias\n- External IDs: Exact match on the IMDb, TMDb or Wikidata id\n- Status: One or more release statuses (comma-separated)\n- Certification: ` + "`" + `certification_max` + "`" + ` together with ` + "`" + `country` + "`" + ` only returns movies certified in that country, with no certification there above the maximum. The certifications are ordered per country, e.g. for US: G \u003c PG \u003c PG-13 \u003c R \u003c NC-17\n- Updated Since: Only movies created or changed at or after the given RFC 3339 time, for incremental sync. Combine with ` + "`" + `sort=updated_at` + "`" + ` to page through the changes in order\n\n**Sorting:**\n- Prefix with ` + "`" + `-` + "`" + ` for descending order (e.g., ` + "`" + `-year` + "`" + `)\n- Available fields: id, title, year, runtime, release_date (movies without a release date come last), updated_at",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "List All Movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Godfather",
+                        "description": "Filter by movie title (partial match, case-insensitive)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "drama,crime",
+                        "description": "Filter by genres (comma-separated)",
+                        "name": "genres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "announced,in_production",
+                        "description": "Filter by release status (comma-separated: announced, in_production, released)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "PG-13",
+                        "description": "Most restrictive certification to include, requires country",
+                        "name": "certification_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "US",
+                        "description": "Country of the certification_max filter (ISO 3166-1 alpha-2)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "tt0068646",
+                        "description": "Filter by IMDb id",
+                        "name": "imdb",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 238,
+                        "description": "Filter by TMDb id",
+                        "name": "tmdb",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Q47703",
+                        "description": "Filter by Wikidata id",
+                        "name": "wikidata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-10-01T00:00:00Z",
+                        "description": "Only movies updated at or after this RFC 3339 time",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10000000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (minimum: 1, maximum: 10,000,000)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (minimum: 1, maximum: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "mins",
+                            "minutes_int",
+                            "iso8601",
+                            "human"
+                        ],
+                        "type": "string",
+                        "default": "mins",
+                        "description": "Format of the runtimes in the response",
+                        "name": "runtime_format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "title",
+                            "year",
+                            "runtime",
+                            "release_date",
+                            "updated_at",
+                            "-id",
+                            "-title",
+                            "-year",
+                            "-runtime",
+                            "-release_date",
+                            "-updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "de-AT, de;q=0.9, en;q=0.5",
+                        "description": "Preferred languages for the display title",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of movies with pagination metadata",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " metadata": {
+                                    "$ref": "#/definitions/data.Metadata"
+                                },
+                                "movies": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.Movie"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new movie entry in the catalog.\n\n**Duplicate Detection:** A movie whose title matches an existing movie (ignoring case and punctuation), released in the same year and with a runtime within 5 minutes, is rejected with 409 Conflict and the ids of the existing movies in ` + "`" + `candidates` + "`" + `. Pass ` + "`" + `force=true` + "`" + ` to create it anyway.\n\n**Retries:** Send an ` + "`" + `Idempotency-Key` + "`" + ` header to make the request safe to retry. A retry with the same key and body gets the original response, with the ` + "`" + `Idempotent-Replayed: true` + "`" + ` header, instead of creating the movie again.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Validation Rules:**\n- Title: Required, max 500 characters\n- Status: Optional, one of ` + "`" + `announced` + "`" + `, ` + "`" + `in_production` + "`" + ` or ` + "`" + `released` + "`" + ` (default)\n- Year: Required unless a release date is given, from 1888 up to the current year for released movies, or up to 10 years ahead otherwise\n- Release Date: Optional, ` + "`" + `YYYY-MM-DD` + "`" + ` in the movie's year, not in the future for released movies\n- Runtime: Required for released movies, positive number of minutes, at most 60000. Accepted as a JSON integer (` + "`" + `135` + "`" + `), ` + "`" + `\"135 mins\"` + "`" + `, ` + "`" + `\"2h 15m\"` + "`" + ` or an ISO 8601 duration (` + "`" + `\"PT2H15M\"` + "`" + `)\n- Genres: Required, 1-5 unique genres, each the slug, name or alias of a known genre (stored as slugs)\n- External IDs: Optional, IMDb title id (e.g. ` + "`" + `tt0068646` + "`" + `), positive TMDb id and Wikidata item id (e.g. ` + "`" + `Q47703` + "`" + `), each unique across movies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Create New Movie (require movies:write permission)",
+                "parameters": [
+                    {
+                        "description": "Movie creation data",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " external_ids": {
+                                    "$ref": "#/definitions/data.ExternalIDs"
+                                },
+                                " genres": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                " release_date": {
+                                    "type": "string"
+                                },
+                                " runtime": {
+                                    "type": "string"
+                                },
+                                " status": {
+                                    "type": "string"
+                                },
+                                " year": {
+                                    "type": "integer",
+                                    "format": "int32"
+                                },
+                                "title": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Create the movie even if likely duplicates exist",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c3b9e-7d2a-4c8e-9b61-2a4f0d8e7c13",
+                        "description": "Makes the request safe to retry, retries with the same key and body get the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "mins",
+                            "minutes_int",
+                            "iso8601",
+                            "human"
+                        ],
+                        "type": "string",
+                        "default": "mins",
+                        "description": "Format of the runtime in the response",
+                        "name": "runtime_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Movie created successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - likely duplicates of the movie already exist, or a request with the same Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " candidates": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "integer",
+                                        "format": "int64"
+                                    }
+                                },
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors, or an Idempotency-Key used for a different request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/lookup": {
+            "get": {
+                "description": "Find the movie that is known under an id in another catalog. Exactly one of ` + "`" + `imdb` + "`" + `, ` + "`" + `tmdb` + "`" + ` or ` + "`" + `wikidata` + "`" + ` must be given.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Look Up Movie by External ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "tt0068646",
+                        "description": "IMDb id",
+                        "name": "imdb",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 238,
+                        "description": "TMDb id",
+                        "name": "tmdb",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Q47703",
+                        "description": "Wikidata id",
+                        "name": "wikidata",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "mins",
+                            "minutes_int",
+                            "iso8601",
+                            "human"
+                        ],
+                        "type": "string",
+                        "default": "mins",
+                        "description": "Format of the runtime in the response",
+                        "name": "runtime_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "de-AT, de;q=0.9, en;q=0.5",
+                        "description": "Preferred languages for the display title",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie details",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No movie has the given external id",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}": {
+            "get": {
+                "description": "Retrieve detailed information about a specific movie by its unique ID. The ` + "`" + `display_title` + "`" + ` is picked from the alternate titles of the movie according to the ` + "`" + `Accept-Language` + "`" + ` header.\n\nThe ` + "`" + `Last-Modified` + "`" + ` header holds the time the movie was last updated. Send it back in ` + "`" + `If-Modified-Since` + "`" + ` to get 304 Not Modified while the movie is unchanged.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Movie by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "mins",
+                            "minutes_int",
+                            "iso8601",
+                            "human"
+                        ],
+                        "type": "string",
+                        "default": "mins",
+                        "description": "Format of the runtime in the response",
+                        "name": "runtime_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "de-AT, de;q=0.9, en;q=0.5",
+                        "description": "Preferred languages for the display title",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Wed, 22 Oct 2025 07:48:50 GMT",
+                        "description": "Last-Modified value of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie details",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        }
+                    },
+                    "301": {
+                        "description": "The movie was merged into another movie, which the Location header points to",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The movie hasn't changed since If-Modified-Since"
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Permanently delete a movie from the catalog by its ID. This action cannot be undone.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Delete Movie (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Update an existing movie using partial update (PATCH). Only provided fields will be updated. Uses optimistic locking to prevent concurrent modification conflicts.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Validation Rules:** Same as create operation\n\n**Concurrency Control:** Uses version field for optimistic locking. If the movie has been modified by another request, a 409 Conflict will be returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Update Movie (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie update data (all fields optional, external_ids replaces all external ids, a null release_date clears it)",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " external_ids": {
+                                    "$ref": "#/definitions/data.ExternalIDs"
+                                },
+                                " genres": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                " release_date": {
+                                    "type": "string"
+                                },
+                                " runtime": {
+                                    "type": "string"
+                                },
+                                " status": {
+                                    "type": "string"
+                                },
+                                " year": {
+                                    "type": "integer",
+                                    "format": "int32"
+                                },
+                                "title": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "mins",
+                            "minutes_int",
+                            "iso8601",
+                            "human"
+                        ],
+                        "type": "string",
+                        "default": "mins",
+                        "description": "Format of the runtime in the response",
+                        "name": "runtime_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Edit conflict - movie has been modified by another request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/backdrop": {
+            "put": {
+                "description": "Upload a backdrop image for a movie as a ` + "`" + `multipart/form-data` + "`" + ` request with the image in the ` + "`" + `image` + "`" + ` field. Resized copies are generated and exposed in the movie's ` + "`" + `backdrop_urls` + "`" + ` field. Uploading a new backdrop replaces the previous one.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Accepted Formats:** JPEG and PNG (detected from the file content, not the file name)\n\n**Generated Sizes:** w300, w780, w1280, original",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Upload Movie Backdrop (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Backdrop image (JPEG or PNG)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backdrop uploaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed multipart form or upload too large",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Edit conflict - movie has been modified by another request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - missing or unsupported image",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/merge": {
+            "post": {
+                "description": "Fold a duplicate movie into the canonical movie. The list entries and alternate titles of the duplicate move to the canonical movie, external ids that only the duplicate has are copied over, and the duplicate is deleted. Afterwards ` + "`" + `GET /v1/movies/{id}` + "`" + ` on the duplicate's id answers with 301 Moved Permanently pointing at the canonical movie.\n\n**Permissions Required:** ` + "`" + `movies:merge` + "`" + `",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Merge Duplicate Movie (require movies:merge permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "description": "ID of the duplicate movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID of the canonical movie",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "into": {
+                                    "type": "integer",
+                                    "format": "int64"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movies merged successfully, returns the canonical movie",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Edit conflict - a movie has been modified by another request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/poster": {
+            "put": {
+                "description": "Upload a poster image for a movie as a ` + "`" + `multipart/form-data` + "`" + ` request with the image in the ` + "`" + `image` + "`" + ` field. Resized copies are generated and exposed in the movie's ` + "`" + `poster_urls` + "`" + ` field. Uploading a new poster replaces the previous one.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Accepted Formats:** JPEG and PNG (detected from the file content, not the file name)\n\n**Generated Sizes:** w185, w342, w500, original",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Upload Movie Poster (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image (JPEG or PNG)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Poster uploaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movie": {
+                                    "$ref": "#/definitions/data.Movie"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed multipart form or upload too large",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Edit conflict - movie has been modified by another request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - missing or unsupported image",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/releases": {
+            "get": {
+                "description": "Retrieve the per-country releases of a movie, along with the age certification it received in each country.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "List Movie Releases",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Releases ordered by country and date",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "releases": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.MovieRelease"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Add a release of a movie in a country. A movie has at most one release per country and type.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Validation Rules:**\n- Country: Required, uppercase ISO 3166-1 alpha-2 code (e.g. ` + "`" + `US` + "`" + `)\n- Type: Required, one of ` + "`" + `theatrical` + "`" + `, ` + "`" + `digital` + "`" + ` or ` + "`" + `physical` + "`" + `\n- Release Date: Required, ` + "`" + `YYYY-MM-DD` + "`" + `\n- Certification: Optional, max 20 characters. For countries with a known rating system it must be part of it:\n- US: G, PG, PG-13, R, NC-17\n- GB: U, PG, 12A, 12, 15, 18, R18\n- DE: 0, 6, 12, 16, 18\n- FR: U, 10, 12, 16, 18\n- JP: G, PG12, R15+, R18+\n- AU: G, PG, M, MA15+, R18+, X18+",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Add Movie Release (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Release data",
+                        "name": "release",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " certification": {
+                                    "type": "string"
+                                },
+                                " release_date": {
+                                    "type": "string"
+                                },
+                                " type": {
+                                    "type": "string"
+                                },
+                                "country": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Release added successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "release": {
+                                    "$ref": "#/definitions/data.MovieRelease"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/releases/{release_id}": {
+            "delete": {
+                "description": "Remove a release from a movie.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Delete Movie Release (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Release ID",
+                        "name": "release_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Release deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie or release not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/similar": {
+            "get": {
+                "description": "Retrieve the movies that are most similar to a movie, best match first. Each movie carries a ` + "`" + `similarity` + "`" + ` score between 0 and 1.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `\n\n**Scoring:**\n- Genre overlap (Jaccard index of the genres): 60%\n- Year proximity (falling off to zero at 20 years apart): 25%\n- Title similarity (trigram similarity): 15%\n\nOnly movies sharing at least one genre are considered. Rankings are computed in the background, within a minute of a movie being added or changed, and again every 24 hours. Until then a new movie has no similar movies and a changed movie keeps its previous ranking.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "List Similar Movies",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of movies (minimum: 1, maximum: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2,3",
+                        "description": "Movie IDs to leave out (comma-separated, at most 50)",
+                        "name": "exclude",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "mins",
+                            "minutes_int",
+                            "iso8601",
+                            "human"
+                        ],
+                        "type": "string",
+                        "default": "mins",
+                        "description": "Format of the runtimes in the response",
+                        "name": "runtime_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "de-AT, de;q=0.9, en;q=0.5",
+                        "description": "Preferred languages for the display title",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Similar movies ordered by similarity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "movies": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.Movie"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/titles": {
+            "get": {
+                "description": "Retrieve the alternate titles of a movie, such as its original title or the titles it was released under in other languages.\n\n**Permissions Required:** ` + "`" + `movies:read` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "List Movie Titles",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alternate titles ordered by language, region and type",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "titles": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.MovieTitle"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Add an alternate title to a movie. The ` + "`" + `updated_at` + "`" + ` time of the movie moves forward, as its display title may change.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `\n\n**Validation Rules:**\n- Title: Required, max 500 characters\n- Language: Required, lowercase ISO 639-1 code (e.g. ` + "`" + `de` + "`" + `)\n- Region: Optional, uppercase ISO 3166-1 alpha-2 code (e.g. ` + "`" + `AT` + "`" + `)\n- Type: Optional, one of ` + "`" + `original` + "`" + `, ` + "`" + `working` + "`" + ` or ` + "`" + `translated` + "`" + ` (default)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Add Movie Title (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alternate title data",
+                        "name": "title",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " language": {
+                                    "type": "string"
+                                },
+                                " region": {
+                                    "type": "string"
+                                },
+                                " type": {
+                                    "type": "string"
+                                },
+                                "title": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Title added successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "title": {
+                                    "$ref": "#/definitions/data.MovieTitle"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/movies/{id}/titles/{title_id}": {
+            "delete": {
+                "description": "Remove an alternate title from a movie. The ` + "`" + `updated_at` + "`" + ` time of the movie moves forward, as its display title may change.\n\n**Permissions Required:** ` + "`" + `movies:write` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Delete Movie Title (require movies:write permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Title ID",
+                        "name": "title_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Title deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Movie or title not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/tokens/activation": {
+            "post": {
+                "description": "Request a new activation token to be sent via email. Useful if the original token expired or was lost. The token is valid for 3 days. This endpoint cannot be used if the account is already activated.\n\n**Email Delivery:** Token is sent to the email address registered in the system (not the one provided in request).\n\n**Token Lifetime:** 3 days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Resend Activation Token",
+                "parameters": [
+                    {
+                        "description": "User email address",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "email": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Activation email will be sent",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - email not found or already activated",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/authentication": {
+            "post": {
+                "description": "Generate a bearer token for authentication. The token is valid for 24 hours and must be included in the Authorization header for protected endpoints.\n\n**Usage:** Include the token in subsequent requests as: ` + "`" + `Authorization: Bearer {token}` + "`" + `\n\n**Token Lifetime:** 24 hours",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Generate Authentication Token",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " password": {
+                                    "type": "string"
+                                },
+                                "email": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Token generated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "authentication_token": {
+                                    "type": "object",
+                                    "properties": {
+                                        " expiry": {
+                                            "type": "string"
+                                        },
+                                        "token": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid email or password",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/password-reset": {
+            "post": {
+                "description": "Request a password reset token to be sent via email. The token is valid for 45 minutes and can only be used once. The user account must be activated to receive a reset token.\n\n**Email Delivery:** Token is sent to the email address registered in the system (not the one provided in request).\n\n**Token Lifetime:** 45 minutes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Request Password Reset Token",
+                "parameters": [
+                    {
+                        "description": "User email address",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "email": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Password reset email will be sent",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - email not found or account not activated",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/activated": {
+            "put": {
+                "description": "Activate a user account using the token received via email. The token is single-use and expires after 3 days. Once activated, all activation tokens for this user are deleted.\n\n**Token Format:** 26-character alphanumeric string",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Activate User Account",
+                "parameters": [
+                    {
+                        "description": "Activation token (26 characters)",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "token": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account activated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "user": {
+                                    "type": "object",
+                                    "properties": {
+                                        " activated": {
+                                            "type": "boolean"
+                                        },
+                                        " created_at": {
+                                            "type": "string"
+                                        },
+                                        " email": {
+                                            "type": "string"
+                                        },
+                                        " name": {
+                                            "type": "string"
+                                        },
+                                        " version": {
+                                            "type": "integer"
+                                        },
+                                        "id": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - account has been modified during activation",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - invalid or expired token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "description": "Retrieve the authenticated user along with their permissions. The ` + "`" + `version` + "`" + ` of the user is needed to update them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Show Current User",
+                "responses": {
+                    "200": {
+                        "description": "The current user and their permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " permissions": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                "user": {
+                                    "type": "object",
+                                    "properties": {
+                                        " activated": {
+                                            "type": "boolean"
+                                        },
+                                        " created_at": {
+                                            "type": "string"
+                                        },
+                                        " email": {
+                                            "type": "string"
+                                        },
+                                        " name": {
+                                            "type": "string"
+                                        },
+                                        " version": {
+                                            "type": "integer"
+                                        },
+                                        "id": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Change the name of the authenticated user.\n\n**Concurrency Control:** The ` + "`" + `version` + "`" + ` of the user as last read is required. If the user has been modified since, a 409 Conflict is returned and nothing is changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update Current User",
+                "parameters": [
+                    {
+                        "description": "User update data (name optional, version required)",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " version": {
+                                    "type": "integer"
+                                },
+                                "name": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "user": {
+                                    "type": "object",
+                                    "properties": {
+                                        " activated": {
+                                            "type": "boolean"
+                                        },
+                                        " created_at": {
+                                            "type": "string"
+                                        },
+                                        " email": {
+                                            "type": "string"
+                                        },
+                                        " name": {
+                                            "type": "string"
+                                        },
+                                        " version": {
+                                            "type": "integer"
+                                        },
+                                        "id": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Edit conflict - user has been modified since the given version",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/email": {
+            "post": {
+                "description": "Start changing the email address of the authenticated user. The new address is stored as pending, and a confirmation token valid for 24 hours is sent to it, along with a notice to the current address. The email address only changes once the token is confirmed with ` + "`" + `PUT /users/me/email/confirm` + "`" + `.\n\nA new request replaces the pending address, and tokens sent for earlier requests stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change Current User Email",
+                "parameters": [
+                    {
+                        "description": "New email address",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "email": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Confirmation email will be sent to the new address",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - invalid email, unchanged email or email taken by another user",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/email/confirm": {
+            "put": {
+                "description": "Confirm a change of email address with the token sent to the new address by ` + "`" + `POST /users/me/email` + "`" + `. The request must be authenticated as the user who asked for the change. The pending address becomes the email address of the user, and the token is used up.\n\nActivation and password reset tokens, which were sent to the old address, are revoked. Authentication tokens stay valid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm Current User Email Change",
+                "parameters": [
+                    {
+                        "description": "Email change token (26 characters)",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "token": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email address changed successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "user": {
+                                    "type": "object",
+                                    "properties": {
+                                        " activated": {
+                                            "type": "boolean"
+                                        },
+                                        " created_at": {
+                                            "type": "string"
+                                        },
+                                        " email": {
+                                            "type": "string"
+                                        },
+                                        " name": {
+                                            "type": "string"
+                                        },
+                                        " version": {
+                                            "type": "integer"
+                                        },
+                                        "id": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - invalid or expired token, or email taken by another user since the change was requested",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "description": "Change the password of the authenticated user, who must provide their current password. Users who forgot their password use a password reset token instead, see ` + "`" + `PUT /users/password` + "`" + `.\n\nOutstanding password reset tokens are revoked, and so are the authentication tokens of the user other than the one the request is made with, signing out their other sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change Current User Password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " password": {
+                                    "type": "string"
+                                },
+                                "current_password": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - account has been modified during the password change",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - incorrect current password or weak new password",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/password": {
+            "put": {
+                "description": "Reset a user's password using a password reset token. The token must be obtained via the ` + "`" + `/v1/tokens/password-reset` + "`" + ` endpoint and is valid for 45 minutes. Once used, all password reset tokens for this user are deleted.\n\n**Validation Rules:**\n- Password: Required, 8-72 characters\n- Token: Required, 26-character alphanumeric string",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reset User Password",
+                "parameters": [
+                    {
+                        "description": "New password and reset token",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " token": {
+                                    "type": "string"
+                                },
+                                "password": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - account has been modified during password reset",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - invalid/expired token or weak password",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Register a new user account. Upon successful registration, an activation email will be sent containing a token valid for 3 days. The account must be activated before it can be used.\n\n**Validation Rules:**\n- Name: Required, max 500 characters\n- Email: Required, valid email format, must be unique\n- Password: Required, 8-72 characters\n\n**Default Permissions:** New users receive ` + "`" + `movies:read` + "`" + ` permission by default.\n\n**Retries:** Send an ` + "`" + `Idempotency-Key` + "`" + ` header to make the request safe to retry. A retry with the same key and body gets the original response, with the ` + "`" + `Idempotent-Replayed: true` + "`" + ` header, instead of failing because the email is taken.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register New User",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " email": {
+                                    "type": "string"
+                                },
+                                " password": {
+                                    "type": "string"
+                                },
+                                "name": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c3b9e-7d2a-4c8e-9b61-2a4f0d8e7c13",
+                        "description": "Makes the request safe to retry, retries with the same key and body get the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Registration successful - activation email sent",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " user": {
+                                    "type": "object",
+                                    "properties": {
+                                        " activated": {
+                                            "type": "boolean"
+                                        },
+                                        " created_at": {
+                                            "type": "string"
+                                        },
+                                        " email": {
+                                            "type": "string"
+                                        },
+                                        " name": {
+                                            "type": "string"
+                                        },
+                                        " version": {
+                                            "type": "integer"
+                                        },
+                                        "id": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - a request with the same Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors (e.g., duplicate email, weak password), or an Idempotency-Key used for a different request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Retrieve a paginated list of the webhook subscriptions.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Webhooks (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "maximum": 10000000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (minimum: 1, maximum: 10,000,000)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (minimum: 1, maximum: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "-id",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of webhooks with pagination metadata",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " metadata": {
+                                    "$ref": "#/definitions/data.Metadata"
+                                },
+                                "webhooks": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.Webhook"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Subscribe a URL to events. Every event is POSTed to the URL as JSON, ` + "`" + `{\"event\": ..., \"occurred_at\": ..., \"data\": {...}}` + "`" + `, with these headers:\n- ` + "`" + `Gmoapi-Event` + "`" + `: the event\n- ` + "`" + `Gmoapi-Delivery` + "`" + `: the id of the delivery\n- ` + "`" + `Gmoapi-Timestamp` + "`" + `: Unix time at which the delivery was sent\n- ` + "`" + `Gmoapi-Signature` + "`" + `: ` + "`" + `sha256=` + "`" + ` followed by the hex HMAC-SHA256 of ` + "`" + `\u003ctimestamp\u003e.\u003cbody\u003e` + "`" + `, keyed with the secret of the webhook\n\nThe secret is only returned in this response. Any 2xx response counts as delivered, anything else is retried with exponential backoff up to 8 times. A webhook is disabled after 20 failed attempts in a row.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `\n\n**Validation Rules:**\n- URL: Required, absolute ` + "`" + `http` + "`" + ` or ` + "`" + `https` + "`" + ` URL, max 2048 characters\n- Events: Required, 1 or more unique events out of ` + "`" + `movie.created` + "`" + `, ` + "`" + `movie.updated` + "`" + `, ` + "`" + `movie.deleted` + "`" + ` and ` + "`" + `user.registered` + "`" + `",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "description": "Webhook creation data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " events": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                "url": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook created successfully, along with its secret",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "webhook": {
+                                    "$ref": "#/definitions/data.Webhook"
+                                }
+                            }
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Retrieve a webhook subscription. The secret isn't included.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook by ID (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook details",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "webhook": {
+                                    "$ref": "#/definitions/data.Webhook"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove a webhook subscription together with its delivery log.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Change the URL or events of a webhook, or disable and re-enable it. Re-enabling a webhook resets its count of failed attempts, and its pending deliveries are sent again.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update Webhook (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook update data (all fields optional)",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " active": {
+                                    "type": "boolean"
+                                },
+                                " events": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                "url": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "webhook": {
+                                    "$ref": "#/definitions/data.Webhook"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - malformed JSON or invalid data types",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - edit conflict, please retry",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Retrieve the delivery log of a webhook: the payload of every delivery, its status (` + "`" + `pending` + "`" + `, ` + "`" + `succeeded` + "`" + ` or ` + "`" + `failed` + "`" + `), the number of attempts, and the response status and error of the last attempt.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Webhook Deliveries (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 10000000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (minimum: 1, maximum: 10,000,000)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (minimum: 1, maximum: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id"
+                        ],
+                        "type": "string",
+                        "default": "-id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries with pagination metadata, newest first by default",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                " metadata": {
+                                    "$ref": "#/definitions/data.Metadata"
+                                },
+                                "deliveries": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/data.WebhookDelivery"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - validation errors",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "additionalProperties": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Queue a new delivery of the event and payload of an earlier delivery, for example one that failed. It is sent as soon as possible, as long as the webhook is active.\n\n**Permissions Required:** ` + "`" + `webhooks:manage` + "`" + `",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver Webhook Delivery (require webhooks:manage permission)",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "The new delivery was queued",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "delivery": {
+                                    "$ref": "#/definitions/data.WebhookDelivery"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication token",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user account not activated or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "data.Date": {
+            "type": "object",
+            "properties": {
+                "time.Time": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.ExternalIDs": {
+            "type": "object",
+            "properties": {
+                "imdb": {
+                    "type": "string"
+                },
+                "tmdb": {
+                    "type": "integer"
+                },
+                "wikidata": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.Genre": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.ImageURLs": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "data.List": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "share_token": {
+                    "description": "ShareToken is only set for unlisted lists, and should only be shown to the\nowner of the list.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.ListItem": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "movie": {
+                    "$ref": "#/definitions/data.Movie"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.Metadata": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "first_page": {
+                    "type": "integer"
+                },
+                "last_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.Movie": {
+            "type": "object",
+            "properties": {
+                "backdrop_urls": {
+                    "$ref": "#/definitions/data.ImageURLs"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "display_title": {
+                    "description": "DisplayTitle is the title that best suits the client's preferred languages. It\nisn't stored, but filled in from the alternate titles of the movie.",
+                    "type": "string"
+                },
+                "external_ids": {
+                    "$ref": "#/definitions/data.ExternalIDs"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "poster_urls": {
+                    "$ref": "#/definitions/data.ImageURLs"
+                },
+                "release_date": {
+                    "$ref": "#/definitions/data.Date"
+                },
+                "runtime": {
+                    "type": "integer"
+                },
+                "similarity": {
+                    "description": "Similarity is the score of the movie when it is listed as similar to another\nmovie, between 0 and 1. It is only set in those listings.",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "The version number starts at 1 and will be incremented\neach time the movie information is updated",
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.MovieChange": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.MovieRelease": {
+            "type": "object",
+            "properties": {
+                "certification": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "$ref": "#/definitions/data.Date"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.MovieTitle": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Enter your bearer token in the format: Bearer {token}",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    },
+    "tags": [
+        {
+            "description": "System health check and version information",
             "name": "Health"
         },
         {
             "description": "Movie catalog management - requires authentication and appropriate permissions",
             "name": "Movies"
         },
+        {
+            "description": "Genre taxonomy that movies are tagged with - slugs, display names and aliases",
+            "name": "Genres"
+        },
+        {
+            "description": "Ordered log of movie changes for replicating the catalog, and a live stream of them",
+            "name": "Changes"
+        },
+        {
+            "description": "User-curated, ordered movie lists that can be private, unlisted or public",
+            "name": "Lists"
+        },
         {
             "description": "User account registration, activation, and password management",
             "name": "Users"
         },
+        {
+            "description": "Subscriptions that are notified of movie and user events with signed HTTP requests",
+            "name": "Webhooks"
+        },
+        {
+            "description": "GraphQL endpoint over movies and the current user, for fetching related data in one round trip",
+            "name": "GraphQL"
+        },
+        {
+            "description": "Several API requests in one round trip, each handled as if it had been sent on its own",
+            "name": "Batch"
+        },
         {
             "description": "Token generation for authentication, activation, and password reset",
             "name": "Tokens"
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/graphql-go/graphql/gqlerrors"
 )
 
 func (app *application) logError(r *http.Request, err error) {
@@ -52,6 +54,45 @@ func (app *application) editConflictResponse(w http.ResponseWriter, r *http.Requ
 	app.errorResponse(w, r, http.StatusConflict, message)
 }
 
+// duplicateMovieResponse rejects a new movie that looks like a movie which is already in
+// the catalog, listing the ids of the likely duplicates.
+func (app *application) duplicateMovieResponse(w http.ResponseWriter, r *http.Request, candidates []int64) {
+	env := envelope{
+		"error":      "a similar movie already exists, pass force=true to create it anyway",
+		"candidates": candidates,
+	}
+
+	err := app.writeJSON(w, http.StatusConflict, env, nil)
+	if err != nil {
+		app.logError(r, err)
+		w.WriteHeader(500)
+	}
+}
+
+// graphqlErrorsResponse rejects a GraphQL request that can't be executed, in the
+// format of GraphQL responses so that clients report the errors like any other.
+func (app *application) graphqlErrorsResponse(w http.ResponseWriter, r *http.Request, errors []gqlerrors.FormattedError) {
+	err := app.writeJSON(w, http.StatusBadRequest, envelope{"errors": errors}, nil)
+	if err != nil {
+		app.logError(r, err)
+		w.WriteHeader(500)
+	}
+}
+
+// idempotencyKeyMismatchResponse rejects a request whose Idempotency-Key was already used
+// for a request with a different method, path or body.
+func (app *application) idempotencyKeyMismatchResponse(w http.ResponseWriter, r *http.Request) {
+	message := "the Idempotency-Key has already been used for a different request"
+	app.errorResponse(w, r, http.StatusUnprocessableEntity, message)
+}
+
+// idempotencyKeyInUseResponse rejects a retry that arrives while the request with the
+// same Idempotency-Key is still being handled.
+func (app *application) idempotencyKeyInUseResponse(w http.ResponseWriter, r *http.Request) {
+	message := "a request with the same Idempotency-Key is still being processed, please try again"
+	app.errorResponse(w, r, http.StatusConflict, message)
+}
+
 func (app *application) rateLimitExceededResponse(w http.ResponseWriter, r *http.Request) {
 	message := "rate limit exceeded"
 	app.errorResponse(w, r, http.StatusTooManyRequests, message)
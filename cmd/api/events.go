@@ -0,0 +1,102 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+)
+
+// relayMovieChanges wakes up the event streams whenever a movie changes, on this or any
+// other instance, until the event bus is closed. A resync of the bus wakes them up as
+// well, since changes may have been missed.
+func (app *application) relayMovieChanges() {
+	sub := app.bus.Subscribe(data.TopicMovieChanged)
+	defer sub.Close()
+
+	for range sub.C {
+		app.events.notify()
+	}
+}
+
+// broadcaster wakes up every goroutine waiting for the next event. It carries no data,
+// waiters look up what happened themselves, so a wake-up that is missed or spurious
+// does no harm.
+type broadcaster struct {
+	mu     sync.Mutex
+	next   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{
+		next:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+}
+
+// wait returns a channel that is closed by the next call to notify. Get the channel
+// before checking for events, so that an event in between isn't missed.
+func (b *broadcaster) wait() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.next
+}
+
+func (b *broadcaster) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	close(b.next)
+	b.next = make(chan struct{})
+}
+
+// close tells the waiters to give up, when the server shuts down.
+func (b *broadcaster) close() {
+	b.once.Do(func() { close(b.closed) })
+}
+
+// done returns a channel that is closed once close has been called.
+func (b *broadcaster) done() <-chan struct{} {
+	return b.closed
+}
+
+// streamLimiter caps the number of event streams that a user can have open at once.
+type streamLimiter struct {
+	mu      sync.Mutex
+	max     int
+	streams map[int64]int
+}
+
+func newStreamLimiter(maximum int) *streamLimiter {
+	return &streamLimiter{
+		max:     maximum,
+		streams: make(map[int64]int),
+	}
+}
+
+// acquire reserves a stream for the user, reporting false when the user already has
+// the maximum number of streams open. Every successful acquire has to be followed by
+// a release.
+func (l *streamLimiter) acquire(userID int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.streams[userID] >= l.max {
+		return false
+	}
+
+	l.streams[userID]++
+	return true
+}
+
+func (l *streamLimiter) release(userID int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.streams[userID]--
+	if l.streams[userID] <= 0 {
+		delete(l.streams, userID)
+	}
+}
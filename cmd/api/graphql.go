@@ -0,0 +1,879 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/dataloader"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+const graphqlContextKey = contextKey("graphql")
+
+// graphqlRequest holds the state that the resolvers of a GraphQL request share: the
+// HTTP request and its user, the permissions of the user, which are read at most once,
+// and the loaders that batch the lookups of the movie fields.
+type graphqlRequest struct {
+	r           *http.Request
+	user        *data.User
+	locales     []data.Locale
+	permissions func() (data.Permissions, error)
+
+	titles   *dataloader.Loader[int64, []*data.MovieTitle]
+	releases *dataloader.Loader[int64, []*data.MovieRelease]
+}
+
+func (app *application) newGraphQLRequest(r *http.Request) *graphqlRequest {
+	user := app.contextGetUser(r)
+
+	return &graphqlRequest{
+		r:       r,
+		user:    user,
+		locales: app.readLocales(r),
+		permissions: sync.OnceValues(func() (data.Permissions, error) {
+			return app.models.Permissions.GetAllForUser(user.ID)
+		}),
+		titles:   dataloader.New(app.models.MovieTitles.GetAllForMovies),
+		releases: dataloader.New(app.models.MovieReleases.GetAllForMovies),
+	}
+}
+
+func graphqlRequestFrom(ctx context.Context) *graphqlRequest {
+	gr, ok := ctx.Value(graphqlContextKey).(*graphqlRequest)
+	if !ok {
+		panic("missing graphql request in context")
+	}
+	return gr
+}
+
+// graphqlError is an error of a GraphQL field. The response of a GraphQL request
+// doesn't have a status per field, so the kind of error is reported as a code in the
+// extensions of the error instead, along with any details.
+type graphqlError struct {
+	message    string
+	extensions map[string]any
+}
+
+func (e *graphqlError) Error() string {
+	return e.message
+}
+
+func (e *graphqlError) Extensions() map[string]any {
+	return e.extensions
+}
+
+func newGraphQLError(code, message string) *graphqlError {
+	return &graphqlError{message: message, extensions: map[string]any{"code": code}}
+}
+
+// The GraphQL errors carry the messages of their counterparts in errors.go.
+
+func (app *application) graphqlServerError(gr *graphqlRequest, err error) error {
+	app.logError(gr.r, err)
+	return newGraphQLError("INTERNAL_SERVER_ERROR", "the server encountered a problem and could not process your request")
+}
+
+func graphqlNotFoundError() error {
+	return newGraphQLError("NOT_FOUND", "the requested resource could not be found")
+}
+
+func graphqlFailedValidationError(errors map[string]string) error {
+	err := newGraphQLError("FAILED_VALIDATION", "the input failed validation")
+	err.extensions["errors"] = errors
+	return err
+}
+
+func graphqlEditConflictError() error {
+	return newGraphQLError("EDIT_CONFLICT", "unable to update the record due to an edit conflict, please try again")
+}
+
+func graphqlDuplicateMovieError(candidates []int64) error {
+	err := newGraphQLError("DUPLICATE_MOVIE", "a similar movie already exists, pass force: true to create it anyway")
+	err.extensions["candidates"] = candidates
+	return err
+}
+
+// graphqlRequirePermission is the counterpart of the requirePermission middleware for
+// GraphQL fields: the user must be authenticated, activated and hold the permission.
+func (app *application) graphqlRequirePermission(gr *graphqlRequest, code string) error {
+	if gr.user.IsAnonymous() {
+		return newGraphQLError("UNAUTHENTICATED", "you must be authenticated to access this resource")
+	}
+
+	if !gr.user.Activated {
+		return newGraphQLError("FORBIDDEN", "your user account must be activated to access this resource")
+	}
+
+	permissions, err := gr.permissions()
+	if err != nil {
+		return app.graphqlServerError(gr, err)
+	}
+
+	if !permissions.Include(code) {
+		return newGraphQLError("FORBIDDEN", "your user account doesn't have the necessary permissions to access this resource")
+	}
+
+	return nil
+}
+
+// withPermission wraps a resolver so that it only runs for users with the permission.
+func (app *application) withPermission(code string, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		err := app.graphqlRequirePermission(graphqlRequestFrom(p.Context), code)
+		if err != nil {
+			return nil, err
+		}
+		return resolve(p)
+	}
+}
+
+// newGraphQLSchema builds the GraphQL schema. It covers the movies, with the filters
+// and paging of the REST listing, the current user, and the creation, update and
+// deletion of movies.
+func (app *application) newGraphQLSchema() (graphql.Schema, error) {
+	externalIDsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ExternalIDs",
+		Description: "The keys of a movie in other catalogs.",
+		Fields: graphql.Fields{
+			"imdb": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return nullString(p.Source.(data.ExternalIDs).IMDb), nil
+				},
+			},
+			"tmdb": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if id := p.Source.(data.ExternalIDs).TMDb; id != 0 {
+						return id, nil
+					}
+					return nil, nil
+				},
+			},
+			"wikidata": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return nullString(p.Source.(data.ExternalIDs).Wikidata), nil
+				},
+			},
+		},
+	})
+
+	imageType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Image",
+		Description: "A generated size of a movie image.",
+		Fields: graphql.Fields{
+			"size": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"url":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	titleType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MovieTitle",
+		Description: "An alternate title of a movie.",
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"title":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"language": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"region": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return nullString(p.Source.(*data.MovieTitle).Region), nil
+				},
+			},
+			"type": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	releaseType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MovieRelease",
+		Description: "The release of a movie in a country.",
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"country": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"type":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"releaseDate": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*data.MovieRelease).ReleaseDate.String(), nil
+				},
+			},
+			"certification": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return nullString(p.Source.(*data.MovieRelease).Certification), nil
+				},
+			},
+		},
+	})
+
+	movieType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Movie",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"title":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"displayTitle": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The title that best suits the Accept-Language header of the request.",
+				Resolve:     app.resolveMovieDisplayTitle,
+			},
+			"year": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"runtime": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The runtime in minutes.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if runtime := p.Source.(*data.Movie).Runtime; runtime != 0 {
+						return int(runtime), nil
+					}
+					return nil, nil
+				},
+			},
+			"genres": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+			"status": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"releaseDate": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if date := p.Source.(*data.Movie).ReleaseDate; !date.IsZero() {
+						return date.String(), nil
+					}
+					return nil, nil
+				},
+			},
+			"externalIds": &graphql.Field{
+				Type: graphql.NewNonNull(externalIDsType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*data.Movie).ExternalIDs, nil
+				},
+			},
+			"posters": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(imageType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return imageList(p.Source.(*data.Movie).PosterURLs), nil
+				},
+			},
+			"backdrops": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(imageType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return imageList(p.Source.(*data.Movie).BackdropURLs), nil
+				},
+			},
+			"titles": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(titleType))),
+				Description: "The alternate titles, ordered by language, region and type.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gr := graphqlRequestFrom(p.Context)
+					titles := gr.titles.Load(p.Source.(*data.Movie).ID)
+
+					return func() (any, error) {
+						titles, err := titles()
+						if err != nil {
+							return nil, app.graphqlServerError(gr, err)
+						}
+						if titles == nil {
+							titles = []*data.MovieTitle{}
+						}
+						return titles, nil
+					}, nil
+				},
+			},
+			"releases": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(releaseType))),
+				Description: "The releases, ordered by country and date.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gr := graphqlRequestFrom(p.Context)
+					releases := gr.releases.Load(p.Source.(*data.Movie).ID)
+
+					return func() (any, error) {
+						releases, err := releases()
+						if err != nil {
+							return nil, app.graphqlServerError(gr, err)
+						}
+						if releases == nil {
+							releases = []*data.MovieRelease{}
+						}
+						return releases, nil
+					}, nil
+				},
+			},
+			"version": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	metadataType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Metadata",
+		Fields: graphql.Fields{
+			"currentPage":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"pageSize":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"firstPage":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"lastPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalRecords": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	moviePageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MoviePage",
+		Fields: graphql.Fields{
+			"movies":   &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(movieType)))},
+			"metadata": &graphql.Field{Type: graphql.NewNonNull(metadataType)},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"activated": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"permissions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gr := graphqlRequestFrom(p.Context)
+
+					permissions, err := gr.permissions()
+					if err != nil {
+						return nil, app.graphqlServerError(gr, err)
+					}
+					if permissions == nil {
+						permissions = data.Permissions{}
+					}
+					return permissions, nil
+				},
+			},
+		},
+	})
+
+	externalIDsInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ExternalIDsInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"imdb":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"tmdb":     &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"wikidata": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	// The same input type serves both mutations. Fields that are left out keep their
+	// value on update, and take their zero value on create, where ValidateMovie
+	// reports the ones that are required.
+	movieInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "MovieInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"year":        &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"runtime":     &graphql.InputObjectFieldConfig{Type: graphql.Int, Description: "The runtime in minutes."},
+			"genres":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"status":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"releaseDate": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "The release date as YYYY-MM-DD."},
+			"externalIds": &graphql.InputObjectFieldConfig{Type: externalIDsInputType, Description: "Replaces all external ids of the movie."},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"movies": &graphql.Field{
+				Type:        graphql.NewNonNull(moviePageType),
+				Description: "A page of movies, with the filters, sorting and paging of GET /v1/movies. Requires movies:read.",
+				Args: graphql.FieldConfigArgument{
+					"title":            &graphql.ArgumentConfig{Type: graphql.String},
+					"genres":           &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"status":           &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"certificationMax": &graphql.ArgumentConfig{Type: graphql.String},
+					"country":          &graphql.ArgumentConfig{Type: graphql.String},
+					"imdb":             &graphql.ArgumentConfig{Type: graphql.String},
+					"tmdb":             &graphql.ArgumentConfig{Type: graphql.Int},
+					"wikidata":         &graphql.ArgumentConfig{Type: graphql.String},
+					"updatedSince":     &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"page":             &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"pageSize":         &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+					"sort":             &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "id"},
+				},
+				Resolve: app.withPermission("movies:read", app.resolveMovies),
+			},
+			"movie": &graphql.Field{
+				Type:        movieType,
+				Description: "The movie with the id, or the movie it was merged into. Requires movies:read.",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: app.withPermission("movies:read", app.resolveMovie),
+			},
+			"me": &graphql.Field{
+				Type:        userType,
+				Description: "The authenticated user, or null for anonymous requests.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gr := graphqlRequestFrom(p.Context)
+					if gr.user.IsAnonymous() {
+						return nil, nil
+					}
+					return gr.user, nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createMovie": &graphql.Field{
+				Type:        graphql.NewNonNull(movieType),
+				Description: "Creates a movie, unless likely duplicates exist and force is false. Requires movies:write.",
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(movieInputType)},
+					"force": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: app.withPermission("movies:write", app.resolveCreateMovie),
+			},
+			"updateMovie": &graphql.Field{
+				Type:        graphql.NewNonNull(movieType),
+				Description: "Updates the fields of a movie that are given in the input. Requires movies:write.",
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(movieInputType)},
+				},
+				Resolve: app.withPermission("movies:write", app.resolveUpdateMovie),
+			},
+			"deleteMovie": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Deletes a movie and returns its id. Requires movies:write.",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: app.withPermission("movies:write", app.resolveDeleteMovie),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+func (app *application) resolveMovies(p graphql.ResolveParams) (any, error) {
+	gr := graphqlRequestFrom(p.Context)
+
+	var search data.MovieSearch
+	var filter data.Filter
+
+	search.Title, _ = p.Args["title"].(string)
+	search.Genres = stringsArg(p.Args["genres"])
+	search.Statuses = stringsArg(p.Args["status"])
+	search.ExternalIDs.IMDb, _ = p.Args["imdb"].(string)
+	if tmdb, ok := p.Args["tmdb"].(int); ok {
+		search.ExternalIDs.TMDb = int64(tmdb)
+	}
+	search.ExternalIDs.Wikidata, _ = p.Args["wikidata"].(string)
+	search.UpdatedSince, _ = p.Args["updatedSince"].(time.Time)
+
+	country, _ := p.Args["country"].(string)
+	certificationMax, _ := p.Args["certificationMax"].(string)
+
+	filter.Page, _ = p.Args["page"].(int)
+	filter.PageSize, _ = p.Args["pageSize"].(int)
+	filter.Sort, _ = p.Args["sort"].(string)
+
+	v := validator.New()
+
+	err := app.prepareMovieSearch(v, &search, &filter, country, certificationMax)
+	if err != nil {
+		return nil, app.graphqlServerError(gr, err)
+	}
+
+	if !v.Valid() {
+		return nil, graphqlFailedValidationError(v.Errors)
+	}
+
+	movies, metadata, err := app.models.Movies.GetAll(search, filter)
+	if err != nil {
+		return nil, app.graphqlServerError(gr, err)
+	}
+
+	return map[string]any{"movies": movies, "metadata": metadata}, nil
+}
+
+func (app *application) resolveMovie(p graphql.ResolveParams) (any, error) {
+	gr := graphqlRequestFrom(p.Context)
+
+	id, err := idArg(p.Args["id"])
+	if err != nil {
+		return nil, nil
+	}
+
+	movie, err := app.models.Movies.Get(id)
+	if errors.Is(err, data.ErrRecordNotFound) {
+		// Follow the redirect of a merged movie, as GET /v1/movies/:id does.
+		id, err = app.models.Movies.GetRedirect(id)
+		if err == nil {
+			movie, err = app.models.Movies.Get(id)
+		}
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, nil
+		default:
+			return nil, app.graphqlServerError(gr, err)
+		}
+	}
+
+	return movie, nil
+}
+
+func (app *application) resolveMovieDisplayTitle(p graphql.ResolveParams) (any, error) {
+	gr := graphqlRequestFrom(p.Context)
+	movie := p.Source.(*data.Movie)
+
+	if len(gr.locales) == 0 {
+		return movie.Title, nil
+	}
+
+	titles := gr.titles.Load(movie.ID)
+
+	return func() (any, error) {
+		titles, err := titles()
+		if err != nil {
+			return nil, app.graphqlServerError(gr, err)
+		}
+		return data.DisplayTitle(movie, titles, gr.locales), nil
+	}, nil
+}
+
+func (app *application) resolveCreateMovie(p graphql.ResolveParams) (any, error) {
+	gr := graphqlRequestFrom(p.Context)
+
+	movie := &data.Movie{Status: data.StatusReleased}
+
+	v := validator.New()
+
+	input, _ := p.Args["input"].(map[string]any)
+	applyMovieInput(v, movie, input)
+
+	taxonomy, err := app.models.Genres.GetTaxonomy()
+	if err != nil {
+		return nil, app.graphqlServerError(gr, err)
+	}
+
+	if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+		return nil, graphqlFailedValidationError(v.Errors)
+	}
+
+	if force, _ := p.Args["force"].(bool); !force {
+		candidates, err := app.models.Movies.FindDuplicates(movie)
+		if err != nil {
+			return nil, app.graphqlServerError(gr, err)
+		}
+
+		if len(candidates) > 0 {
+			return nil, graphqlDuplicateMovieError(candidates)
+		}
+	}
+
+	err = app.models.Movies.Insert(movie)
+	if err != nil {
+		switch {
+		case app.addExternalIDError(v, err):
+			return nil, graphqlFailedValidationError(v.Errors)
+		default:
+			return nil, app.graphqlServerError(gr, err)
+		}
+	}
+
+	return movie, nil
+}
+
+func (app *application) resolveUpdateMovie(p graphql.ResolveParams) (any, error) {
+	gr := graphqlRequestFrom(p.Context)
+
+	id, err := idArg(p.Args["id"])
+	if err != nil {
+		return nil, graphqlNotFoundError()
+	}
+
+	movie, err := app.models.Movies.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, graphqlNotFoundError()
+		default:
+			return nil, app.graphqlServerError(gr, err)
+		}
+	}
+
+	v := validator.New()
+
+	input, _ := p.Args["input"].(map[string]any)
+	applyMovieInput(v, movie, input)
+
+	taxonomy, err := app.models.Genres.GetTaxonomy()
+	if err != nil {
+		return nil, app.graphqlServerError(gr, err)
+	}
+
+	if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+		return nil, graphqlFailedValidationError(v.Errors)
+	}
+
+	err = app.models.Movies.Update(movie)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			return nil, graphqlEditConflictError()
+		case app.addExternalIDError(v, err):
+			return nil, graphqlFailedValidationError(v.Errors)
+		default:
+			return nil, app.graphqlServerError(gr, err)
+		}
+	}
+
+	return movie, nil
+}
+
+func (app *application) resolveDeleteMovie(p graphql.ResolveParams) (any, error) {
+	gr := graphqlRequestFrom(p.Context)
+
+	id, err := idArg(p.Args["id"])
+	if err != nil {
+		return nil, graphqlNotFoundError()
+	}
+
+	err = app.models.Movies.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, graphqlNotFoundError()
+		default:
+			return nil, app.graphqlServerError(gr, err)
+		}
+	}
+
+	return id, nil
+}
+
+// applyMovieInput copies the fields that are present in a MovieInput onto the movie.
+// A malformed release date is recorded in v.
+func applyMovieInput(v *validator.Validator, movie *data.Movie, input map[string]any) {
+	if title, ok := input["title"].(string); ok {
+		movie.Title = title
+	}
+	if year, ok := input["year"].(int); ok {
+		movie.Year = int32(year)
+	}
+	if runtime, ok := input["runtime"].(int); ok {
+		movie.Runtime = data.Runtime(runtime)
+	}
+	if genres, ok := input["genres"].([]any); ok {
+		movie.Genres = stringsArg(genres)
+	}
+	if status, ok := input["status"].(string); ok {
+		movie.Status = status
+	}
+	if releaseDate, ok := input["releaseDate"].(string); ok {
+		t, err := time.Parse("2006-01-02", releaseDate)
+		if err != nil {
+			v.AddError("release_date", "must be a date in the YYYY-MM-DD format")
+		}
+		movie.ReleaseDate = data.NewDate(t)
+	}
+	if ids, ok := input["externalIds"].(map[string]any); ok {
+		movie.ExternalIDs = data.ExternalIDs{}
+		movie.ExternalIDs.IMDb, _ = ids["imdb"].(string)
+		if tmdb, ok := ids["tmdb"].(int); ok {
+			movie.ExternalIDs.TMDb = int64(tmdb)
+		}
+		movie.ExternalIDs.Wikidata, _ = ids["wikidata"].(string)
+	}
+}
+
+// idArg parses an ID argument, which GraphQL passes as a string.
+func idArg(value any) (int64, error) {
+	s, _ := value.(string)
+
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id < 1 {
+		return 0, errors.New("invalid id parameter")
+	}
+	return id, nil
+}
+
+// stringsArg converts a list argument of strings.
+func stringsArg(value any) []string {
+	values, _ := value.([]any)
+
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// nullString returns nil for an empty string, so that missing values are null.
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// imageList turns the URLs of a movie image into a list ordered by size name.
+func imageList(urls data.ImageURLs) []map[string]any {
+	sizes := make([]string, 0, len(urls))
+	for size := range urls {
+		sizes = append(sizes, size)
+	}
+	sort.Strings(sizes)
+
+	images := make([]map[string]any, len(sizes))
+	for i, size := range sizes {
+		images[i] = map[string]any{"size": size, "url": urls[size]}
+	}
+	return images
+}
+
+// graphqlCost measures the operation of a GraphQL document: how deeply its fields are
+// nested, and how many fields it resolves. A field with a pageSize argument resolves
+// its selection once per item of the page. The fields of the introspection system
+// only count towards the complexity, so that clients may introspect the schema with
+// their usual queries.
+type graphqlCost struct {
+	document  *ast.Document
+	variables map[string]any
+	fragments map[string]*ast.FragmentDefinition
+}
+
+func newGraphQLCost(document *ast.Document, variables map[string]any) *graphqlCost {
+	c := &graphqlCost{
+		document:  document,
+		variables: variables,
+		fragments: make(map[string]*ast.FragmentDefinition),
+	}
+
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	return c
+}
+
+// operation returns the depth and the complexity of the named operation, or of the
+// first operation when name is empty.
+func (c *graphqlCost) operation(name string) (depth, complexity int) {
+	for _, definition := range c.document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if name == "" || (operation.Name != nil && operation.Name.Value == name) {
+			c.applyVariableDefaults(operation)
+			return c.selectionSet(operation.SelectionSet, true, map[string]bool{})
+		}
+	}
+	return 0, 0
+}
+
+// applyVariableDefaults adds the default values of the variables of the operation that
+// weren't supplied, so that a page size given as a default counts like any other.
+func (c *graphqlCost) applyVariableDefaults(operation *ast.OperationDefinition) {
+	variables := maps.Clone(c.variables)
+	if variables == nil {
+		variables = make(map[string]any)
+	}
+
+	for _, definition := range operation.VariableDefinitions {
+		name := definition.Variable.Name.Value
+		if _, ok := variables[name]; ok {
+			continue
+		}
+
+		if value, ok := definition.DefaultValue.(*ast.IntValue); ok {
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				variables[name] = n
+			}
+		}
+	}
+
+	c.variables = variables
+}
+
+func (c *graphqlCost) selectionSet(set *ast.SelectionSet, root bool, visited map[string]bool) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, n int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, n = c.selectionSet(selection.SelectionSet, false, visited)
+			n = 1 + n*c.pageSize(selection, root)
+			d++
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				d = 0
+			}
+		case *ast.InlineFragment:
+			d, n = c.selectionSet(selection.SelectionSet, root, visited)
+		case *ast.FragmentSpread:
+			// Validation rejects cyclic fragments, but don't rely on it here.
+			name := selection.Name.Value
+			if fragment := c.fragments[name]; fragment != nil && !visited[name] {
+				visited[name] = true
+				d, n = c.selectionSet(fragment.SelectionSet, root, visited)
+				delete(visited, name)
+			}
+		}
+
+		depth = max(depth, d)
+		complexity += n
+	}
+
+	return depth, complexity
+}
+
+// pageSize returns the pageSize argument of a field, or 1 if the field isn't paged.
+func (c *graphqlCost) pageSize(field *ast.Field, root bool) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "pageSize" {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				return max(n, 1)
+			}
+		case *ast.Variable:
+			// Variables decoded from JSON are float64.
+			switch n := c.variables[value.Name.Value].(type) {
+			case float64:
+				return max(int(n), 1)
+			case int:
+				return max(n, 1)
+			}
+		}
+	}
+
+	// The movies query is paged even when the page size is left at its default.
+	if root && field.Name.Value == "movies" {
+		return 20
+	}
+	return 1
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestGraphQLCostPageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		want      int
+	}{
+		{"default page size", `{ movies { id title } }`, nil, 1 + 2*20},
+		{"literal", `{ movies(pageSize: 100) { id title } }`, nil, 1 + 2*100},
+		{"variable", `query($n: Int) { movies(pageSize: $n) { id title } }`, map[string]any{"n": float64(100)}, 1 + 2*100},
+		{"variable default", `query($n: Int = 100) { movies(pageSize: $n) { id title } }`, nil, 1 + 2*100},
+		{"variable overrides default", `query($n: Int = 100) { movies(pageSize: $n) { id title } }`, map[string]any{"n": float64(5)}, 1 + 2*5},
+		{"missing variable", `query($n: Int) { movies(pageSize: $n) { id title } }`, nil, 1 + 2*20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, complexity := newGraphQLCost(document, tt.variables).operation("")
+			if complexity != tt.want {
+				t.Errorf("complexity = %d, want %d", complexity, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLCostDefaultsOfNamedOperation(t *testing.T) {
+	query := `
+		query Small($n: Int = 1) { movies(pageSize: $n) { id } }
+		query Large($n: Int = 100) { movies(pageSize: $n) { id } }`
+
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, complexity := newGraphQLCost(document, nil).operation("Large"); complexity != 1+100 {
+		t.Errorf("complexity = %d, want the default of the Large operation", complexity)
+	}
+}
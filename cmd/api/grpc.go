@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+	gmoapiv1 "github.com/ucok-man/gmoapi/proto/gmoapi/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// grpcPublicMethods are the gRPC methods that are open to anyone.
+var grpcPublicMethods = map[string]bool{
+	gmoapiv1.TokensService_CreateAuthenticationToken_FullMethodName: true,
+	gmoapiv1.TokensService_CreatePasswordResetToken_FullMethodName:  true,
+	gmoapiv1.TokensService_CreateActivationToken_FullMethodName:     true,
+	healthpb.Health_Check_FullMethodName:                            true,
+	healthpb.Health_List_FullMethodName:                             true,
+}
+
+// grpcMethodPermissions maps the gRPC methods that require a permission to the code of
+// the permission, like the requirePermission middleware does for the REST routes.
+// Methods that are neither listed here nor in grpcPublicMethods are denied, so that a
+// new method is not exposed until it has been given a permission.
+var grpcMethodPermissions = map[string]string{
+	gmoapiv1.MoviesService_ListMovies_FullMethodName:  "movies:read",
+	gmoapiv1.MoviesService_GetMovie_FullMethodName:    "movies:read",
+	gmoapiv1.MoviesService_CreateMovie_FullMethodName: "movies:write",
+	gmoapiv1.MoviesService_UpdateMovie_FullMethodName: "movies:write",
+	gmoapiv1.MoviesService_DeleteMovie_FullMethodName: "movies:write",
+}
+
+// newGRPCServer returns the gRPC server with the Movies and Tokens services, along with
+// the health service that reports its status.
+func (app *application) newGRPCServer() (*grpc.Server, *health.Server) {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			app.grpcRecoverPanic,
+			app.grpcAuthenticate,
+			app.grpcRequirePermission,
+		),
+	)
+
+	gmoapiv1.RegisterMoviesServiceServer(srv, &moviesService{app: app})
+	gmoapiv1.RegisterTokensServiceServer(srv, &tokensService{app: app})
+
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	for name := range srv.GetServiceInfo() {
+		healthSrv.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	reflection.Register(srv)
+
+	return srv, healthSrv
+}
+
+// stopGRPC reports the services as not serving and stops the server, letting the calls
+// in flight finish unless ctx is done first.
+func (app *application) stopGRPC(ctx context.Context, srv *grpc.Server, healthSrv *health.Server) {
+	healthSrv.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
+func (app *application) grpcRecoverPanic(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = app.grpcServerError(ctx, fmt.Errorf("%v", p))
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// grpcAuthenticate is the counterpart of the authenticate middleware. It reads the
+// bearer token from the authorization metadata and adds the user to the context.
+func (app *application) grpcAuthenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return handler(contextWithUser(ctx, data.AnonymousUser), req)
+	}
+
+	headerParts := strings.Split(authorization[0], " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return nil, grpcInvalidAuthenticationTokenError()
+	}
+
+	token := headerParts[1]
+
+	v := validator.New()
+	if data.ValidateTokenPlaintext(v, token); !v.Valid() {
+		return nil, grpcInvalidAuthenticationTokenError()
+	}
+
+	user, err := app.models.Users.GetForToken(data.ScopeAuthentication, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, grpcInvalidAuthenticationTokenError()
+		default:
+			return nil, app.grpcServerError(ctx, err)
+		}
+	}
+
+	return handler(contextWithUser(ctx, user), req)
+}
+
+// grpcRequirePermission is the counterpart of the requirePermission middleware for the
+// methods listed in grpcMethodPermissions.
+func (app *application) grpcRequirePermission(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if grpcPublicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	code, ok := grpcMethodPermissions[info.FullMethod]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "this method is not available")
+	}
+
+	user := userFromContext(ctx)
+
+	if user.IsAnonymous() {
+		return nil, status.Error(codes.Unauthenticated, "you must be authenticated to access this resource")
+	}
+
+	if !user.Activated {
+		return nil, status.Error(codes.PermissionDenied, "your user account must be activated to access this resource")
+	}
+
+	permissions, err := app.models.Permissions.GetAllForUser(user.ID)
+	if err != nil {
+		return nil, app.grpcServerError(ctx, err)
+	}
+
+	if !permissions.Include(code) {
+		return nil, status.Error(codes.PermissionDenied, "your user account doesn't have the necessary permissions to access this resource")
+	}
+
+	return handler(ctx, req)
+}
+
+// The gRPC errors carry the messages of their counterparts in errors.go.
+
+func (app *application) grpcServerError(ctx context.Context, err error) error {
+	method, _ := grpc.Method(ctx)
+	app.logger.Error(err.Error(), "grpc_method", method)
+
+	return status.Error(codes.Internal, "the server encountered a problem and could not process your request")
+}
+
+// grpcError maps the errors of the data models to a gRPC status.
+func (app *application) grpcError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, data.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "the requested resource could not be found")
+	case errors.Is(err, data.ErrEditConflict):
+		return status.Error(codes.Aborted, "unable to update the record due to an edit conflict, please try again")
+	default:
+		return app.grpcServerError(ctx, err)
+	}
+}
+
+// grpcFailedValidationError reports the validation errors as field violations in the
+// details of an INVALID_ARGUMENT status.
+func grpcFailedValidationError(errors map[string]string) error {
+	badRequest := &errdetails.BadRequest{}
+
+	fields := make([]string, 0, len(errors))
+	for field := range errors {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: errors[field],
+		})
+	}
+
+	st, err := status.New(codes.InvalidArgument, "the request failed validation").WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "the request failed validation")
+	}
+	return st.Err()
+}
+
+// grpcDuplicateMovieError rejects a new movie that looks like a movie which is already
+// in the catalog, listing the ids of the likely duplicates in the details.
+func grpcDuplicateMovieError(candidates []int64) error {
+	ids := make([]string, len(candidates))
+	for i, id := range candidates {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+
+	st, err := status.New(codes.AlreadyExists, "a similar movie already exists, set force to create it anyway").WithDetails(&errdetails.ErrorInfo{
+		Reason:   "DUPLICATE_MOVIE",
+		Domain:   "gmoapi",
+		Metadata: map[string]string{"candidates": strings.Join(ids, ",")},
+	})
+	if err != nil {
+		return status.Error(codes.AlreadyExists, "a similar movie already exists, set force to create it anyway")
+	}
+	return st.Err()
+}
+
+func grpcInvalidCredentialsError() error {
+	return status.Error(codes.Unauthenticated, "invalid authentication credentials")
+}
+
+func grpcInvalidAuthenticationTokenError() error {
+	return status.Error(codes.Unauthenticated, "invalid or missing authentication token")
+}
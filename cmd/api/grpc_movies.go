@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+	gmoapiv1 "github.com/ucok-man/gmoapi/proto/gmoapi/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// moviesService implements the gRPC MoviesService on top of the same models and
+// validation as the REST movie handlers.
+type moviesService struct {
+	gmoapiv1.UnimplementedMoviesServiceServer
+	app *application
+}
+
+func (s *moviesService) ListMovies(ctx context.Context, req *gmoapiv1.ListMoviesRequest) (*gmoapiv1.ListMoviesResponse, error) {
+	search := data.MovieSearch{
+		Title:    req.GetTitle(),
+		Genres:   req.GetGenres(),
+		Statuses: req.GetStatuses(),
+		ExternalIDs: data.ExternalIDs{
+			IMDb:     req.GetImdb(),
+			TMDb:     req.GetTmdb(),
+			Wikidata: req.GetWikidata(),
+		},
+	}
+	if req.GetUpdatedSince() != nil {
+		search.UpdatedSince = req.GetUpdatedSince().AsTime()
+	}
+
+	filter := data.Filter{
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+		Sort:     req.GetSort(),
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = 20
+	}
+	if filter.Sort == "" {
+		filter.Sort = "id"
+	}
+
+	v := validator.New()
+
+	err := s.app.prepareMovieSearch(v, &search, &filter, req.GetCountry(), req.GetCertificationMax())
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	if !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	movies, metadata, err := s.app.models.Movies.GetAll(search, filter)
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	resp := &gmoapiv1.ListMoviesResponse{
+		Movies: make([]*gmoapiv1.Movie, len(movies)),
+		Metadata: &gmoapiv1.Metadata{
+			CurrentPage:  int32(metadata.CurrentPage),
+			PageSize:     int32(metadata.PageSize),
+			FirstPage:    int32(metadata.FirstPage),
+			LastPage:     int32(metadata.LastPage),
+			TotalRecords: int32(metadata.TotalRecords),
+		},
+	}
+	for i, movie := range movies {
+		resp.Movies[i] = movieToProto(movie)
+	}
+
+	return resp, nil
+}
+
+func (s *moviesService) GetMovie(ctx context.Context, req *gmoapiv1.GetMovieRequest) (*gmoapiv1.GetMovieResponse, error) {
+	movie, err := s.app.models.Movies.Get(req.GetId())
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return &gmoapiv1.GetMovieResponse{Movie: movieToProto(movie)}, nil
+}
+
+func (s *moviesService) CreateMovie(ctx context.Context, req *gmoapiv1.CreateMovieRequest) (*gmoapiv1.CreateMovieResponse, error) {
+	movie := &data.Movie{}
+
+	v := validator.New()
+
+	applyMovieInputProto(v, movie, req.GetMovie(), movieInputFields)
+
+	if movie.Status == "" {
+		movie.Status = data.StatusReleased
+	}
+
+	taxonomy, err := s.app.models.Genres.GetTaxonomy()
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	if !req.GetForce() {
+		candidates, err := s.app.models.Movies.FindDuplicates(movie)
+		if err != nil {
+			return nil, s.app.grpcServerError(ctx, err)
+		}
+
+		if len(candidates) > 0 {
+			return nil, grpcDuplicateMovieError(candidates)
+		}
+	}
+
+	err = s.app.models.Movies.Insert(movie)
+	if err != nil {
+		switch {
+		case s.app.addExternalIDError(v, err):
+			return nil, grpcFailedValidationError(v.Errors)
+		default:
+			return nil, s.app.grpcServerError(ctx, err)
+		}
+	}
+
+	return &gmoapiv1.CreateMovieResponse{Movie: movieToProto(movie)}, nil
+}
+
+func (s *moviesService) UpdateMovie(ctx context.Context, req *gmoapiv1.UpdateMovieRequest) (*gmoapiv1.UpdateMovieResponse, error) {
+	v := validator.New()
+
+	paths := req.GetUpdateMask().GetPaths()
+
+	v.Check(len(paths) > 0, "update_mask", "must list at least one field")
+	for _, path := range paths {
+		v.Check(validator.PermittedValue(path, movieInputFields...), "update_mask", "must only contain fields of MovieInput")
+	}
+
+	if !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	movie, err := s.app.models.Movies.Get(req.GetId())
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	applyMovieInputProto(v, movie, req.GetMovie(), paths)
+
+	taxonomy, err := s.app.models.Genres.GetTaxonomy()
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	err = s.app.models.Movies.Update(movie)
+	if err != nil {
+		switch {
+		case s.app.addExternalIDError(v, err):
+			return nil, grpcFailedValidationError(v.Errors)
+		default:
+			return nil, s.app.grpcError(ctx, err)
+		}
+	}
+
+	return &gmoapiv1.UpdateMovieResponse{Movie: movieToProto(movie)}, nil
+}
+
+func (s *moviesService) DeleteMovie(ctx context.Context, req *gmoapiv1.DeleteMovieRequest) (*gmoapiv1.DeleteMovieResponse, error) {
+	err := s.app.models.Movies.Delete(req.GetId())
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return &gmoapiv1.DeleteMovieResponse{}, nil
+}
+
+// movieInputFields lists the fields of MovieInput, by the names used in update masks.
+var movieInputFields = []string{"title", "year", "runtime_minutes", "genres", "status", "release_date", "external_ids"}
+
+// applyMovieInputProto copies the listed fields of the input onto the movie. A
+// malformed release date is recorded in v.
+func applyMovieInputProto(v *validator.Validator, movie *data.Movie, input *gmoapiv1.MovieInput, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case "title":
+			movie.Title = input.GetTitle()
+		case "year":
+			movie.Year = input.GetYear()
+		case "runtime_minutes":
+			movie.Runtime = data.Runtime(input.GetRuntimeMinutes())
+		case "genres":
+			movie.Genres = input.GetGenres()
+		case "status":
+			movie.Status = input.GetStatus()
+		case "release_date":
+			movie.ReleaseDate = data.Date{}
+			if input.GetReleaseDate() != "" {
+				t, err := time.Parse("2006-01-02", input.GetReleaseDate())
+				if err != nil {
+					v.AddError("release_date", "must be a date in the YYYY-MM-DD format")
+				}
+				movie.ReleaseDate = data.NewDate(t)
+			}
+		case "external_ids":
+			movie.ExternalIDs = data.ExternalIDs{
+				IMDb:     input.GetExternalIds().GetImdb(),
+				TMDb:     input.GetExternalIds().GetTmdb(),
+				Wikidata: input.GetExternalIds().GetWikidata(),
+			}
+		}
+	}
+}
+
+func movieToProto(movie *data.Movie) *gmoapiv1.Movie {
+	pb := &gmoapiv1.Movie{
+		Id:             movie.ID,
+		CreatedAt:      timestamppb.New(movie.CreatedAt),
+		UpdatedAt:      timestamppb.New(movie.UpdatedAt),
+		Title:          movie.Title,
+		Year:           movie.Year,
+		RuntimeMinutes: int32(movie.Runtime),
+		Genres:         movie.Genres,
+		Status:         movie.Status,
+		ExternalIds: &gmoapiv1.ExternalIDs{
+			Imdb:     movie.ExternalIDs.IMDb,
+			Tmdb:     movie.ExternalIDs.TMDb,
+			Wikidata: movie.ExternalIDs.Wikidata,
+		},
+		PosterUrls:   movie.PosterURLs,
+		BackdropUrls: movie.BackdropURLs,
+		Version:      movie.Version,
+	}
+	if !movie.ReleaseDate.IsZero() {
+		pb.ReleaseDate = movie.ReleaseDate.String()
+	}
+
+	return pb
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestGRPCMethodsAreListed makes sure that every unary method the server exposes is
+// either public or needs a permission, since unlisted methods are denied.
+func TestGRPCMethodsAreListed(t *testing.T) {
+	app := &application{}
+
+	srv, _ := app.newGRPCServer()
+
+	for service, info := range srv.GetServiceInfo() {
+		for _, method := range info.Methods {
+			if method.IsClientStream || method.IsServerStream {
+				continue
+			}
+
+			name := "/" + service + "/" + method.Name
+			_, protected := grpcMethodPermissions[name]
+			if !protected && !grpcPublicMethods[name] {
+				t.Errorf("%s is neither public nor has a permission", name)
+			}
+		}
+	}
+}
+
+func TestGRPCRequirePermissionDeniesUnlisted(t *testing.T) {
+	app := &application{}
+
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/gmoapi.v1.MoviesService/Unlisted"}
+	_, err := app.grpcRequirePermission(context.Background(), nil, info, handler)
+
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("code = %s, want %s", status.Code(err), codes.PermissionDenied)
+	}
+	if called {
+		t.Error("the handler of an unlisted method was called")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+	gmoapiv1 "github.com/ucok-man/gmoapi/proto/gmoapi/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// tokensService implements the gRPC TokensService, following the REST token handlers.
+type tokensService struct {
+	gmoapiv1.UnimplementedTokensServiceServer
+	app *application
+}
+
+func (s *tokensService) CreateAuthenticationToken(ctx context.Context, req *gmoapiv1.CreateAuthenticationTokenRequest) (*gmoapiv1.CreateAuthenticationTokenResponse, error) {
+	v := validator.New()
+	data.ValidateEmail(v, req.GetEmail())
+	data.ValidatePasswordPlaintext(v, req.GetPassword())
+
+	if !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	user, err := s.app.models.Users.GetByEmail(req.GetEmail())
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, grpcInvalidCredentialsError()
+		default:
+			return nil, s.app.grpcServerError(ctx, err)
+		}
+	}
+
+	match, err := user.Password.Matches(req.GetPassword())
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	if !match {
+		return nil, grpcInvalidCredentialsError()
+	}
+
+	token, err := s.app.models.Tokens.New(user.ID, 24*time.Hour, data.ScopeAuthentication)
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	return &gmoapiv1.CreateAuthenticationTokenResponse{
+		Token:  token.Plaintext,
+		Expiry: timestamppb.New(token.Expiry),
+	}, nil
+}
+
+func (s *tokensService) CreatePasswordResetToken(ctx context.Context, req *gmoapiv1.CreatePasswordResetTokenRequest) (*gmoapiv1.CreatePasswordResetTokenResponse, error) {
+	v := validator.New()
+	if data.ValidateEmail(v, req.GetEmail()); !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	user, err := s.app.models.Users.GetByEmail(req.GetEmail())
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("email", "no matching email address found")
+			return nil, grpcFailedValidationError(v.Errors)
+		default:
+			return nil, s.app.grpcServerError(ctx, err)
+		}
+	}
+
+	if !user.Activated {
+		v.AddError("email", "user account must be activated")
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	token, err := s.app.models.Tokens.New(user.ID, 45*time.Minute, data.ScopePasswordReset)
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	s.app.background(func() {
+		data := map[string]any{
+			"passwordResetToken": token.Plaintext,
+		}
+		err := s.app.mailer.Send(user.Email, "token_password_reset.tmpl", data)
+		if err != nil {
+			s.app.logger.Error(err.Error())
+		}
+	})
+
+	return &gmoapiv1.CreatePasswordResetTokenResponse{
+		Message: "an email will be sent to you containing password reset instructions",
+	}, nil
+}
+
+func (s *tokensService) CreateActivationToken(ctx context.Context, req *gmoapiv1.CreateActivationTokenRequest) (*gmoapiv1.CreateActivationTokenResponse, error) {
+	v := validator.New()
+	if data.ValidateEmail(v, req.GetEmail()); !v.Valid() {
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	user, err := s.app.models.Users.GetByEmail(req.GetEmail())
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("email", "no matching email address found")
+			return nil, grpcFailedValidationError(v.Errors)
+		default:
+			return nil, s.app.grpcServerError(ctx, err)
+		}
+	}
+
+	if user.Activated {
+		v.AddError("email", "user has already been activated")
+		return nil, grpcFailedValidationError(v.Errors)
+	}
+
+	token, err := s.app.models.Tokens.New(user.ID, 3*24*time.Hour, data.ScopeActivation)
+	if err != nil {
+		return nil, s.app.grpcServerError(ctx, err)
+	}
+
+	s.app.background(func() {
+		data := map[string]any{
+			"activationToken": token.Plaintext,
+		}
+		err := s.app.mailer.Send(user.Email, "token_activation.tmpl", data)
+		if err != nil {
+			s.app.logger.Error(err.Error())
+		}
+	})
+
+	return &gmoapiv1.CreateActivationTokenResponse{
+		Message: "an email will be sent to you containing activation instructions",
+	}, nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// batchRequest is one of the sub-requests of a batch.
+type batchRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// batchResponse is the response to one of the sub-requests of a batch.
+type batchResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers"`
+	Body    any         `json:"body"`
+}
+
+// @Summary      Batch Requests
+// @Description  Run several API requests in one round trip. Every sub-request goes through the same routing, authentication, permission checks and rate limiting as if it had been sent on its own, with the identity of the batch request: the `Authorization` header of the batch is used for every sub-request and can't be overridden per sub-request.
+// @Description
+// @Description  Sub-requests run one after the other in the order given, so later ones see the changes of earlier ones. Set `parallel` to run them all at once instead. The batch succeeds even if some sub-requests fail, the outcome of each is reported by its `status`. Bodies of JSON responses are embedded as JSON, other bodies as strings.
+// @Description
+// @Description  **Limits:** A batch holds at most 20 sub-requests by default. Each sub-request counts against the rate limit, the batch itself doesn't. Paths must start with `/v1`, and batches and event streams can't be nested in a batch.
+// @Tags         Batch
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object{requests=[]object{method=string, path=string, headers=map[string]string, body=object}, parallel=bool}  true  "Sub-requests to run"
+// @Success      200  {object}  object{responses=[]object{status=int, headers=map[string][]string, body=object}}  "Responses in the order of the sub-requests"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /batch [post]
+func (app *application) batchHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Requests []batchRequest `json:"requests"`
+		Parallel bool           `json:"parallel"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+
+	v.Check(len(input.Requests) > 0, "requests", "must contain at least one request")
+	v.Check(len(input.Requests) <= app.config.Batch.MaxSize, "requests", fmt.Sprintf("must not contain more than %d requests", app.config.Batch.MaxSize))
+
+	subs := make([]*http.Request, len(input.Requests))
+	for i, item := range input.Requests {
+		sub, err := app.newBatchSubRequest(v, r, item, fmt.Sprintf("requests[%d]", i))
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+		subs[i] = sub
+	}
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	responses := make([]batchResponse, len(subs))
+
+	if input.Parallel {
+		var wg sync.WaitGroup
+		for i, sub := range subs {
+			wg.Go(func() {
+				responses[i] = app.serveBatchSubRequest(sub)
+			})
+		}
+		wg.Wait()
+	} else {
+		for i, sub := range subs {
+			responses[i] = app.serveBatchSubRequest(sub)
+		}
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"responses": responses}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// newBatchSubRequest validates a sub-request of the batch r, recording the problems in v
+// under the given key, and returns the request to run for it. The sub-request carries
+// the credentials and client address of the batch, so that it is authenticated and rate
+// limited like the batch would be.
+func (app *application) newBatchSubRequest(v *validator.Validator, r *http.Request, item batchRequest, key string) (*http.Request, error) {
+	item.Method = strings.ToUpper(item.Method)
+
+	v.Check(validator.PermittedValue(item.Method, http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete), key+".method", "must be one of GET, POST, PUT, PATCH or DELETE")
+
+	u, err := url.Parse(item.Path)
+	switch {
+	case item.Path == "":
+		v.AddError(key+".path", "must be provided")
+	case err != nil || u.Scheme != "" || u.Host != "":
+		v.AddError(key+".path", "must be a path, not a URL")
+	default:
+		clean := path.Clean(u.Path)
+		switch {
+		case clean != "/v1" && !strings.HasPrefix(clean, "/v1/"):
+			v.AddError(key+".path", "must start with /v1")
+		case clean == "/v1/batch" || strings.HasPrefix(clean, "/v1/events/"):
+			v.AddError(key+".path", "must not be a batch or an event stream")
+		}
+	}
+
+	for name := range item.Headers {
+		v.Check(!strings.EqualFold(name, "Authorization"), key+".headers", "must not contain Authorization, sub-requests use the credentials of the batch")
+	}
+
+	if !v.Valid() {
+		return nil, nil
+	}
+
+	var body []byte
+	if len(item.Body) > 0 && !bytes.Equal(item.Body, []byte("null")) {
+		body = item.Body
+	}
+
+	sub, err := http.NewRequestWithContext(r.Context(), item.Method, item.Path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range item.Headers {
+		sub.Header.Set(name, value)
+	}
+	if body != nil && sub.Header.Get("Content-Type") == "" {
+		sub.Header.Set("Content-Type", "application/json")
+	}
+	if sub.Header.Get("Accept-Language") == "" && r.Header.Get("Accept-Language") != "" {
+		sub.Header.Set("Accept-Language", r.Header.Get("Accept-Language"))
+	}
+
+	for _, name := range []string{"Authorization", "X-Forwarded-For", "X-Real-Ip"} {
+		sub.Header.Del(name)
+		if value := r.Header.Get(name); value != "" {
+			sub.Header.Set(name, value)
+		}
+	}
+
+	sub.RemoteAddr = r.RemoteAddr
+	sub.RequestURI = sub.URL.RequestURI()
+
+	return sub, nil
+}
+
+// serveBatchSubRequest runs the sub-request through the complete handler chain of the
+// application and returns the response it got.
+func (app *application) serveBatchSubRequest(sub *http.Request) batchResponse {
+	rw := newBatchResponseWriter()
+	app.handler.ServeHTTP(rw, sub)
+
+	resp := batchResponse{
+		Status:  rw.statusCode,
+		Headers: rw.header,
+	}
+
+	switch {
+	case rw.body.Len() == 0:
+		resp.Body = nil
+	case json.Valid(rw.body.Bytes()):
+		resp.Body = json.RawMessage(rw.body.Bytes())
+	default:
+		resp.Body = rw.body.String()
+	}
+
+	return resp
+}
+
+// batchResponseWriter records the response to a sub-request of a batch.
+type batchResponseWriter struct {
+	header        http.Header
+	body          bytes.Buffer
+	statusCode    int
+	headerWritten bool
+}
+
+func newBatchResponseWriter() *batchResponseWriter {
+	return &batchResponseWriter{
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+}
+
+func (bw *batchResponseWriter) Header() http.Header {
+	return bw.header
+}
+
+func (bw *batchResponseWriter) WriteHeader(statusCode int) {
+	if !bw.headerWritten {
+		bw.statusCode = statusCode
+		bw.headerWritten = true
+	}
+}
+
+func (bw *batchResponseWriter) Write(b []byte) (int, error) {
+	bw.headerWritten = true
+	return bw.body.Write(b)
+}
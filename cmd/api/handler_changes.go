@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// maxChangesWait is the longest a request to the change feed may wait for new changes.
+const maxChangesWait = 60 * time.Second
+
+// @Summary      List Movie Changes
+// @Description  Retrieve the log of movies created, updated and deleted, for replicating the catalog. Every change has a sequence number that increases in the order the changes were made. The log starts with every movie that existed when it was introduced.
+// @Description
+// @Description  To replicate, start with `since=0` and pass the `next_since` of each response as `since` of the next request. Fetch a created or updated movie with `GET /v1/movies/{id}`; the `version` of a change is the movie's version right after it. Several changes of the same movie may be listed.
+// @Description
+// @Description  **Long Polling:** When there are no changes after `since`, `wait` (at most 60s) holds the request open until a change is made or the wait runs out, in which case an empty list is returned.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Changes
+// @Produce      json
+// @Param        since  query     int     false  "Only changes with a greater sequence number"  default(0)  minimum(0)
+// @Param        limit  query     int     false  "Maximum number of changes (minimum: 1, maximum: 1000)"  default(100)  minimum(1)  maximum(1000)
+// @Param        wait   query     string  false  "How long to wait for changes when there are none yet"  example(30s)
+// @Security     BearerAuth
+// @Success      200  {object}  object{changes=[]data.MovieChange, next_since=int}  "Changes ordered by sequence number"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /changes [get]
+func (app *application) listChangesHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+
+	qs := r.URL.Query()
+
+	since := int64(app.readQueryInt(qs, "since", 0, v))
+	limit := app.readQueryInt(qs, "limit", 100, v)
+	wait := app.readQueryDuration(qs, "wait", 0, v)
+
+	v.Check(since >= 0, "since", "must be zero or greater")
+	v.Check(limit >= 1 && limit <= 1000, "limit", "must be between 1 and 1000")
+	v.Check(wait >= 0 && wait <= maxChangesWait, "wait", "must be between 0s and 60s")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	// Start waiting before reading the changes, so that none slips in between.
+	changed := app.events.wait()
+
+	changes, err := app.models.MovieChanges.GetSince(since, limit)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if len(changes) == 0 && wait > 0 {
+		// The server's write timeout is shorter than the longest wait, so extend the
+		// deadline of this response to leave time for writing it after the wait.
+		err = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(wait + 5*time.Second))
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+
+		timeout := time.NewTimer(wait)
+		defer timeout.Stop()
+
+		// The change log is only read again when a movie has changed, rather than
+		// polled, so that idle clients cost nothing.
+	waiting:
+		for len(changes) == 0 {
+			select {
+			case <-r.Context().Done():
+				// The client has gone away, so there is no one to respond to.
+				return
+			case <-app.events.done():
+				break waiting
+			case <-timeout.C:
+				break waiting
+			case <-changed:
+			}
+
+			changed = app.events.wait()
+
+			changes, err = app.models.MovieChanges.GetSince(since, limit)
+			if err != nil {
+				app.serverErrorResponse(w, r, err)
+				return
+			}
+		}
+	}
+
+	nextSince := since
+	if len(changes) > 0 {
+		nextSince = changes[len(changes)-1].Seq
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"changes": changes, "next_since": nextSince}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
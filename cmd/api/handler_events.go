@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/eventbus"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+const (
+	// maxStreamsPerUser is the number of event streams a user may have open at once.
+	maxStreamsPerUser = 3
+
+	// maxStreamDuration is how long an event stream stays open. Clients reconnect with
+	// Last-Event-ID afterwards, which spreads long-lived connections over the servers.
+	maxStreamDuration = 30 * time.Minute
+
+	// streamHeartbeatInterval is how often an idle stream sends a comment, to keep
+	// proxies from closing it.
+	streamHeartbeatInterval = 15 * time.Second
+
+	// streamWriteTimeout bounds every write to a stream, replacing the write timeout of
+	// the server, which would otherwise end the stream after 10 seconds.
+	streamWriteTimeout = 10 * time.Second
+
+	// streamBatchSize is the number of changes read from the change log at once.
+	streamBatchSize = 100
+)
+
+// @Summary      Stream Movie Events
+// @Description  Server-Sent Events stream of movies being created, updated and deleted. Every event is named after the change (`movie.created`, `movie.updated` or `movie.deleted`), carries the sequence number of the change log as its `id`, and the change as JSON `data`: `{"seq": 42, "created_at": "...", "movie_id": 1, "type": "updated", "version": 3}`.
+// @Description
+// @Description  A new stream starts with the changes made after it was opened. To resume after a disconnect, send the id of the last event received in the `Last-Event-ID` header (browsers do this when reconnecting) or the `last_event_id` query parameter.
+// @Description
+// @Description  **Limits:** An idle stream receives a `: heartbeat` comment every 15 seconds. A stream is closed after 30 minutes, clients are expected to reconnect. A user may have at most 3 streams open at once. A stream is also closed when the user loses the `movies:read` permission or their authentication tokens are revoked.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Changes
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header  int  false  "Sequence number of the last event received"  example(42)
+// @Param        last_event_id  query   int  false  "Same as the Last-Event-ID header, for clients that can't set headers"  example(42)
+// @Security     BearerAuth
+// @Success      200  {string}  string  "Stream of events"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded or too many open streams"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /events/stream [get]
+func (app *application) streamEventsHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	v := validator.New()
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	var since int64
+	if lastEventID != "" {
+		var err error
+		since, err = strconv.ParseInt(lastEventID, 10, 64)
+		v.Check(err == nil && since >= 0, "last_event_id", "must be the id of an earlier event")
+	}
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	if !app.streams.acquire(user.ID) {
+		app.errorResponse(w, r, http.StatusTooManyRequests, fmt.Sprintf("you can't have more than %d event streams open at once", maxStreamsPerUser))
+		return
+	}
+	defer app.streams.release(user.ID)
+
+	if lastEventID == "" {
+		var err error
+		since, err = app.models.MovieChanges.LatestSeq()
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w)
+
+	err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Stop nginx and similar proxies from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Once the response has started, errors can only be logged. Returning closes the
+	// stream, and the client reconnects where it left off.
+	send := func(format string, args ...any) bool {
+		err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			app.logger.Debug("event stream closed", "error", err.Error(), "user_id", user.ID)
+			return false
+		}
+		return true
+	}
+
+	if !send("retry: %d\n\n", (3 * time.Second).Milliseconds()) {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	expire := time.NewTimer(maxStreamDuration)
+	defer expire.Stop()
+
+	// The stream outlives the authentication of the request that opened it, so watch
+	// for the user losing access.
+	access := app.bus.Subscribe(data.TopicPermissionsChanged, data.TopicTokensRevoked)
+	defer access.Close()
+
+	for {
+		// Start waiting before reading the changes, so that none slips in between.
+		changed := app.events.wait()
+
+		changes, err := app.models.MovieChanges.GetSince(since, streamBatchSize)
+		if err != nil {
+			app.logError(r, err)
+			return
+		}
+
+		for _, change := range changes {
+			if !app.sendMovieChange(send, change) {
+				return
+			}
+			since = change.Seq
+		}
+
+		// A full batch means there may be more changes waiting.
+		if len(changes) == streamBatchSize {
+			continue
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-app.events.done():
+			return
+		case <-expire.C:
+			return
+		case <-changed:
+		case event, ok := <-access.C:
+			if !ok || app.streamAccessRevoked(user, app.contextGetToken(r), event) {
+				return
+			}
+		case <-heartbeat.C:
+			if !send(": heartbeat\n\n") {
+				return
+			}
+		}
+	}
+}
+
+// streamAccessRevoked reports whether the event means that the user may no longer read
+// an event stream opened with the token. Errors are logged and count as revoked.
+func (app *application) streamAccessRevoked(user *data.User, token string, event eventbus.Event) bool {
+	switch event.Topic {
+	case data.TopicTokensRevoked:
+		var revoked data.TokensRevokedEvent
+		err := event.Decode(&revoked)
+		if err != nil {
+			app.logger.Error(err.Error())
+			return true
+		}
+		if revoked.UserID != user.ID || revoked.Scope != data.ScopeAuthentication {
+			return false
+		}
+
+		// Some tokens may have been kept, such as the one that changed the password.
+		_, err = app.models.Users.GetForToken(data.ScopeAuthentication, token)
+		if err != nil {
+			if !errors.Is(err, data.ErrRecordNotFound) {
+				app.logger.Error(err.Error())
+			}
+			return true
+		}
+		return false
+
+	case data.TopicPermissionsChanged:
+		var changed data.UserEvent
+		err := event.Decode(&changed)
+		if err != nil {
+			app.logger.Error(err.Error())
+			return true
+		}
+		if changed.UserID != user.ID {
+			return false
+		}
+	}
+
+	// The permissions of the user changed, or events may have been missed.
+	permissions, err := app.models.Permissions.GetAllForUser(user.ID)
+	if err != nil {
+		app.logger.Error(err.Error())
+		return true
+	}
+	return !permissions.Include("movies:read")
+}
+
+// sendMovieChange writes a change to an event stream as a movie.<type> event.
+func (app *application) sendMovieChange(send func(format string, args ...any) bool, change *data.MovieChange) bool {
+	js, err := json.Marshal(change)
+	if err != nil {
+		app.logger.Error(err.Error())
+		return false
+	}
+
+	return send("id: %d\nevent: movie.%s\ndata: %s\n\n", change.Seq, change.Type, js)
+}
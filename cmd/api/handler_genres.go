@@ -0,0 +1,392 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      List All Genres
+// @Description  Retrieve the genre taxonomy. Movies are tagged with genre slugs, and the names and aliases of a genre are accepted wherever a genre is expected.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Genres
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{genres=[]data.Genre}  "List of genres ordered by slug"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /genres [get]
+func (app *application) listGenresHandler(w http.ResponseWriter, r *http.Request) {
+	genres, err := app.models.Genres.GetAll()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"genres": genres}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Get Genre by ID
+// @Description  Retrieve a single genre of the taxonomy.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Genres
+// @Produce      json
+// @Param        id   path      int  true  "Genre ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{genre=data.Genre}  "Genre details"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Genre not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /genres/{id} [get]
+func (app *application) showGenreHandler(w http.ResponseWriter, r *http.Request) {
+	genre, ok := app.readGenre(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.writeJSON(w, http.StatusOK, envelope{"genre": genre}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Create Genre (require genres:write permission)
+// @Description  Add a genre to the taxonomy.
+// @Description
+// @Description  **Permissions Required:** `genres:write`
+// @Description
+// @Description  **Validation Rules:**
+// @Description  - Slug: Required, lowercase letters, digits and single hyphens, max 100 characters, unique
+// @Description  - Name: Required, max 100 characters
+// @Description  - Aliases: Optional, max 20 unique values
+// @Description  - The slug, name and aliases must not already refer to another genre
+// @Tags         Genres
+// @Accept       json
+// @Produce      json
+// @Param        genre  body      object{slug=string, name=string, aliases=[]string}  true  "Genre creation data"
+// @Security     BearerAuth
+// @Success      201  {object}  object{genre=data.Genre}  "Genre created successfully"
+// @Header       201  {string}  Location  "URL of the created genre"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /genres [post]
+func (app *application) createGenreHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Slug    string   `json:"slug"`
+		Name    string   `json:"name"`
+		Aliases []string `json:"aliases"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	genre := &data.Genre{
+		Slug:    input.Slug,
+		Name:    input.Name,
+		Aliases: input.Aliases,
+	}
+
+	if genre.Aliases == nil {
+		genre.Aliases = []string{}
+	}
+
+	v := validator.New()
+	if data.ValidateGenre(v, genre); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	ok := app.checkGenreConflicts(w, r, v, genre)
+	if !ok {
+		return
+	}
+
+	err = app.models.Genres.Insert(genre)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateGenre):
+			v.AddError("slug", "a genre with this slug already exists")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/genres/%d", genre.ID))
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"genre": genre}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Update Genre (require genres:write permission)
+// @Description  Rename a genre or change its aliases. Only provided fields will be updated. Changing the slug retags every movie with the new slug, and the old slug is kept as an alias.
+// @Description
+// @Description  **Permissions Required:** `genres:write`
+// @Description
+// @Description  **Validation Rules:** Same as create operation
+// @Tags         Genres
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true  "Genre ID"  minimum(1)  example(1)
+// @Param        genre  body      object{slug=string, name=string, aliases=[]string}  true  "Genre update data (all fields optional)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{genre=data.Genre}  "Genre updated successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Genre not found"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - genre has been modified by another request"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /genres/{id} [patch]
+func (app *application) updateGenreHandler(w http.ResponseWriter, r *http.Request) {
+	genre, ok := app.readGenre(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Slug    *string  `json:"slug"`
+		Name    *string  `json:"name"`
+		Aliases []string `json:"aliases"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if input.Slug != nil {
+		genre.Slug = *input.Slug
+	}
+	if input.Name != nil {
+		genre.Name = *input.Name
+	}
+	if input.Aliases != nil {
+		genre.Aliases = input.Aliases
+	}
+
+	v := validator.New()
+	if data.ValidateGenre(v, genre); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	ok = app.checkGenreConflicts(w, r, v, genre)
+	if !ok {
+		return
+	}
+
+	err = app.models.Genres.Update(genre)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateGenre):
+			v.AddError("slug", "a genre with this slug already exists")
+			app.failedValidationResponse(w, r, v.Errors)
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"genre": genre}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Delete Genre (require genres:write permission)
+// @Description  Remove a genre from the taxonomy. Genres that are still used by movies cannot be deleted; merge them into another genre instead.
+// @Description
+// @Description  **Permissions Required:** `genres:write`
+// @Tags         Genres
+// @Produce      json
+// @Param        id   path      int  true  "Genre ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "Genre deleted successfully"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Genre not found"
+// @Failure      409  {object}  object{error=string}  "Conflict - genre is still used by movies"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /genres/{id} [delete]
+func (app *application) deleteGenreHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.Genres.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrGenreInUse):
+			app.errorResponse(w, r, http.StatusConflict, "the genre is still used by movies, merge it into another genre instead")
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "genre successfully deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Merge Genre (require genres:write permission)
+// @Description  Fold a genre into another one. Every movie tagged with the merged genre is retagged with the target genre, the slug, name and aliases of the merged genre become aliases of the target, and the merged genre is deleted.
+// @Description
+// @Description  **Permissions Required:** `genres:write`
+// @Tags         Genres
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true  "ID of the genre to merge"  minimum(1)  example(1)
+// @Param        merge  body      object{into=int64}  true  "ID of the genre to merge into"
+// @Security     BearerAuth
+// @Success      200  {object}  object{genre=data.Genre}  "Genres merged successfully, returns the target genre"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Genre not found"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - a genre has been modified by another request"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /genres/{id}/merge [post]
+func (app *application) mergeGenreHandler(w http.ResponseWriter, r *http.Request) {
+	source, ok := app.readGenre(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Into int64 `json:"into"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.Into > 0, "into", "must be provided")
+	v.Check(input.Into != source.ID, "into", "must not be the genre being merged")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	target, err := app.models.Genres.Get(input.Into)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("into", "genre does not exist")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Genres.Merge(source, target)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"genre": target}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// readGenre fetches the genre named by the id URL parameter. When ok is false a
+// response has already been sent.
+func (app *application) readGenre(w http.ResponseWriter, r *http.Request) (genre *data.Genre, ok bool) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	genre, err = app.models.Genres.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	return genre, true
+}
+
+// checkGenreConflicts makes sure that none of the slug, name or aliases of the genre
+// already refer to a different genre, since the taxonomy would become ambiguous. When
+// ok is false a response has already been sent.
+func (app *application) checkGenreConflicts(w http.ResponseWriter, r *http.Request, v *validator.Validator, genre *data.Genre) (ok bool) {
+	genres, err := app.models.Genres.GetAll()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return false
+	}
+
+	// Leave the genre itself out, so that its current slug, name and aliases don't
+	// conflict with the new ones.
+	others := make([]*data.Genre, 0, len(genres))
+	for _, g := range genres {
+		if g.ID != genre.ID {
+			others = append(others, g)
+		}
+	}
+
+	conflicts := data.NewGenreTaxonomy(others).Conflicts(genre)
+	if len(conflicts) > 0 {
+		v.AddError("genre", fmt.Sprintf("%s already refer to another genre", strings.Join(conflicts, ", ")))
+		app.failedValidationResponse(w, r, v.Errors)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      GraphQL Query
+// @Description  Run a GraphQL query or mutation. The schema covers the movies, with the same filters, sorting and paging as `GET /v1/movies`, the current user with their permissions, and mutations to create, update and delete movies. Use introspection to explore it.
+// @Description
+// @Description  **Permissions Required:** Checked per field. `movies` and `movie` require `movies:read`, the mutations require `movies:write`, and `me` is null for anonymous requests. A field the user may not access resolves to null with an error whose `extensions.code` is `UNAUTHENTICATED` or `FORBIDDEN`. Validation errors carry the code `FAILED_VALIDATION` and the messages per input field in `extensions.errors`.
+// @Description
+// @Description  **Limits:** Queries may nest fields at most 6 levels deep and have a complexity of at most 5000 by default. The complexity is the number of fields the query resolves, where the fields of a page of movies count once per movie of the page. Queries over the limits are rejected with 400 Bad Request.
+// @Tags         GraphQL
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object{query=string, operationName=string, variables=object}  true  "GraphQL request"
+// @Param        Accept-Language  header  string  false  "Preferred languages for the displayTitle of movies"  example(de-AT, de;q=0.9, en;q=0.5)
+// @Success      200  {object}  object{data=object, errors=[]object{message=string, extensions=object}}  "Result of the operation, with the errors of any fields that failed"
+// @Failure      400  {object}  object{errors=[]object{message=string}}  "Bad request - malformed JSON, or a query that doesn't parse, validate or fit the limits"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - invalid authentication token"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - missing query"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /graphql [post]
+func (app *application) graphqlHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+
+		// Extensions are sent by some clients, but none are supported.
+		Extensions map[string]any `json:"extensions"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+
+	if v.Check(input.Query != "", "query", "must be provided"); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: input.Query})
+	if err != nil {
+		app.graphqlErrorsResponse(w, r, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	validation := graphql.ValidateDocument(&app.graphql, document, nil)
+	if !validation.IsValid {
+		app.graphqlErrorsResponse(w, r, validation.Errors)
+		return
+	}
+
+	depth, complexity := newGraphQLCost(document, input.Variables).operation(input.OperationName)
+
+	if depth > app.config.GraphQL.MaxDepth {
+		err := fmt.Errorf("query is nested %d levels deep, the maximum is %d", depth, app.config.GraphQL.MaxDepth)
+		app.graphqlErrorsResponse(w, r, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	if complexity > app.config.GraphQL.MaxComplexity {
+		err := fmt.Errorf("query has a complexity of %d, the maximum is %d", complexity, app.config.GraphQL.MaxComplexity)
+		app.graphqlErrorsResponse(w, r, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	// The display titles of movies depend on the header, so caches must keep one copy
+	// of the response per language.
+	w.Header().Add("Vary", "Accept-Language")
+
+	ctx := context.WithValue(r.Context(), graphqlContextKey, app.newGraphQLRequest(r))
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        app.graphql,
+		AST:           document,
+		OperationName: input.OperationName,
+		Args:          input.Variables,
+		Context:       ctx,
+	})
+
+	env := envelope{"data": result.Data}
+	if result.HasErrors() {
+		env["errors"] = result.Errors
+	}
+
+	err = app.writeJSON(w, http.StatusOK, env, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/imaging"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      Upload Movie Poster (require movies:write permission)
+// @Description  Upload a poster image for a movie as a `multipart/form-data` request with the image in the `image` field. Resized copies are generated and exposed in the movie's `poster_urls` field. Uploading a new poster replaces the previous one.
+// @Description
+// @Description  **Permissions Required:** `movies:write`
+// @Description
+// @Description  **Accepted Formats:** JPEG and PNG (detected from the file content, not the file name)
+// @Description
+// @Description  **Generated Sizes:** w185, w342, w500, original
+// @Tags         Movies
+// @Accept       mpfd
+// @Produce      json
+// @Param        id     path      int   true  "Movie ID"  minimum(1)  example(1)
+// @Param        image  formData  file  true  "Poster image (JPEG or PNG)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{movie=data.Movie}  "Poster uploaded successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed multipart form or upload too large"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - movie has been modified by another request"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - missing or unsupported image"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/poster [put]
+func (app *application) updateMoviePosterHandler(w http.ResponseWriter, r *http.Request) {
+	app.uploadMovieImage(w, r, data.ImagePoster)
+}
+
+// @Summary      Upload Movie Backdrop (require movies:write permission)
+// @Description  Upload a backdrop image for a movie as a `multipart/form-data` request with the image in the `image` field. Resized copies are generated and exposed in the movie's `backdrop_urls` field. Uploading a new backdrop replaces the previous one.
+// @Description
+// @Description  **Permissions Required:** `movies:write`
+// @Description
+// @Description  **Accepted Formats:** JPEG and PNG (detected from the file content, not the file name)
+// @Description
+// @Description  **Generated Sizes:** w300, w780, w1280, original
+// @Tags         Movies
+// @Accept       mpfd
+// @Produce      json
+// @Param        id     path      int   true  "Movie ID"  minimum(1)  example(1)
+// @Param        image  formData  file  true  "Backdrop image (JPEG or PNG)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{movie=data.Movie}  "Backdrop uploaded successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed multipart form or upload too large"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - movie has been modified by another request"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - missing or unsupported image"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/backdrop [put]
+func (app *application) updateMovieBackdropHandler(w http.ResponseWriter, r *http.Request) {
+	app.uploadMovieImage(w, r, data.ImageBackdrop)
+}
+
+func (app *application) uploadMovieImage(w http.ResponseWriter, r *http.Request, kind string) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	movie, err := app.models.Movies.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// Image uploads are a lot bigger than the JSON bodies that the server timeouts
+	// are tuned for, so give this request more time to be read and answered.
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Now().Add(time.Minute))
+	rc.SetWriteDeadline(time.Now().Add(time.Minute))
+
+	r.Body = http.MaxBytesReader(w, r.Body, app.config.Storage.MaxUploadSize)
+
+	// Keep up to 1MB of the upload in memory, the rest is buffered in temporary files.
+	err = r.ParseMultipartForm(1 << 20)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesError):
+			app.badRequestResponse(w, r, fmt.Errorf("body must not be larger than %d bytes", maxBytesError.Limit))
+		default:
+			app.badRequestResponse(w, r, errors.New("body must be a valid multipart form"))
+		}
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	v := validator.New()
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		v.AddError("image", "must be provided")
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+	defer file.Close()
+
+	img, format, err := imaging.Decode(file)
+	if err != nil {
+		switch {
+		case errors.Is(err, imaging.ErrUnsupportedFormat):
+			v.AddError("image", "must be a JPEG or PNG image")
+		case errors.Is(err, imaging.ErrImageTooLarge):
+			v.AddError("image", "must not be larger than 50 megapixels")
+		default:
+			v.AddError("image", "must be a valid image")
+		}
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	urls, err := app.storeMovieImage(r.Context(), movie.ID, kind, img, format)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	var previous data.ImageURLs
+	switch kind {
+	case data.ImagePoster:
+		previous, movie.PosterURLs = movie.PosterURLs, urls
+	case data.ImageBackdrop:
+		previous, movie.BackdropURLs = movie.BackdropURLs, urls
+	}
+
+	err = app.models.Movies.Update(movie)
+	if err != nil {
+		// The new files will never be referenced, so clean them up again.
+		app.deleteMovieImage(urls)
+
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.deleteMovieImage(previous)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"movie": movie}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// storeMovieImage resizes the image to all of the sizes configured for its kind and
+// stores them, returning the public URL of each size. Every upload gets a fresh random
+// key, so stored files never change and can be cached forever.
+func (app *application) storeMovieImage(ctx context.Context, movieID int64, kind string, img image.Image, format string) (data.ImageURLs, error) {
+	prefix := fmt.Sprintf("movies/%d/%s/%s", movieID, kind, strings.ToLower(rand.Text()[:10]))
+	urls := make(data.ImageURLs)
+
+	for size, width := range data.ImageSizes[kind] {
+		var buf bytes.Buffer
+
+		err := imaging.Encode(&buf, imaging.Resize(img, width), format)
+		if err != nil {
+			app.deleteMovieImage(urls)
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s-%s.%s", prefix, size, imaging.Extension(format))
+
+		err = app.storage.Put(ctx, key, &buf, imaging.ContentType(format))
+		if err != nil {
+			app.deleteMovieImage(urls)
+			return nil, err
+		}
+
+		urls[size] = app.storage.URL(key)
+	}
+
+	return urls, nil
+}
+
+// deleteMovieImage removes the stored files behind the given URLs. Failures are only
+// logged, since a leftover file doesn't affect clients.
+func (app *application) deleteMovieImage(urls data.ImageURLs) {
+	for _, url := range urls {
+		key, ok := app.storage.Key(url)
+		if !ok {
+			continue
+		}
+
+		err := app.storage.Delete(context.Background(), key)
+		if err != nil {
+			app.logger.Error(err.Error(), "key", key)
+		}
+	}
+}
@@ -0,0 +1,597 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      List My Movie Lists
+// @Description  Retrieve a paginated list of the movie lists owned by the authenticated user, regardless of their visibility.
+// @Tags         Lists
+// @Produce      json
+// @Param        page       query     int     false  "Page number (minimum: 1, maximum: 10,000,000)"  default(1)  minimum(1)  maximum(10000000)
+// @Param        page_size  query     int     false  "Items per page (minimum: 1, maximum: 100)"  default(20)  minimum(1)  maximum(100)
+// @Param        sort       query     string  false  "Sort field"  default(id)  Enums(id, name, created_at, -id, -name, -created_at)
+// @Security     BearerAuth
+// @Success      200  {object}  object{lists=[]data.List, metadata=data.Metadata}  "List of movie lists with pagination metadata"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists [get]
+func (app *application) listListsHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	v := validator.New()
+	qs := r.URL.Query()
+
+	filter := data.Filter{
+		Page:         app.readQueryInt(qs, "page", 1, v),
+		PageSize:     app.readQueryInt(qs, "page_size", 20, v),
+		Sort:         app.readQueryString(qs, "sort", "id"),
+		SortSafelist: []string{"id", "name", "created_at", "-id", "-name", "-created_at"},
+	}
+
+	if data.ValidateFilters(v, filter); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	lists, metadata, err := app.models.Lists.GetAllForUser(user.ID, filter)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"lists": lists, "metadata": metadata}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Create Movie List
+// @Description  Create a new named movie list owned by the authenticated user.
+// @Description
+// @Description  **Visibility:**
+// @Description  - `private`: only visible to the owner (default)
+// @Description  - `unlisted`: visible to anyone holding the generated `share_token`
+// @Description  - `public`: visible to everyone
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        list  body      object{name=string, description=string, visibility=string}  true  "List creation data"
+// @Security     BearerAuth
+// @Success      201  {object}  object{list=data.List}  "List created successfully"
+// @Header       201  {string}  Location  "URL of the created list"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists [post]
+func (app *application) createListHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Visibility  string `json:"visibility"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	list := &data.List{
+		UserID:      app.contextGetUser(r).ID,
+		Name:        input.Name,
+		Description: input.Description,
+		Visibility:  input.Visibility,
+	}
+
+	if list.Visibility == "" {
+		list.Visibility = data.VisibilityPrivate
+	}
+
+	v := validator.New()
+	if data.ValidateList(v, list); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Lists.Insert(list)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/lists/%d", list.ID))
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"list": list}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Get Movie List by ID
+// @Description  Retrieve a movie list. Private lists are only visible to their owner, unlisted lists additionally require the `share_token` query parameter, and public lists are visible to everyone.
+// @Tags         Lists
+// @Produce      json
+// @Param        id           path      int     true   "List ID"  minimum(1)  example(1)
+// @Param        share_token  query     string  false  "Share token of an unlisted list"
+// @Success      200  {object}  object{list=data.List}  "List details"
+// @Failure      404  {object}  object{error=string}  "List not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id} [get]
+func (app *application) showListHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readVisibleList(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.writeJSON(w, http.StatusOK, envelope{"list": list}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Update Movie List
+// @Description  Update the name, description or visibility of a list owned by the authenticated user. Only provided fields will be updated. Switching a list to `unlisted` generates a new share token, and switching away from `unlisted` revokes it.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true  "List ID"  minimum(1)  example(1)
+// @Param        list  body      object{name=string, description=string, visibility=string}  true  "List update data (all fields optional)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{list=data.List}  "List updated successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or not the owner of the list"
+// @Failure      404  {object}  object{error=string}  "List not found"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - list has been modified by another request"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id} [patch]
+func (app *application) updateListHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readOwnedList(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		Visibility  *string `json:"visibility"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if input.Name != nil {
+		list.Name = *input.Name
+	}
+	if input.Description != nil {
+		list.Description = *input.Description
+	}
+	if input.Visibility != nil {
+		list.Visibility = *input.Visibility
+	}
+
+	v := validator.New()
+	if data.ValidateList(v, list); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Lists.Update(list)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"list": list}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Delete Movie List
+// @Description  Permanently delete a list owned by the authenticated user, together with all of its items.
+// @Tags         Lists
+// @Produce      json
+// @Param        id   path      int  true  "List ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "List deleted successfully"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or not the owner of the list"
+// @Failure      404  {object}  object{error=string}  "List not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id} [delete]
+func (app *application) deleteListHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readOwnedList(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.models.Lists.Delete(list.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "list successfully deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      List Movie List Items
+// @Description  Retrieve the movies in a list in their explicit order. The same visibility rules as for reading the list apply.
+// @Description
+// @Description  The items embed the full movie records only for activated users with the `movies:read` permission. Everyone else gets the movie ids alone.
+// @Tags         Lists
+// @Produce      json
+// @Param        id           path      int     true   "List ID"  minimum(1)  example(1)
+// @Param        share_token  query     string  false  "Share token of an unlisted list"
+// @Success      200  {object}  object{items=[]data.ListItem}  "Ordered list items"
+// @Failure      404  {object}  object{error=string}  "List not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id}/items [get]
+func (app *application) listListItemsHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readVisibleList(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := app.models.Lists.GetItems(list.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.hideListItemMovies(r, items...)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"items": items}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Add Movie to List
+// @Description  Add a movie to a list owned by the authenticated user. The movie is inserted at `position` (1-based), shifting later items down. When `position` is omitted the movie is appended to the end of the list.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true  "List ID"  minimum(1)  example(1)
+// @Param        item  body      object{movie_id=int64, position=int, note=string}  true  "List item data"
+// @Security     BearerAuth
+// @Success      201  {object}  object{item=data.ListItem}  "Movie added to the list"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or not the owner of the list"
+// @Failure      404  {object}  object{error=string}  "List not found"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors (e.g., unknown movie, movie already in list)"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id}/items [post]
+func (app *application) addListItemHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readOwnedList(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		MovieID  int64  `json:"movie_id"`
+		Position int    `json:"position"`
+		Note     string `json:"note"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	item := &data.ListItem{
+		MovieID:  input.MovieID,
+		Position: input.Position,
+		Note:     input.Note,
+	}
+
+	v := validator.New()
+	if data.ValidateListItem(v, item); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	item.Movie, err = app.models.Movies.Get(item.MovieID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("movie_id", "movie does not exist")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Lists.AddItem(list.ID, item)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateListItem):
+			v.AddError("movie_id", "movie is already in this list")
+			app.failedValidationResponse(w, r, v.Errors)
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.hideListItemMovies(r, item)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"item": item}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Update Movie List Item
+// @Description  Move an item to a new position and/or change its note. Only provided fields will be updated. Positions beyond the ends of the list are clamped.
+// @Tags         Lists
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int     true  "List ID"  minimum(1)  example(1)
+// @Param        movie_id  path      int     true  "Movie ID"  minimum(1)  example(1)
+// @Param        item      body      object{position=int, note=string}  true  "List item update data (all fields optional)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{item=data.ListItem}  "List item updated successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or not the owner of the list"
+// @Failure      404  {object}  object{error=string}  "List or list item not found"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id}/items/{movie_id} [patch]
+func (app *application) updateListItemHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readOwnedList(w, r)
+	if !ok {
+		return
+	}
+
+	movieID, err := app.readInt64Param(r, "movie_id")
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	item, err := app.models.Lists.GetItem(list.ID, movieID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	var input struct {
+		Position *int    `json:"position"`
+		Note     *string `json:"note"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if input.Position != nil {
+		item.Position = *input.Position
+	}
+	if input.Note != nil {
+		item.Note = *input.Note
+	}
+
+	v := validator.New()
+	v.Check(item.Position >= 1, "position", "must be greater than zero")
+	if data.ValidateListItem(v, item); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Lists.UpdateItem(list.ID, item)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.hideListItemMovies(r, item)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"item": item}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Remove Movie from List
+// @Description  Remove a movie from a list owned by the authenticated user. The items after it move up to close the gap.
+// @Tags         Lists
+// @Produce      json
+// @Param        id        path      int  true  "List ID"  minimum(1)  example(1)
+// @Param        movie_id  path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "Movie removed from the list"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or not the owner of the list"
+// @Failure      404  {object}  object{error=string}  "List or list item not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /lists/{id}/items/{movie_id} [delete]
+func (app *application) removeListItemHandler(w http.ResponseWriter, r *http.Request) {
+	list, ok := app.readOwnedList(w, r)
+	if !ok {
+		return
+	}
+
+	movieID, err := app.readInt64Param(r, "movie_id")
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.Lists.RemoveItem(list.ID, movieID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "movie successfully removed from list"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// readVisibleList fetches the list named by the id URL parameter and checks that the
+// current user is allowed to see it. Lists that aren't visible are reported as not
+// found, so that their existence isn't leaked. When ok is false a response has
+// already been sent.
+func (app *application) readVisibleList(w http.ResponseWriter, r *http.Request) (list *data.List, ok bool) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	list, err = app.models.Lists.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	user := app.contextGetUser(r)
+	if !list.VisibleTo(user, r.URL.Query().Get("share_token")) {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	// Only the owner gets to see (and hand out) the share token.
+	if !list.OwnedBy(user) {
+		list.ShareToken = ""
+	}
+
+	return list, true
+}
+
+// hideListItemMovies drops the embedded movies from the items unless the current user
+// may read the catalog, as requirePermission("movies:read") would allow. Public and
+// unlisted lists can be read by anyone, and must not serve as a way around the
+// permission.
+func (app *application) hideListItemMovies(r *http.Request, items ...*data.ListItem) error {
+	user := app.contextGetUser(r)
+
+	if !user.IsAnonymous() && user.Activated {
+		permissions, err := app.models.Permissions.GetAllForUser(user.ID)
+		if err != nil {
+			return err
+		}
+		if permissions.Include("movies:read") {
+			return nil
+		}
+	}
+
+	for _, item := range items {
+		item.Movie = nil
+	}
+	return nil
+}
+
+// readOwnedList fetches the list named by the id URL parameter and checks that it
+// belongs to the current user. When ok is false a response has already been sent.
+func (app *application) readOwnedList(w http.ResponseWriter, r *http.Request) (list *data.List, ok bool) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	list, err = app.models.Lists.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	user := app.contextGetUser(r)
+	if !list.OwnedBy(user) {
+		// Someone else's list that the user can see anyway gets a 403, while lists they
+		// can't see at all stay hidden behind a 404.
+		if list.VisibleTo(user, r.URL.Query().Get("share_token")) {
+			app.notPermittedResponse(w, r)
+		} else {
+			app.notFoundResponse(w, r)
+		}
+		return nil, false
+	}
+
+	return list, true
+}
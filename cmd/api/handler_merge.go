@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      Merge Duplicate Movie (require movies:merge permission)
+// @Description  Fold a duplicate movie into the canonical movie. The list entries and alternate titles of the duplicate move to the canonical movie, external ids that only the duplicate has are copied over, and the duplicate is deleted. Afterwards `GET /v1/movies/{id}` on the duplicate's id answers with 301 Moved Permanently pointing at the canonical movie.
+// @Description
+// @Description  **Permissions Required:** `movies:merge`
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true  "ID of the duplicate movie"  minimum(1)  example(2)
+// @Param        merge  body      object{into=int64}  true  "ID of the canonical movie"
+// @Security     BearerAuth
+// @Success      200  {object}  object{movie=data.Movie}  "Movies merged successfully, returns the canonical movie"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - a movie has been modified by another request"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/merge [post]
+func (app *application) mergeMovieHandler(w http.ResponseWriter, r *http.Request) {
+	duplicate, ok := app.readMovie(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Into int64 `json:"into"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.Into > 0, "into", "must be provided")
+	v.Check(input.Into != duplicate.ID, "into", "must not be the movie being merged")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	canonical, err := app.models.Movies.Get(input.Into)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("into", "movie does not exist")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Movies.Merge(duplicate, canonical)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// The images of the duplicate aren't referenced by anything anymore.
+	app.deleteMovieImage(duplicate.PosterURLs)
+	app.deleteMovieImage(duplicate.BackdropURLs)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"movie": canonical}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// redirectMergedMovie answers a request for a movie that no longer exists. If the movie
+// was merged into another movie, the client is sent there with 301 Moved Permanently,
+// otherwise the movie is reported as not found.
+func (app *application) redirectMergedMovie(w http.ResponseWriter, r *http.Request, id int64) {
+	movieID, err := app.models.Movies.GetRedirect(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/movies/%d", movieID))
+
+	err = app.writeJSON(w, http.StatusMovedPermanently, envelope{"message": "the movie has been merged into another movie"}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -4,31 +4,46 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
+	"github.com/julienschmidt/httprouter"
 	"github.com/ucok-man/gmoapi/internal/data"
 	"github.com/ucok-man/gmoapi/internal/validator"
 )
 
 // @Summary      List All Movies
-// @Description  Retrieve a paginated list of movies with optional filtering by title (full-text search) and genres. Supports sorting by multiple fields.
+// @Description  Retrieve a paginated list of movies with optional filtering by title (full-text search) and genres. Supports sorting by multiple fields. The `display_title` of each movie is picked from its alternate titles according to the `Accept-Language` header.
 // @Description
 // @Description  **Permissions Required:** `movies:read`
 // @Description
 // @Description  **Filtering:**
-// @Description  - Title: Partial match using PostgreSQL full-text search
-// @Description  - Genres: Multiple genres can be specified (comma-separated)
+// @Description  - Title: Partial match using PostgreSQL full-text search, on the title and the alternate titles
+// @Description  - Genres: Multiple genres can be specified (comma-separated), by slug, name or alias
+// @Description  - External IDs: Exact match on the IMDb, TMDb or Wikidata id
+// @Description  - Status: One or more release statuses (comma-separated)
+// @Description  - Certification: `certification_max` together with `country` only returns movies certified in that country, with no certification there above the maximum. The certifications are ordered per country, e.g. for US: G < PG < PG-13 < R < NC-17
+// @Description  - Updated Since: Only movies created or changed at or after the given RFC 3339 time, for incremental sync. Combine with `sort=updated_at` to page through the changes in order
 // @Description
 // @Description  **Sorting:**
 // @Description  - Prefix with `-` for descending order (e.g., `-year`)
-// @Description  - Available fields: id, title, year, runtime
+// @Description  - Available fields: id, title, year, runtime, release_date (movies without a release date come last), updated_at
 // @Tags         Movies
 // @Accept       json
 // @Produce      json
 // @Param        title      query     string  false  "Filter by movie title (partial match, case-insensitive)"  example(Godfather)
 // @Param        genres     query     string  false  "Filter by genres (comma-separated)"  example(drama,crime)
+// @Param        status     query     string  false  "Filter by release status (comma-separated: announced, in_production, released)"  example(announced,in_production)
+// @Param        certification_max  query  string  false  "Most restrictive certification to include, requires country"  example(PG-13)
+// @Param        country    query     string  false  "Country of the certification_max filter (ISO 3166-1 alpha-2)"  example(US)
+// @Param        imdb       query     string  false  "Filter by IMDb id"  example(tt0068646)
+// @Param        tmdb       query     int     false  "Filter by TMDb id"  example(238)
+// @Param        wikidata   query     string  false  "Filter by Wikidata id"  example(Q47703)
+// @Param        updated_since  query  string  false  "Only movies updated at or after this RFC 3339 time"  example(2025-10-01T00:00:00Z)
 // @Param        page       query     int     false  "Page number (minimum: 1, maximum: 10,000,000)"  default(1)  minimum(1)  maximum(10000000)
 // @Param        page_size  query     int     false  "Items per page (minimum: 1, maximum: 100)"  default(20)  minimum(1)  maximum(100)
-// @Param        sort       query     string  false  "Sort field"  default(id)  Enums(id, title, year, runtime, -id, -title, -year, -runtime)
+// @Param        runtime_format  query  string  false  "Format of the runtimes in the response"  default(mins)  Enums(mins, minutes_int, iso8601, human)
+// @Param        sort       query     string  false  "Sort field"  default(id)  Enums(id, title, year, runtime, release_date, updated_at, -id, -title, -year, -runtime, -release_date, -updated_at)
+// @Param        Accept-Language  header  string  false  "Preferred languages for the display title"  example(de-AT, de;q=0.9, en;q=0.5)
 // @Security     BearerAuth
 // @Success      200  {object}  object{movies=[]data.Movie, metadata=data.Metadata}  "List of movies with pagination metadata"
 // @Failure      400  {object}  object{error=string}  "Bad request - invalid query parameters"
@@ -40,8 +55,7 @@ import (
 // @Router       /movies [get]
 func (app *application) listMoviesHandler(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Title  string
-		Genres []string
+		Search data.MovieSearch
 		Filter data.Filter
 	}
 
@@ -49,25 +63,47 @@ func (app *application) listMoviesHandler(w http.ResponseWriter, r *http.Request
 
 	qs := r.URL.Query()
 
-	input.Title = app.readQueryString(qs, "title", "")
-	input.Genres = app.readQueryStrings(qs, "genres", []string{})
+	input.Search.Title = app.readQueryString(qs, "title", "")
+	input.Search.Genres = app.readQueryStrings(qs, "genres", []string{})
+	input.Search.Statuses = app.readQueryStrings(qs, "status", []string{})
+	runtimeFormat := app.readRuntimeFormat(qs, v)
+	input.Search.ExternalIDs = app.readExternalIDs(qs, v)
+	input.Search.UpdatedSince = app.readQueryTime(qs, "updated_since", v)
+
+	country := app.readQueryString(qs, "country", "")
+	certificationMax := app.readQueryString(qs, "certification_max", "")
 
 	input.Filter.Page = app.readQueryInt(qs, "page", 1, v)
 	input.Filter.PageSize = app.readQueryInt(qs, "page_size", 20, v)
 	input.Filter.Sort = app.readQueryString(qs, "sort", "id")
-	input.Filter.SortSafelist = []string{"id", "title", "year", "runtime", "-id", "-title", "-year", "-runtime"}
 
-	if data.ValidateFilters(v, input.Filter); !v.Valid() {
+	err := app.prepareMovieSearch(v, &input.Search, &input.Filter, country, certificationMax)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	movies, metadata, err := app.models.Movies.GetAll(input.Title, input.Genres, input.Filter)
+	movies, metadata, err := app.models.Movies.GetAll(input.Search, input.Filter)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.setDisplayTitles(w, r, movies...)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
+	for _, movie := range movies {
+		movie.RuntimeFormat = runtimeFormat
+	}
+
 	err = app.writeJSON(w, http.StatusOK, envelope{"movies": movies, "metadata": metadata}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
@@ -76,14 +112,21 @@ func (app *application) listMoviesHandler(w http.ResponseWriter, r *http.Request
 }
 
 // @Summary      Get Movie by ID
-// @Description  Retrieve detailed information about a specific movie by its unique ID.
+// @Description  Retrieve detailed information about a specific movie by its unique ID. The `display_title` is picked from the alternate titles of the movie according to the `Accept-Language` header.
+// @Description
+// @Description  The `Last-Modified` header holds the time the movie was last updated. Send it back in `If-Modified-Since` to get 304 Not Modified while the movie is unchanged.
 // @Description
 // @Description  **Permissions Required:** `movies:read`
 // @Tags         Movies
 // @Produce      json
 // @Param        id   path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Param        runtime_format  query  string  false  "Format of the runtime in the response"  default(mins)  Enums(mins, minutes_int, iso8601, human)
+// @Param        Accept-Language  header  string  false  "Preferred languages for the display title"  example(de-AT, de;q=0.9, en;q=0.5)
+// @Param        If-Modified-Since  header  string  false  "Last-Modified value of a cached copy"  example(Wed, 22 Oct 2025 07:48:50 GMT)
 // @Security     BearerAuth
 // @Success      200  {object}  object{movie=data.Movie}  "Movie details"
+// @Success      304  "The movie hasn't changed since If-Modified-Since"
+// @Success      301  {object}  object{message=string}  "The movie was merged into another movie, which the Location header points to"
 // @Failure      404  {object}  object{error=string}  "Movie not found"
 // @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
 // @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
@@ -91,23 +134,54 @@ func (app *application) listMoviesHandler(w http.ResponseWriter, r *http.Request
 // @Failure      500  {object}  object{error=string}  "Internal server error"
 // @Router       /movies/{id} [get]
 func (app *application) showMovieHandler(w http.ResponseWriter, r *http.Request) {
+	// httprouter doesn't allow /v1/movies/lookup to be registered next to
+	// /v1/movies/:id, so the lookup endpoint is dispatched from here instead.
+	if httprouter.ParamsFromContext(r.Context()).ByName("id") == "lookup" {
+		app.lookupMovieHandler(w, r)
+		return
+	}
+
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
 		return
 	}
 
+	v := validator.New()
+
+	runtimeFormat := app.readRuntimeFormat(r.URL.Query(), v)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
 	movie, err := app.models.Movies.Get(id)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
-			app.notFoundResponse(w, r)
+			app.redirectMergedMovie(w, r, id)
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
 		return
 	}
 
+	w.Header().Set("Last-Modified", movie.UpdatedAt.UTC().Format(http.TimeFormat))
+	varyOnAcceptLanguage(w)
+
+	if app.notModifiedSince(r, movie.UpdatedAt) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	err = app.setDisplayTitles(w, r, movie)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	movie.RuntimeFormat = runtimeFormat
+
 	err = app.writeJSON(w, http.StatusOK, envelope{"movie": movie}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
@@ -115,26 +189,37 @@ func (app *application) showMovieHandler(w http.ResponseWriter, r *http.Request)
 }
 
 // @Summary      Create New Movie (require movies:write permission)
-// @Description  Create a new movie entry in the catalog. All fields are required.
+// @Description  Create a new movie entry in the catalog.
+// @Description
+// @Description  **Duplicate Detection:** A movie whose title matches an existing movie (ignoring case and punctuation), released in the same year and with a runtime within 5 minutes, is rejected with 409 Conflict and the ids of the existing movies in `candidates`. Pass `force=true` to create it anyway.
+// @Description
+// @Description  **Retries:** Send an `Idempotency-Key` header to make the request safe to retry. A retry with the same key and body gets the original response, with the `Idempotent-Replayed: true` header, instead of creating the movie again.
 // @Description
 // @Description  **Permissions Required:** `movies:write`
 // @Description
 // @Description  **Validation Rules:**
 // @Description  - Title: Required, max 500 characters
-// @Description  - Year: Required, between 1888 and current year
-// @Description  - Runtime: Required, positive integer, format "123 mins"
-// @Description  - Genres: Required, 1-5 unique genres
+// @Description  - Status: Optional, one of `announced`, `in_production` or `released` (default)
+// @Description  - Year: Required unless a release date is given, from 1888 up to the current year for released movies, or up to 10 years ahead otherwise
+// @Description  - Release Date: Optional, `YYYY-MM-DD` in the movie's year, not in the future for released movies
+// @Description  - Runtime: Required for released movies, positive number of minutes, at most 60000. Accepted as a JSON integer (`135`), `"135 mins"`, `"2h 15m"` or an ISO 8601 duration (`"PT2H15M"`)
+// @Description  - Genres: Required, 1-5 unique genres, each the slug, name or alias of a known genre (stored as slugs)
+// @Description  - External IDs: Optional, IMDb title id (e.g. `tt0068646`), positive TMDb id and Wikidata item id (e.g. `Q47703`), each unique across movies
 // @Tags         Movies
 // @Accept       json
 // @Produce      json
-// @Param        movie  body      object{title=string, year=int32, runtime=string, genres=[]string}  true  "Movie creation data"
+// @Param        movie  body      object{title=string, year=int32, runtime=string, genres=[]string, status=string, release_date=string, external_ids=data.ExternalIDs}  true  "Movie creation data"
+// @Param        force  query     bool    false  "Create the movie even if likely duplicates exist"  default(false)
+// @Param        Idempotency-Key  header  string  false  "Makes the request safe to retry, retries with the same key and body get the original response"  example(5f1c3b9e-7d2a-4c8e-9b61-2a4f0d8e7c13)
+// @Param        runtime_format  query  string  false  "Format of the runtime in the response"  default(mins)  Enums(mins, minutes_int, iso8601, human)
 // @Security     BearerAuth
 // @Success      201  {object}  object{movie=data.Movie}  "Movie created successfully"
 // @Header       201  {string}  Location  "URL of the created movie"
 // @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
 // @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
 // @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
-// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      409  {object}  object{error=string, candidates=[]int64}  "Conflict - likely duplicates of the movie already exist, or a request with the same Idempotency-Key is still being processed"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors, or an Idempotency-Key used for a different request"
 // @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
 // @Failure      500  {object}  object{error=string}  "Internal server error"
 // @Router       /movies [post]
@@ -144,6 +229,11 @@ func (app *application) createMovieHandler(w http.ResponseWriter, r *http.Reques
 		Year    int32        `json:"year"`
 		Runtime data.Runtime `json:"runtime"`
 		Genres  []string     `json:"genres"`
+
+		Status      string    `json:"status"`
+		ReleaseDate data.Date `json:"release_date"`
+
+		ExternalIDs data.ExternalIDs `json:"external_ids"`
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -157,18 +247,54 @@ func (app *application) createMovieHandler(w http.ResponseWriter, r *http.Reques
 		Year:    input.Year,
 		Runtime: input.Runtime,
 		Genres:  input.Genres,
+
+		Status:      input.Status,
+		ReleaseDate: input.ReleaseDate,
+
+		ExternalIDs: input.ExternalIDs,
+	}
+
+	if movie.Status == "" {
+		movie.Status = data.StatusReleased
+	}
+
+	taxonomy, err := app.models.Genres.GetTaxonomy()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
 	}
 
 	v := validator.New()
 
-	if data.ValidateMovie(v, movie); !v.Valid() {
+	force := app.readQueryBool(r.URL.Query(), "force", false, v)
+	movie.RuntimeFormat = app.readRuntimeFormat(r.URL.Query(), v)
+
+	if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
+	if !force {
+		candidates, err := app.models.Movies.FindDuplicates(movie)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+
+		if len(candidates) > 0 {
+			app.duplicateMovieResponse(w, r, candidates)
+			return
+		}
+	}
+
 	err = app.models.Movies.Insert(movie)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		switch {
+		case app.addExternalIDError(v, err):
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
 		return
 	}
 
@@ -195,7 +321,8 @@ func (app *application) createMovieHandler(w http.ResponseWriter, r *http.Reques
 // @Accept       json
 // @Produce      json
 // @Param        id     path      int     true  "Movie ID"  minimum(1)  example(1)
-// @Param        movie  body      object{title=string, year=int32, runtime=string, genres=[]string}  true  "Movie update data (all fields optional)"
+// @Param        movie  body      object{title=string, year=int32, runtime=string, genres=[]string, status=string, release_date=string, external_ids=data.ExternalIDs}  true  "Movie update data (all fields optional, external_ids replaces all external ids, a null release_date clears it)"
+// @Param        runtime_format  query  string  false  "Format of the runtime in the response"  default(mins)  Enums(mins, minutes_int, iso8601, human)
 // @Security     BearerAuth
 // @Success      200  {object}  object{movie=data.Movie}  "Movie updated successfully"
 // @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
@@ -230,6 +357,11 @@ func (app *application) updateMovieHandler(w http.ResponseWriter, r *http.Reques
 		Year    *int32        `json:"year"`
 		Runtime *data.Runtime `json:"runtime"`
 		Genres  []string      `json:"genres"`
+
+		Status      *string           `json:"status"`
+		ReleaseDate data.NullableDate `json:"release_date"`
+
+		ExternalIDs *data.ExternalIDs `json:"external_ids"`
 	}
 
 	err = app.readJSON(w, r, &input)
@@ -251,9 +383,27 @@ func (app *application) updateMovieHandler(w http.ResponseWriter, r *http.Reques
 	if input.Genres != nil {
 		movie.Genres = input.Genres
 	}
+	if input.Status != nil {
+		movie.Status = *input.Status
+	}
+	if input.ReleaseDate.Set {
+		movie.ReleaseDate = input.ReleaseDate.Date
+	}
+	if input.ExternalIDs != nil {
+		movie.ExternalIDs = *input.ExternalIDs
+	}
+
+	taxonomy, err := app.models.Genres.GetTaxonomy()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
 
 	v := validator.New()
-	if data.ValidateMovie(v, movie); !v.Valid() {
+
+	movie.RuntimeFormat = app.readRuntimeFormat(r.URL.Query(), v)
+
+	if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
@@ -263,6 +413,8 @@ func (app *application) updateMovieHandler(w http.ResponseWriter, r *http.Reques
 		switch {
 		case errors.Is(err, data.ErrEditConflict):
 			app.editConflictResponse(w, r)
+		case app.addExternalIDError(v, err):
+			app.failedValidationResponse(w, r, v.Errors)
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
@@ -313,3 +465,171 @@ func (app *application) deleteMovieHandler(w http.ResponseWriter, r *http.Reques
 		app.serverErrorResponse(w, r, err)
 	}
 }
+
+// readMovie fetches the movie named by the id URL parameter. When ok is false a
+// response has already been sent.
+func (app *application) readMovie(w http.ResponseWriter, r *http.Request) (movie *data.Movie, ok bool) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	movie, err = app.models.Movies.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	return movie, true
+}
+
+// prepareMovieSearch checks the search and paging of a movie listing, recording the
+// problems in v. The certification_max filter is turned into the certifications that
+// it includes, and the genres are resolved to their slugs. Only a failure to read the
+// genre taxonomy is returned as an error.
+func (app *application) prepareMovieSearch(v *validator.Validator, search *data.MovieSearch, filter *data.Filter, country, certificationMax string) error {
+	if certificationMax != "" {
+		certifications, ok := data.CertificationsUpTo(country, certificationMax)
+		switch {
+		case country == "":
+			v.AddError("country", "must be provided together with certification_max")
+		case data.CertificationSystems[country] == nil:
+			v.AddError("country", "must be a country with a known certification system")
+		case !ok:
+			v.AddError("certification_max", "must be a certification of the country's rating system")
+		default:
+			search.CertificationCountry = country
+			search.Certifications = certifications
+		}
+	}
+
+	// Movies are tagged with genre slugs, so resolve names and aliases in the filter
+	// the same way they are resolved when a movie is saved.
+	if len(search.Genres) > 0 {
+		taxonomy, err := app.models.Genres.GetTaxonomy()
+		if err != nil {
+			return err
+		}
+
+		for i, genre := range search.Genres {
+			if slug, ok := taxonomy.Resolve(genre); ok {
+				search.Genres[i] = slug
+			} else {
+				search.Genres[i] = data.GenreSlug(genre)
+			}
+		}
+	}
+
+	filter.SortSafelist = []string{"id", "title", "year", "runtime", "release_date", "updated_at", "-id", "-title", "-year", "-runtime", "-release_date", "-updated_at"}
+
+	for _, status := range search.Statuses {
+		v.Check(validator.PermittedValue(status, data.StatusAnnounced, data.StatusInProduction, data.StatusReleased), "status", "must only contain announced, in_production or released")
+	}
+	data.ValidateExternalIDs(v, search.ExternalIDs)
+	data.ValidateFilters(v, *filter)
+
+	return nil
+}
+
+// @Summary      Look Up Movie by External ID
+// @Description  Find the movie that is known under an id in another catalog. Exactly one of `imdb`, `tmdb` or `wikidata` must be given.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Movies
+// @Produce      json
+// @Param        imdb      query     string  false  "IMDb id"  example(tt0068646)
+// @Param        tmdb      query     int     false  "TMDb id"  example(238)
+// @Param        wikidata  query     string  false  "Wikidata id"  example(Q47703)
+// @Param        runtime_format  query  string  false  "Format of the runtime in the response"  default(mins)  Enums(mins, minutes_int, iso8601, human)
+// @Param        Accept-Language  header  string  false  "Preferred languages for the display title"  example(de-AT, de;q=0.9, en;q=0.5)
+// @Security     BearerAuth
+// @Success      200  {object}  object{movie=data.Movie}  "Movie details"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "No movie has the given external id"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/lookup [get]
+func (app *application) lookupMovieHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+
+	ids := app.readExternalIDs(r.URL.Query(), v)
+	runtimeFormat := app.readRuntimeFormat(r.URL.Query(), v)
+
+	given := 0
+	for _, ok := range []bool{ids.IMDb != "", ids.TMDb != 0, ids.Wikidata != ""} {
+		if ok {
+			given++
+		}
+	}
+	v.Check(given == 1, "external_id", "exactly one of imdb, tmdb or wikidata must be provided")
+
+	if data.ValidateExternalIDs(v, ids); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	movie, err := app.models.Movies.GetByExternalIDs(ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.setDisplayTitles(w, r, movie)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	movie.RuntimeFormat = runtimeFormat
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"movie": movie}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// readExternalIDs reads the imdb, tmdb and wikidata query string parameters.
+func (app *application) readExternalIDs(qs url.Values, v *validator.Validator) data.ExternalIDs {
+	return data.ExternalIDs{
+		IMDb:     app.readQueryString(qs, "imdb", ""),
+		TMDb:     int64(app.readQueryInt(qs, "tmdb", 0, v)),
+		Wikidata: app.readQueryString(qs, "wikidata", ""),
+	}
+}
+
+// addExternalIDError records a validation error when err reports that another movie
+// already has one of the external ids, and reports whether it did.
+func (app *application) addExternalIDError(v *validator.Validator, err error) bool {
+	switch {
+	case errors.Is(err, data.ErrDuplicateIMDbID):
+		v.AddError("external_ids.imdb", "a movie with this IMDb id already exists")
+	case errors.Is(err, data.ErrDuplicateTMDbID):
+		v.AddError("external_ids.tmdb", "a movie with this TMDb id already exists")
+	case errors.Is(err, data.ErrDuplicateWikidataID):
+		v.AddError("external_ids.wikidata", "a movie with this Wikidata id already exists")
+	default:
+		return false
+	}
+	return true
+}
+
+// readRuntimeFormat reads the runtime_format query string parameter, which selects how
+// the runtimes of the movies in the response are written.
+func (app *application) readRuntimeFormat(qs url.Values, v *validator.Validator) data.RuntimeFormat {
+	format := data.RuntimeFormat(app.readQueryString(qs, "runtime_format", string(data.RuntimeMins)))
+	v.Check(validator.PermittedValue(format, data.RuntimeFormats...), "runtime_format", "must be one of mins, minutes_int, iso8601 or human")
+	return format
+}
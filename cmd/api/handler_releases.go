@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      List Movie Releases
+// @Description  Retrieve the per-country releases of a movie, along with the age certification it received in each country.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Movies
+// @Produce      json
+// @Param        id   path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{releases=[]data.MovieRelease}  "Releases ordered by country and date"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/releases [get]
+func (app *application) listMovieReleasesHandler(w http.ResponseWriter, r *http.Request) {
+	movie, ok := app.readMovie(w, r)
+	if !ok {
+		return
+	}
+
+	releases, err := app.models.MovieReleases.GetAllForMovie(movie.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"releases": releases}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Add Movie Release (require movies:write permission)
+// @Description  Add a release of a movie in a country. A movie has at most one release per country and type.
+// @Description
+// @Description  **Permissions Required:** `movies:write`
+// @Description
+// @Description  **Validation Rules:**
+// @Description  - Country: Required, uppercase ISO 3166-1 alpha-2 code (e.g. `US`)
+// @Description  - Type: Required, one of `theatrical`, `digital` or `physical`
+// @Description  - Release Date: Required, `YYYY-MM-DD`
+// @Description  - Certification: Optional, max 20 characters. For countries with a known rating system it must be part of it:
+// @Description    - US: G, PG, PG-13, R, NC-17
+// @Description    - GB: U, PG, 12A, 12, 15, 18, R18
+// @Description    - DE: 0, 6, 12, 16, 18
+// @Description    - FR: U, 10, 12, 16, 18
+// @Description    - JP: G, PG12, R15+, R18+
+// @Description    - AU: G, PG, M, MA15+, R18+, X18+
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Param        release  body      object{country=string, type=string, release_date=string, certification=string}  true  "Release data"
+// @Security     BearerAuth
+// @Success      201  {object}  object{release=data.MovieRelease}  "Release added successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/releases [post]
+func (app *application) createMovieReleaseHandler(w http.ResponseWriter, r *http.Request) {
+	movie, ok := app.readMovie(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Country       string    `json:"country"`
+		Type          string    `json:"type"`
+		ReleaseDate   data.Date `json:"release_date"`
+		Certification string    `json:"certification"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	release := &data.MovieRelease{
+		MovieID:       movie.ID,
+		Country:       input.Country,
+		Type:          input.Type,
+		ReleaseDate:   input.ReleaseDate,
+		Certification: input.Certification,
+	}
+
+	v := validator.New()
+	if data.ValidateMovieRelease(v, release); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.MovieReleases.Insert(release)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateMovieRelease):
+			v.AddError("type", "the movie already has a release of this type in this country")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/movies/%d/releases", movie.ID))
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"release": release}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Delete Movie Release (require movies:write permission)
+// @Description  Remove a release from a movie.
+// @Description
+// @Description  **Permissions Required:** `movies:write`
+// @Tags         Movies
+// @Produce      json
+// @Param        id          path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Param        release_id  path      int  true  "Release ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "Release deleted successfully"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie or release not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/releases/{release_id} [delete]
+func (app *application) deleteMovieReleaseHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	releaseID, err := app.readInt64Param(r, "release_id")
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.MovieReleases.Delete(movieID, releaseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "release successfully deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      List Similar Movies
+// @Description  Retrieve the movies that are most similar to a movie, best match first. Each movie carries a `similarity` score between 0 and 1.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Description
+// @Description  **Scoring:**
+// @Description  - Genre overlap (Jaccard index of the genres): 60%
+// @Description  - Year proximity (falling off to zero at 20 years apart): 25%
+// @Description  - Title similarity (trigram similarity): 15%
+// @Description
+// @Description  Only movies sharing at least one genre are considered. Rankings are computed in the background, within a minute of a movie being added or changed, and again every 24 hours. Until then a new movie has no similar movies and a changed movie keeps its previous ranking.
+// @Tags         Movies
+// @Produce      json
+// @Param        id       path      int     true   "Movie ID"  minimum(1)  example(1)
+// @Param        limit    query     int     false  "Maximum number of movies (minimum: 1, maximum: 50)"  default(10)  minimum(1)  maximum(50)
+// @Param        exclude  query     string  false  "Movie IDs to leave out (comma-separated, at most 50)"  example(2,3)
+// @Param        runtime_format  query  string  false  "Format of the runtimes in the response"  default(mins)  Enums(mins, minutes_int, iso8601, human)
+// @Param        Accept-Language  header  string  false  "Preferred languages for the display title"  example(de-AT, de;q=0.9, en;q=0.5)
+// @Security     BearerAuth
+// @Success      200  {object}  object{movies=[]data.Movie}  "Similar movies ordered by similarity"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/similar [get]
+func (app *application) listSimilarMoviesHandler(w http.ResponseWriter, r *http.Request) {
+	movie, ok := app.readMovie(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	qs := r.URL.Query()
+
+	limit := app.readQueryInt(qs, "limit", 10, v)
+	runtimeFormat := app.readRuntimeFormat(qs, v)
+
+	exclude := []int64{}
+	for _, s := range app.readQueryStrings(qs, "exclude", []string{}) {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			v.AddError("exclude", "must be a comma-separated list of integer values")
+			break
+		}
+		exclude = append(exclude, id)
+	}
+
+	v.Check(limit > 0, "limit", "must be greater than zero")
+	v.Check(limit <= data.SimilarMaxLimit, "limit", fmt.Sprintf("must be a maximum of %d", data.SimilarMaxLimit))
+	v.Check(len(exclude) <= data.SimilarMaxExclude, "exclude", fmt.Sprintf("must not contain more than %d ids", data.SimilarMaxExclude))
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	movies, err := app.models.Movies.GetSimilar(movie, limit, exclude)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.setDisplayTitles(w, r, movies...)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	for _, movie := range movies {
+		movie.RuntimeFormat = runtimeFormat
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"movies": movies}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// serveStaticHandler serves the files stored by the local storage backend, such as
+// movie posters. Directory listings are never served.
+func (app *application) serveStaticHandler(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+	name := strings.TrimPrefix(params.ByName("filepath"), "/")
+
+	fsys := os.DirFS(app.config.Storage.Dir)
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil || info.IsDir() {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	// Stored files are never modified in place, since every upload is written under
+	// a new key. That makes it safe to let clients and proxies cache them forever.
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeFileFS(w, r, fsys, name)
+}
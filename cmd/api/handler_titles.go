@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      List Movie Titles
+// @Description  Retrieve the alternate titles of a movie, such as its original title or the titles it was released under in other languages.
+// @Description
+// @Description  **Permissions Required:** `movies:read`
+// @Tags         Movies
+// @Produce      json
+// @Param        id   path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{titles=[]data.MovieTitle}  "Alternate titles ordered by language, region and type"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/titles [get]
+func (app *application) listMovieTitlesHandler(w http.ResponseWriter, r *http.Request) {
+	movie, ok := app.readMovie(w, r)
+	if !ok {
+		return
+	}
+
+	titles, err := app.models.MovieTitles.GetAllForMovie(movie.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"titles": titles}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Add Movie Title (require movies:write permission)
+// @Description  Add an alternate title to a movie. The `updated_at` time of the movie moves forward, as its display title may change.
+// @Description
+// @Description  **Permissions Required:** `movies:write`
+// @Description
+// @Description  **Validation Rules:**
+// @Description  - Title: Required, max 500 characters
+// @Description  - Language: Required, lowercase ISO 639-1 code (e.g. `de`)
+// @Description  - Region: Optional, uppercase ISO 3166-1 alpha-2 code (e.g. `AT`)
+// @Description  - Type: Optional, one of `original`, `working` or `translated` (default)
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Param        title  body      object{title=string, language=string, region=string, type=string}  true  "Alternate title data"
+// @Security     BearerAuth
+// @Success      201  {object}  object{title=data.MovieTitle}  "Title added successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie not found"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/titles [post]
+func (app *application) createMovieTitleHandler(w http.ResponseWriter, r *http.Request) {
+	movie, ok := app.readMovie(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Title    string `json:"title"`
+		Language string `json:"language"`
+		Region   string `json:"region"`
+		Type     string `json:"type"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	title := &data.MovieTitle{
+		MovieID:  movie.ID,
+		Title:    input.Title,
+		Language: input.Language,
+		Region:   input.Region,
+		Type:     input.Type,
+	}
+
+	if title.Type == "" {
+		title.Type = data.TitleTranslated
+	}
+
+	v := validator.New()
+	if data.ValidateMovieTitle(v, title); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.MovieTitles.Insert(title)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateMovieTitle):
+			v.AddError("title", "the movie already has this title")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/movies/%d/titles", movie.ID))
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"title": title}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Delete Movie Title (require movies:write permission)
+// @Description  Remove an alternate title from a movie. The `updated_at` time of the movie moves forward, as its display title may change.
+// @Description
+// @Description  **Permissions Required:** `movies:write`
+// @Tags         Movies
+// @Produce      json
+// @Param        id        path      int  true  "Movie ID"  minimum(1)  example(1)
+// @Param        title_id  path      int  true  "Title ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "Title deleted successfully"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Movie or title not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /movies/{id}/titles/{title_id} [delete]
+func (app *application) deleteMovieTitleHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	titleID, err := app.readInt64Param(r, "title_id")
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.MovieTitles.Delete(movieID, titleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "title successfully deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// varyOnAcceptLanguage tells caches that the response depends on the Accept-Language
+// header, so that they keep one copy per language. Handlers that may answer with 304 Not
+// Modified call it before they check, as the 304 must carry the header as well.
+func varyOnAcceptLanguage(w http.ResponseWriter) {
+	if !slices.Contains(w.Header().Values("Vary"), "Accept-Language") {
+		w.Header().Add("Vary", "Accept-Language")
+	}
+}
+
+// setDisplayTitles fills in the display title of the movies from their alternate
+// titles, according to the Accept-Language header of the request.
+func (app *application) setDisplayTitles(w http.ResponseWriter, r *http.Request, movies ...*data.Movie) error {
+	varyOnAcceptLanguage(w)
+
+	locales := app.readLocales(r)
+	if len(locales) == 0 {
+		for _, movie := range movies {
+			movie.DisplayTitle = movie.Title
+		}
+		return nil
+	}
+
+	ids := make([]int64, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.ID
+	}
+
+	titles, err := app.models.MovieTitles.GetAllForMovies(ids)
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		movie.DisplayTitle = data.DisplayTitle(movie, titles[movie.ID], locales)
+	}
+
+	return nil
+}
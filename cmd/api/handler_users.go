@@ -4,6 +4,7 @@ package main
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/ucok-man/gmoapi/internal/data"
@@ -19,13 +20,17 @@ import (
 // @Description  - Password: Required, 8-72 characters
 // @Description
 // @Description  **Default Permissions:** New users receive `movies:read` permission by default.
+// @Description
+// @Description  **Retries:** Send an `Idempotency-Key` header to make the request safe to retry. A retry with the same key and body gets the original response, with the `Idempotent-Replayed: true` header, instead of failing because the email is taken.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Param        user  body      object{name=string, email=string, password=string}  true  "User registration data"
-// @Success      202  {object}  object{message=string, user=object{id=int64, created_at=string, name=string, email=string, activated=bool}}  "Registration successful - activation email sent"
+// @Param        Idempotency-Key  header  string  false  "Makes the request safe to retry, retries with the same key and body get the original response"  example(5f1c3b9e-7d2a-4c8e-9b61-2a4f0d8e7c13)
+// @Success      202  {object}  object{message=string, user=object{id=int64, created_at=string, name=string, email=string, activated=bool, version=int}}  "Registration successful - activation email sent"
 // @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
-// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors (e.g., duplicate email, weak password)"
+// @Failure      409  {object}  object{error=string}  "Conflict - a request with the same Idempotency-Key is still being processed"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors (e.g., duplicate email, weak password), or an Idempotency-Key used for a different request"
 // @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
 // @Failure      500  {object}  object{error=string}  "Internal server error"
 // @Router       /users/register [post]
@@ -115,7 +120,7 @@ func (app *application) registerUserHandler(w http.ResponseWriter, r *http.Reque
 // @Accept       json
 // @Produce      json
 // @Param        token  body      object{token=string}  true  "Activation token (26 characters)"
-// @Success      200  {object}  object{user=object{id=int64, created_at=string, name=string, email=string, activated=bool}}  "Account activated successfully"
+// @Success      200  {object}  object{user=object{id=int64, created_at=string, name=string, email=string, activated=bool, version=int}}  "Account activated successfully"
 // @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON"
 // @Failure      409  {object}  object{error=string}  "Conflict - account has been modified during activation"
 // @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - invalid or expired token"
@@ -268,3 +273,337 @@ func (app *application) updateUserPasswordHandler(w http.ResponseWriter, r *http
 		app.serverErrorResponse(w, r, err)
 	}
 }
+
+// @Summary      Show Current User
+// @Description  Retrieve the authenticated user along with their permissions. The `version` of the user is needed to update them.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{user=object{id=int64, created_at=string, name=string, email=string, activated=bool, version=int}, permissions=[]string}  "The current user and their permissions"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /users/me [get]
+func (app *application) showCurrentUserHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	permissions, err := app.models.Permissions.GetAllForUser(user.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	if permissions == nil {
+		permissions = data.Permissions{}
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"user": user, "permissions": permissions}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Update Current User
+// @Description  Change the name of the authenticated user.
+// @Description
+// @Description  **Concurrency Control:** The `version` of the user as last read is required. If the user has been modified since, a 409 Conflict is returned and nothing is changed.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        user  body      object{name=string, version=int}  true  "User update data (name optional, version required)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{user=object{id=int64, created_at=string, name=string, email=string, activated=bool, version=int}}  "User updated successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      409  {object}  object{error=string}  "Edit conflict - user has been modified since the given version"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /users/me [patch]
+func (app *application) updateCurrentUserHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	var input struct {
+		Name    *string `json:"name"`
+		Version *int    `json:"version"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.Version != nil, "version", "must be provided")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	// The client read an older version of the user, the change may be based on stale
+	// data.
+	if *input.Version != user.Version {
+		app.editConflictResponse(w, r)
+		return
+	}
+
+	if input.Name != nil {
+		user.Name = *input.Name
+	}
+
+	if data.ValidateUser(v, user); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Users.Update(user)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"user": user}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Change Current User Password
+// @Description  Change the password of the authenticated user, who must provide their current password. Users who forgot their password use a password reset token instead, see `PUT /users/password`.
+// @Description
+// @Description  Outstanding password reset tokens are revoked, and so are the authentication tokens of the user other than the one the request is made with, signing out their other sessions.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        password  body      object{current_password=string, password=string}  true  "Current and new password"
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "Password changed successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      409  {object}  object{error=string}  "Conflict - account has been modified during the password change"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - incorrect current password or weak new password"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /users/me/password [put]
+func (app *application) updateCurrentUserPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	var input struct {
+		CurrentPassword string `json:"current_password"`
+		Password        string `json:"password"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.CurrentPassword != "", "current_password", "must be provided")
+	data.ValidatePasswordPlaintext(v, input.Password)
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	// A wrong current password is a validation error rather than a 401, which would
+	// tell clients that their token is no longer valid.
+	match, err := user.Password.Matches(input.CurrentPassword)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	if !match {
+		v.AddError("current_password", "is incorrect")
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = user.Password.Set(input.Password)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Users.Update(user)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Tokens.DeleteAllForUser(data.ScopePasswordReset, user.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	// Whoever knew the old password may have signed in with it, keep only the session
+	// that changed it.
+	err = app.models.Tokens.DeleteAllForUserExcept(data.ScopeAuthentication, user.ID, app.contextGetToken(r))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "your password was successfully changed"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Change Current User Email
+// @Description  Start changing the email address of the authenticated user. The new address is stored as pending, and a confirmation token valid for 24 hours is sent to it, along with a notice to the current address. The email address only changes once the token is confirmed with `PUT /users/me/email/confirm`.
+// @Description
+// @Description  A new request replaces the pending address, and tokens sent for earlier requests stop working.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        email  body      object{email=string}  true  "New email address"
+// @Security     BearerAuth
+// @Success      202  {object}  object{message=string}  "Confirmation email will be sent to the new address"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - invalid email, unchanged email or email taken by another user"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /users/me/email [post]
+func (app *application) createEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	var input struct {
+		Email string `json:"email"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if data.ValidateEmail(v, input.Email); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	// Email addresses are compared case-insensitively, as the database does.
+	if strings.EqualFold(input.Email, user.Email) {
+		v.AddError("email", "must be different from the current email address")
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	// The address may still be taken by the time the change is confirmed, which the
+	// confirmation handles, but most conflicts are caught here, before any email is
+	// sent.
+	_, err = app.models.Users.GetByEmail(input.Email)
+	switch {
+	case err == nil:
+		v.AddError("email", "a user with this email address already exists")
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	case !errors.Is(err, data.ErrRecordNotFound):
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	token, err := app.models.Users.SetPendingEmail(user.ID, input.Email, 24*time.Hour)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	app.background(func() {
+		err := app.mailer.Send(input.Email, "token_email_change.tmpl", map[string]any{
+			"emailChangeToken": token.Plaintext,
+		})
+		if err != nil {
+			app.logger.Error(err.Error())
+		}
+
+		// Let the owner of the current address know, in case the account has been
+		// taken over.
+		err = app.mailer.Send(user.Email, "email_change_notice.tmpl", map[string]any{
+			"newEmail": input.Email,
+		})
+		if err != nil {
+			app.logger.Error(err.Error())
+		}
+	})
+
+	env := envelope{"message": "an email will be sent to the new address containing confirmation instructions"}
+
+	err = app.writeJSON(w, http.StatusAccepted, env, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Confirm Current User Email Change
+// @Description  Confirm a change of email address with the token sent to the new address by `POST /users/me/email`. The request must be authenticated as the user who asked for the change. The pending address becomes the email address of the user, and the token is used up.
+// @Description
+// @Description  Activation and password reset tokens, which were sent to the old address, are revoked. Authentication tokens stay valid.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        token  body      object{token=string}  true  "Email change token (26 characters)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{user=object{id=int64, created_at=string, name=string, email=string, activated=bool, version=int}}  "Email address changed successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - invalid or expired token, or email taken by another user since the change was requested"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /users/me/email/confirm [put]
+func (app *application) confirmEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	var input struct {
+		TokenPlaintext string `json:"token"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if data.ValidateTokenPlaintext(v, input.TokenPlaintext); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Users.ConfirmPendingEmail(user, input.TokenPlaintext)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("token", "invalid or expired email change token")
+			app.failedValidationResponse(w, r, v.Errors)
+		case errors.Is(err, data.ErrDuplicateEmail):
+			v.AddError("email", "a user with this email address already exists")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"user": user}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
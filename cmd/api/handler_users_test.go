@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+)
+
+func TestUpdateCurrentUserRequiresVersion(t *testing.T) {
+	app := &application{}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"missing version", `{"name":"Alice"}`, http.StatusUnprocessableEntity},
+		{"stale version", `{"name":"Alice","version":2}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &data.User{ID: 1, Name: "Bob", Email: "bob@example.com", Activated: true, Version: 3}
+
+			r := httptest.NewRequest(http.MethodPatch, "/v1/users/me", strings.NewReader(tt.body))
+			r = app.contextSetUser(r, user)
+			w := httptest.NewRecorder()
+
+			app.updateCurrentUserHandler(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if user.Name != "Bob" {
+				t.Errorf("name changed to %q", user.Name)
+			}
+
+			if tt.wantStatus == http.StatusUnprocessableEntity {
+				var resp struct {
+					Error map[string]string `json:"error"`
+				}
+				json.NewDecoder(w.Body).Decode(&resp)
+				if resp.Error["version"] != "must be provided" {
+					t.Errorf("errors = %v, want version must be provided", resp.Error)
+				}
+			}
+		})
+	}
+}
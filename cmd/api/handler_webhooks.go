@@ -0,0 +1,379 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// @Summary      List Webhooks (require webhooks:manage permission)
+// @Description  Retrieve a paginated list of the webhook subscriptions.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Tags         Webhooks
+// @Produce      json
+// @Param        page       query     int     false  "Page number (minimum: 1, maximum: 10,000,000)"  default(1)  minimum(1)  maximum(10000000)
+// @Param        page_size  query     int     false  "Items per page (minimum: 1, maximum: 100)"  default(20)  minimum(1)  maximum(100)
+// @Param        sort       query     string  false  "Sort field"  default(id)  Enums(id, created_at, -id, -created_at)
+// @Security     BearerAuth
+// @Success      200  {object}  object{webhooks=[]data.Webhook, metadata=data.Metadata}  "List of webhooks with pagination metadata"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks [get]
+func (app *application) listWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	qs := r.URL.Query()
+
+	filter := data.Filter{
+		Page:         app.readQueryInt(qs, "page", 1, v),
+		PageSize:     app.readQueryInt(qs, "page_size", 20, v),
+		Sort:         app.readQueryString(qs, "sort", "id"),
+		SortSafelist: []string{"id", "created_at", "-id", "-created_at"},
+	}
+
+	if data.ValidateFilters(v, filter); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	webhooks, metadata, err := app.models.Webhooks.GetAll(filter)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"webhooks": webhooks, "metadata": metadata}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Create Webhook (require webhooks:manage permission)
+// @Description  Subscribe a URL to events. Every event is POSTed to the URL as JSON, `{"event": ..., "occurred_at": ..., "data": {...}}`, with these headers:
+// @Description  - `Gmoapi-Event`: the event
+// @Description  - `Gmoapi-Delivery`: the id of the delivery
+// @Description  - `Gmoapi-Timestamp`: Unix time at which the delivery was sent
+// @Description  - `Gmoapi-Signature`: `sha256=` followed by the hex HMAC-SHA256 of `<timestamp>.<body>`, keyed with the secret of the webhook
+// @Description
+// @Description  The secret is only returned in this response. Any 2xx response counts as delivered, anything else is retried with exponential backoff up to 8 times. A webhook is disabled after 20 failed attempts in a row.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Description
+// @Description  **Validation Rules:**
+// @Description  - URL: Required, absolute `http` or `https` URL, max 2048 characters
+// @Description  - Events: Required, 1 or more unique events out of `movie.created`, `movie.updated`, `movie.deleted` and `user.registered`
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      object{url=string, events=[]string}  true  "Webhook creation data"
+// @Security     BearerAuth
+// @Success      201  {object}  object{webhook=data.Webhook}  "Webhook created successfully, along with its secret"
+// @Header       201  {string}  Location  "URL of the created webhook"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks [post]
+func (app *application) createWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	webhook := &data.Webhook{
+		URL:    input.URL,
+		Events: input.Events,
+	}
+
+	v := validator.New()
+	if data.ValidateWebhook(v, webhook); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Webhooks.Insert(webhook)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/webhooks/%d", webhook.ID))
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"webhook": webhook}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Get Webhook by ID (require webhooks:manage permission)
+// @Description  Retrieve a webhook subscription. The secret isn't included.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{webhook=data.Webhook}  "Webhook details"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Webhook not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks/{id} [get]
+func (app *application) showWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.writeJSON(w, http.StatusOK, envelope{"webhook": webhook}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Update Webhook (require webhooks:manage permission)
+// @Description  Change the URL or events of a webhook, or disable and re-enable it. Re-enabling a webhook resets its count of failed attempts, and its pending deliveries are sent again.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Webhook ID"  minimum(1)  example(1)
+// @Param        webhook  body      object{url=string, events=[]string, active=bool}  true  "Webhook update data (all fields optional)"
+// @Security     BearerAuth
+// @Success      200  {object}  object{webhook=data.Webhook}  "Webhook updated successfully"
+// @Failure      400  {object}  object{error=string}  "Bad request - malformed JSON or invalid data types"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Webhook not found"
+// @Failure      409  {object}  object{error=string}  "Conflict - edit conflict, please retry"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks/{id} [patch]
+func (app *application) updateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		URL    *string  `json:"url"`
+		Events []string `json:"events"`
+		Active *bool    `json:"active"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if input.URL != nil {
+		webhook.URL = *input.URL
+	}
+	if input.Events != nil {
+		webhook.Events = input.Events
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+
+	v := validator.New()
+	if data.ValidateWebhook(v, webhook); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Webhooks.Update(webhook)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"webhook": webhook}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Delete Webhook (require webhooks:manage permission)
+// @Description  Remove a webhook subscription together with its delivery log.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      200  {object}  object{message=string}  "Webhook deleted successfully"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Webhook not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks/{id} [delete]
+func (app *application) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.Webhooks.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "webhook successfully deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      List Webhook Deliveries (require webhooks:manage permission)
+// @Description  Retrieve the delivery log of a webhook: the payload of every delivery, its status (`pending`, `succeeded` or `failed`), the number of attempts, and the response status and error of the last attempt.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id         path      int     true   "Webhook ID"  minimum(1)  example(1)
+// @Param        page       query     int     false  "Page number (minimum: 1, maximum: 10,000,000)"  default(1)  minimum(1)  maximum(10000000)
+// @Param        page_size  query     int     false  "Items per page (minimum: 1, maximum: 100)"  default(20)  minimum(1)  maximum(100)
+// @Param        sort       query     string  false  "Sort field"  default(-id)  Enums(id, -id)
+// @Security     BearerAuth
+// @Success      200  {object}  object{deliveries=[]data.WebhookDelivery, metadata=data.Metadata}  "Deliveries with pagination metadata, newest first by default"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Webhook not found"
+// @Failure      422  {object}  object{error=map[string]string}  "Unprocessable entity - validation errors"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks/{id}/deliveries [get]
+func (app *application) listWebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	qs := r.URL.Query()
+
+	filter := data.Filter{
+		Page:         app.readQueryInt(qs, "page", 1, v),
+		PageSize:     app.readQueryInt(qs, "page_size", 20, v),
+		Sort:         app.readQueryString(qs, "sort", "-id"),
+		SortSafelist: []string{"id", "-id"},
+	}
+
+	if data.ValidateFilters(v, filter); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	deliveries, metadata, err := app.models.WebhookDeliveries.GetAllForWebhook(webhook.ID, filter)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"deliveries": deliveries, "metadata": metadata}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// @Summary      Redeliver Webhook Delivery (require webhooks:manage permission)
+// @Description  Queue a new delivery of the event and payload of an earlier delivery, for example one that failed. It is sent as soon as possible, as long as the webhook is active.
+// @Description
+// @Description  **Permissions Required:** `webhooks:manage`
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id           path      int  true  "Webhook ID"  minimum(1)  example(1)
+// @Param        delivery_id  path      int  true  "Delivery ID"  minimum(1)  example(1)
+// @Security     BearerAuth
+// @Success      202  {object}  object{delivery=data.WebhookDelivery}  "The new delivery was queued"
+// @Failure      401  {object}  object{error=string}  "Unauthorized - missing or invalid authentication token"
+// @Failure      403  {object}  object{error=string}  "Forbidden - user account not activated or insufficient permissions"
+// @Failure      404  {object}  object{error=string}  "Webhook or delivery not found"
+// @Failure      429  {object}  object{error=string}  "Too many requests - rate limit exceeded"
+// @Failure      500  {object}  object{error=string}  "Internal server error"
+// @Router       /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (app *application) redeliverWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	deliveryID, err := app.readInt64Param(r, "delivery_id")
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	delivery, err := app.models.WebhookDeliveries.Redeliver(webhookID, deliveryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusAccepted, envelope{"delivery": delivery}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// readWebhook fetches the webhook named by the id URL parameter. When ok is false a
+// response has already been sent.
+func (app *application) readWebhook(w http.ResponseWriter, r *http.Request) (webhook *data.Webhook, ok bool) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	webhook, err = app.models.Webhooks.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	return webhook, true
+}
@@ -10,19 +10,27 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/ucok-man/gmoapi/internal/data"
 	"github.com/ucok-man/gmoapi/internal/validator"
+	"golang.org/x/text/language"
 )
 
 // envelope wrapping response JSON data.
 type envelope map[string]any
 
 func (app *application) readIDParam(r *http.Request) (int64, error) {
+	return app.readInt64Param(r, "id")
+}
+
+// readInt64Param reads a positive integer from the named URL parameter.
+func (app *application) readInt64Param(r *http.Request, name string) (int64, error) {
 	params := httprouter.ParamsFromContext(r.Context())
-	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	id, err := strconv.ParseInt(params.ByName(name), 10, 64)
 	if err != nil || id < 1 {
-		return 0, errors.New("invalid id parameter")
+		return 0, fmt.Errorf("invalid %s parameter", name)
 	}
 	return id, nil
 }
@@ -149,6 +157,94 @@ func (app *application) readQueryInt(qs url.Values, key string, defaultValue int
 	return i
 }
 
+func (app *application) readQueryBool(qs url.Values, key string, defaultValue bool, v *validator.Validator) bool {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		v.AddError(key, "must be a boolean value")
+		return defaultValue
+	}
+
+	return b
+}
+
+// readQueryTime parses an RFC 3339 time from the query string, returning the zero
+// time when it's missing.
+func (app *application) readQueryTime(qs url.Values, key string, v *validator.Validator) time.Time {
+	s := qs.Get(key)
+
+	if s == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		v.AddError(key, "must be an RFC 3339 time, such as 2025-10-01T00:00:00Z")
+		return time.Time{}
+	}
+
+	return t
+}
+
+// readQueryDuration parses a duration such as "30s" from the query string.
+func (app *application) readQueryDuration(qs url.Values, key string, defaultValue time.Duration, v *validator.Validator) time.Duration {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		v.AddError(key, "must be a duration, such as 30s")
+		return defaultValue
+	}
+
+	return d
+}
+
+// notModifiedSince reports whether the request carries an If-Modified-Since header at
+// or after modtime. HTTP dates have a resolution of one second, so modtime is
+// truncated before comparing.
+func (app *application) notModifiedSince(r *http.Request, modtime time.Time) bool {
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !modtime.Truncate(time.Second).After(ims)
+}
+
+// readLocales returns the locales from the Accept-Language header, ordered by
+// preference. A malformed header is treated as if no preference was given.
+func (app *application) readLocales(r *http.Request) []data.Locale {
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return nil
+	}
+
+	locales := make([]data.Locale, 0, len(tags))
+	for _, tag := range tags {
+		base, confidence := tag.Base()
+		if confidence == language.No {
+			continue
+		}
+
+		locale := data.Locale{Language: base.String()}
+		if region, confidence := tag.Region(); confidence == language.Exact {
+			locale.Region = region.String()
+		}
+		locales = append(locales, locale)
+	}
+
+	return locales
+}
+
 func (app *application) background(fn func()) {
 	app.wg.Add(1)
 
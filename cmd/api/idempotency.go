@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// idempotencyPurgeInterval is how often expired idempotency keys are removed.
+const idempotencyPurgeInterval = time.Hour
+
+// idempotentPaths are the POST routes that honour an Idempotency-Key, the endpoints
+// that create a record. Their responses are stored in the database, so routes whose
+// responses carry secrets, such as the plaintext tokens of /v1/tokens/*, must never
+// be added here.
+var idempotentPaths = map[string]bool{
+	"/v1/movies":         true,
+	"/v1/lists":          true,
+	"/v1/webhooks":       true,
+	"/v1/users/register": true,
+}
+
+// purgeIdempotencyKeys removes expired idempotency keys and their stored responses
+// periodically, until ctx is cancelled. Expired keys are already ignored before then.
+func (app *application) purgeIdempotencyKeys(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := app.models.IdempotencyKeys.DeleteExpired()
+		if err != nil {
+			app.logger.Error(err.Error())
+			continue
+		}
+
+		if n > 0 {
+			app.logger.Info("purged expired idempotency keys", "count", n)
+		}
+	}
+}
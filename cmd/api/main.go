@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"expvar"
+	"flag"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"runtime"
 	"sync"
@@ -12,18 +15,29 @@ import (
 
 	_ "github.com/ucok-man/gmoapi/cmd/api/docs"
 
+	"github.com/graphql-go/graphql"
 	_ "github.com/lib/pq"
 	"github.com/ucok-man/gmoapi/cmd/api/config"
 	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/eventbus"
 	"github.com/ucok-man/gmoapi/internal/mailer"
+	"github.com/ucok-man/gmoapi/internal/storage"
+	"github.com/ucok-man/gmoapi/internal/webhook"
 )
 
 type application struct {
-	config config.Config
-	logger *slog.Logger
-	models data.Models
-	mailer *mailer.Mailer
-	wg     sync.WaitGroup
+	config   config.Config
+	logger   *slog.Logger
+	models   data.Models
+	bus      eventbus.Bus
+	mailer   *mailer.Mailer
+	storage  storage.Storage
+	webhooks *webhook.Sender
+	events   *broadcaster
+	streams  *streamLimiter
+	graphql  graphql.Schema
+	handler  http.Handler
+	wg       sync.WaitGroup
 }
 
 // @title           Gmoapi - Movie Management API
@@ -67,9 +81,27 @@ type application struct {
 // @tag.name Movies
 // @tag.description Movie catalog management - requires authentication and appropriate permissions
 
+// @tag.name Genres
+// @tag.description Genre taxonomy that movies are tagged with - slugs, display names and aliases
+
+// @tag.name Changes
+// @tag.description Ordered log of movie changes for replicating the catalog, and a live stream of them
+
+// @tag.name Lists
+// @tag.description User-curated, ordered movie lists that can be private, unlisted or public
+
 // @tag.name Users
 // @tag.description User account registration, activation, and password management
 
+// @tag.name Webhooks
+// @tag.description Subscriptions that are notified of movie and user events with signed HTTP requests
+
+// @tag.name GraphQL
+// @tag.description GraphQL endpoint over movies and the current user, for fetching related data in one round trip
+
+// @tag.name Batch
+// @tag.description Several API requests in one round trip, each handled as if it had been sent on its own
+
 // @tag.name Tokens
 // @tag.description Token generation for authentication, activation, and password reset
 
@@ -88,12 +120,61 @@ func main() {
 
 	logger.Info("database connection pool established")
 
+	// Commands work on the database and exit instead of starting the server.
+	if flag.NArg() > 0 {
+		err = runCommand(cfg, db, logger, flag.Args())
+		if err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cfg.DB.MigrateOnStart {
+		err = migrateOnStart(db, logger)
+		if err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	err = checkSchema(db)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	bus, err := eventbus.NewPostgres(cfg.DB.DSN, logger)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+	defer bus.Close()
+
+	logger.Info("event bus listening", "channel", eventbus.Channel)
+
 	mailer, err := mailer.New(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.Sender)
 	if err != nil {
 		logger.Error(err.Error())
 		os.Exit(1)
 	}
 
+	// Serve uploaded files from the API itself unless a different public URL has been
+	// configured (for example a CDN in front of the storage directory).
+	baseURL := cfg.Storage.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/static", cfg.Host)
+		if cfg.Env.IsDevelopment() {
+			baseURL = fmt.Sprintf("http://%s:%d/static", cfg.Host, cfg.Port)
+		}
+	}
+
+	storage, err := storage.NewLocalStorage(cfg.Storage.Dir, baseURL)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
 	expvar.NewString("version").Set(config.APP_VERSION)
 
 	// Publish the number of active goroutines.
@@ -112,10 +193,21 @@ func main() {
 	}))
 
 	app := &application{
-		config: cfg,
-		logger: logger,
-		models: data.NewModels(db),
-		mailer: mailer,
+		config:   cfg,
+		logger:   logger,
+		models:   data.NewModels(db, bus),
+		bus:      bus,
+		mailer:   mailer,
+		storage:  storage,
+		webhooks: webhook.New(cfg.Webhooks.Timeout),
+		events:   newBroadcaster(),
+		streams:  newStreamLimiter(maxStreamsPerUser),
+	}
+
+	app.graphql, err = app.newGraphQLSchema()
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
 	}
 
 	err = app.serve()
@@ -125,6 +217,18 @@ func main() {
 	}
 }
 
+// runCommand runs the command given after the flags: migrate or seed.
+func runCommand(cfg config.Config, db *sql.DB, logger *slog.Logger, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(db, args[1:])
+	case "seed":
+		return runSeed(cfg, db, logger, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
 func openDB(cfg config.Config) (*sql.DB, error) {
 	db, err := sql.Open("postgres", cfg.DB.DSN)
 	if err != nil {
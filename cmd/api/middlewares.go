@@ -1,9 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"expvar"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"strconv"
 	"strings"
@@ -16,6 +21,11 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// idempotencyLockTimeout is how long a request holds its idempotency key before another
+// request with the key may take over, in case the server stopped while handling it.
+// It is well above the write timeout of the server.
+const idempotencyLockTimeout = time.Minute
+
 func (app *application) recoverPanic(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Create a deferred function (which will always be run in the event of a panic
@@ -80,8 +90,16 @@ func (app *application) rateLimit(next http.Handler) http.Handler {
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Passthrough swagger
-		if strings.HasPrefix(r.URL.Path, "/swagger") {
+		// Passthrough swagger and static files, a single page can easily load more
+		// images than the limiter would allow.
+		if strings.HasPrefix(r.URL.Path, "/swagger") || strings.HasPrefix(r.URL.Path, "/static/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Batches are charged per sub-request instead, as the sub-requests come back
+		// through this middleware.
+		if r.URL.Path == "/v1/batch" {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -162,14 +180,118 @@ func (app *application) authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Add user to context.
+		// Add user and token to context.
 		r = app.contextSetUser(r, user)
+		r = app.contextSetToken(r, token)
 
 		// Continue next handler.
 		next.ServeHTTP(w, r)
 	})
 }
 
+// idempotency makes POST requests to the routes of idempotentPaths that carry an
+// Idempotency-Key header safe to retry. The header is ignored on other routes. The
+// first request with a key is handled as usual and its response is stored. Retries
+// with the same key and the same method, path and body get the stored response, marked
+// by the Idempotent-Replayed header, without being handled again. Keys are scoped to
+// the user, and must come after authenticate in the chain.
+func (app *application) idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if r.Method != http.MethodPost || key == "" || !idempotentPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		v := validator.New()
+		if data.ValidateIdempotencyKey(v, key); !v.Valid() {
+			app.failedValidationResponse(w, r, v.Errors)
+			return
+		}
+
+		// The body is part of the fingerprint of the request, so read it up front and
+		// hand a copy on to the handler.
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1_048_576))
+		if err != nil {
+			var maxBytesError *http.MaxBytesError
+			switch {
+			case errors.As(err, &maxBytesError):
+				app.badRequestResponse(w, r, fmt.Errorf("body must not be larger than %d bytes", maxBytesError.Limit))
+			default:
+				app.badRequestResponse(w, r, err)
+			}
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := sha256.Sum256(fmt.Appendf(nil, "%s %s\n%s", r.Method, r.URL.RequestURI(), body))
+
+		record := &data.IdempotencyKey{
+			UserID:      app.contextGetUser(r).ID,
+			Key:         key,
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+		}
+
+		claimed, err := app.models.IdempotencyKeys.Claim(record, app.config.Idempotency.TTL, idempotencyLockTimeout)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+
+		if !claimed {
+			stored, err := app.models.IdempotencyKeys.Get(record.UserID, record.Key)
+			switch {
+			case errors.Is(err, data.ErrRecordNotFound):
+				// The key expired after it couldn't be claimed, a retry will claim it.
+				app.idempotencyKeyInUseResponse(w, r)
+			case err != nil:
+				app.serverErrorResponse(w, r, err)
+			case stored.Fingerprint != record.Fingerprint:
+				app.idempotencyKeyMismatchResponse(w, r)
+			case !stored.Completed():
+				app.idempotencyKeyInUseResponse(w, r)
+			default:
+				maps.Copy(w.Header(), stored.ResponseHeaders)
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.ResponseStatus)
+				w.Write(stored.ResponseBody)
+			}
+			return
+		}
+
+		// Unless the response is stored, release the key so that the request can be
+		// retried. That includes server errors and panics, which are worth retrying.
+		saved := false
+		defer func() {
+			if saved {
+				return
+			}
+			if err := app.models.IdempotencyKeys.Release(record); err != nil {
+				app.logError(r, err)
+			}
+		}()
+
+		iw := newIdempotencyResponseWriter(w)
+		next.ServeHTTP(iw, r)
+
+		if iw.statusCode >= http.StatusInternalServerError {
+			return
+		}
+
+		record.ResponseStatus = iw.statusCode
+		record.ResponseHeaders = iw.header
+		record.ResponseBody = iw.body.Bytes()
+
+		// The response has been sent by now, so failing to store it is only logged.
+		err = app.models.IdempotencyKeys.SaveResponse(record)
+		if err != nil {
+			app.logError(r, err)
+			return
+		}
+		saved = true
+	})
+}
+
 func (app *application) requireAuthenticatedUser(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		user := app.contextGetUser(r)
@@ -293,10 +415,64 @@ func (mw *metricsResponseWriter) Write(b []byte) (int, error) {
 	return mw.wrapped.Write(b)
 }
 
+// Flush sends any buffered data to the client, so that streaming handlers work through
+// the metrics middleware.
+func (mw *metricsResponseWriter) Flush() {
+	mw.headerWritten = true
+
+	if f, ok := mw.wrapped.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func (mw *metricsResponseWriter) Unwrap() http.ResponseWriter {
 	return mw.wrapped
 }
 
+// idempotencyResponseWriter passes the response on to the client while keeping a copy,
+// so that it can be replayed to retries.
+type idempotencyResponseWriter struct {
+	wrapped       http.ResponseWriter
+	header        http.Header
+	body          bytes.Buffer
+	statusCode    int
+	headerWritten bool
+}
+
+func newIdempotencyResponseWriter(w http.ResponseWriter) *idempotencyResponseWriter {
+	return &idempotencyResponseWriter{
+		wrapped:    w,
+		statusCode: http.StatusOK,
+	}
+}
+
+func (iw *idempotencyResponseWriter) Header() http.Header {
+	return iw.wrapped.Header()
+}
+
+func (iw *idempotencyResponseWriter) WriteHeader(statusCode int) {
+	iw.wrapped.WriteHeader(statusCode)
+
+	if !iw.headerWritten {
+		iw.statusCode = statusCode
+		iw.header = iw.wrapped.Header().Clone()
+		iw.headerWritten = true
+	}
+}
+
+func (iw *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	if !iw.headerWritten {
+		iw.WriteHeader(http.StatusOK)
+	}
+
+	iw.body.Write(b)
+	return iw.wrapped.Write(b)
+}
+
+func (iw *idempotencyResponseWriter) Unwrap() http.ResponseWriter {
+	return iw.wrapped
+}
+
 func (app *application) metrics(next http.Handler) http.Handler {
 	var (
 		totalRequestsReceived           = expvar.NewInt("total_requests_received")
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+)
+
+// idempotencyDB stands in for the idempotency_keys table. It understands the statements
+// of IdempotencyKeyModel that the middleware runs, and ignores expiry and locks.
+type idempotencyDB struct {
+	mu   sync.Mutex
+	keys map[string]*idempotencyRow
+}
+
+type idempotencyRow struct {
+	userID      int64
+	key         string
+	fingerprint string
+	status      any
+	headers     any
+	body        any
+}
+
+func newIdempotencyDB() *sql.DB {
+	return sql.OpenDB(&idempotencyDB{keys: make(map[string]*idempotencyRow)})
+}
+
+func (db *idempotencyDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db *idempotencyDB) Driver() driver.Driver                        { return nil }
+func (db *idempotencyDB) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (db *idempotencyDB) Close() error              { return nil }
+func (db *idempotencyDB) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func rowKey(userID, key any) string {
+	return fmt.Sprintf("%v\x00%v", userID, key)
+}
+
+func (db *idempotencyDB) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	query = strings.TrimSpace(query)
+
+	switch {
+	case strings.HasPrefix(query, "INSERT INTO idempotency_keys"):
+		k := rowKey(args[0].Value, args[1].Value)
+		if _, ok := db.keys[k]; ok {
+			return driver.RowsAffected(0), nil
+		}
+		db.keys[k] = &idempotencyRow{userID: args[0].Value.(int64), key: args[1].Value.(string), fingerprint: args[4].Value.(string)}
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(query, "UPDATE idempotency_keys"):
+		row, ok := db.keys[rowKey(args[3].Value, args[4].Value)]
+		if !ok || row.fingerprint != args[5].Value {
+			return driver.RowsAffected(0), nil
+		}
+		row.status, row.headers, row.body = args[0].Value, args[1].Value, args[2].Value
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(query, "DELETE FROM idempotency_keys"):
+		k := rowKey(args[0].Value, args[1].Value)
+		row, ok := db.keys[k]
+		if !ok || row.fingerprint != args[2].Value || row.status != nil {
+			return driver.RowsAffected(0), nil
+		}
+		delete(db.keys, k)
+		return driver.RowsAffected(1), nil
+	}
+
+	return nil, errors.New("unexpected statement: " + query)
+}
+
+func (db *idempotencyDB) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !strings.Contains(query, "FROM idempotency_keys") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+
+	rows := &idempotencyRows{}
+	if row, ok := db.keys[rowKey(args[0].Value, args[1].Value)]; ok {
+		rows.values = []driver.Value{row.userID, row.key, row.fingerprint, row.status, row.headers, row.body}
+	}
+	return rows, nil
+}
+
+type idempotencyRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *idempotencyRows) Columns() []string {
+	return []string{"user_id", "key", "fingerprint", "response_status", "response_headers", "response_body"}
+}
+
+func (r *idempotencyRows) Close() error { return nil }
+
+func (r *idempotencyRows) Next(dest []driver.Value) error {
+	if r.values == nil || r.done {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.done = true
+	return nil
+}
+
+// newIdempotencyTestHandler returns the idempotency middleware around a handler that
+// creates a record per request, and the number of records created.
+func newIdempotencyTestHandler() (http.Handler, *int) {
+	app := &application{
+		models: data.Models{IdempotencyKeys: data.IdempotencyKeyModel{DB: newIdempotencyDB()}},
+	}
+
+	created := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Location", "/v1/movies/1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+
+	user := &data.User{ID: 1, Activated: true}
+	withUser := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		app.idempotency(next).ServeHTTP(w, app.contextSetUser(r, user))
+	})
+
+	return withUser, &created
+}
+
+func postWithKey(h http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	r.Header.Set("Idempotency-Key", key)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	h, created := newIdempotencyTestHandler()
+
+	first := postWithKey(h, "/v1/movies", "key-1", `{"title":"Alien"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", first.Code, http.StatusCreated)
+	}
+
+	retry := postWithKey(h, "/v1/movies", "key-1", `{"title":"Alien"}`)
+
+	if *created != 1 {
+		t.Errorf("handler ran %d times, want once", *created)
+	}
+	if retry.Code != http.StatusCreated {
+		t.Errorf("replayed status = %d, want %d", retry.Code, http.StatusCreated)
+	}
+	if retry.Body.String() != first.Body.String() {
+		t.Errorf("replayed body = %q, want %q", retry.Body, first.Body)
+	}
+	if retry.Header().Get("Location") != "/v1/movies/1" {
+		t.Error("the headers of the response were not replayed")
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("the replayed response is not marked with Idempotent-Replayed")
+	}
+}
+
+func TestIdempotencyFingerprintMismatch(t *testing.T) {
+	h, created := newIdempotencyTestHandler()
+
+	postWithKey(h, "/v1/movies", "key-1", `{"title":"Alien"}`)
+	w := postWithKey(h, "/v1/movies", "key-1", `{"title":"Aliens"}`)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if *created != 1 {
+		t.Errorf("handler ran %d times, want once", *created)
+	}
+}
+
+func TestIdempotencyIgnoredOutsideAllowlist(t *testing.T) {
+	h, created := newIdempotencyTestHandler()
+
+	for _, path := range []string{"/v1/tokens/authentication", "/v1/movies/1/merge"} {
+		postWithKey(h, path, "key-1", `{}`)
+		w := postWithKey(h, path, "key-1", `{}`)
+
+		if w.Header().Get("Idempotent-Replayed") != "" {
+			t.Errorf("the response of %s was stored and replayed", path)
+		}
+	}
+
+	if *created != 4 {
+		t.Errorf("handler ran %d times, want every request handled", *created)
+	}
+}
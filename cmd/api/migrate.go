@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/ucok-man/gmoapi/migrations"
+)
+
+// migrateUsage describes the arguments of the migrate command.
+const migrateUsage = "usage: migrate up|down [VERSION]|status|version"
+
+// runMigrate runs the migrate command against the database and prints its outcome:
+//
+//   - up applies every pending migration.
+//   - down rolls back the last migration, or every migration after VERSION.
+//   - status lists the migrations and whether they have been applied.
+//   - version prints the version of the schema, and the version this binary expects.
+func runMigrate(db *sql.DB, args []string) error {
+	if len(args) == 0 || (args[0] != "down" && len(args) > 1) || len(args) > 2 {
+		return errors.New(migrateUsage)
+	}
+
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		results, err := provider.Up(ctx)
+		if err != nil {
+			var partialErr *goose.PartialError
+			if errors.As(err, &partialErr) {
+				printMigrationResults(partialErr.Applied)
+			}
+			return err
+		}
+
+		if len(results) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		printMigrationResults(results)
+
+	case "down":
+		var results []*goose.MigrationResult
+
+		if len(args) == 2 {
+			version, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version %q: %w", args[1], err)
+			}
+
+			results, err = provider.DownTo(ctx, version)
+			if err != nil {
+				return err
+			}
+		} else {
+			result, err := provider.Down(ctx)
+			if err != nil {
+				if errors.Is(err, goose.ErrNoNextVersion) {
+					fmt.Println("no migrations to roll back")
+					return nil
+				}
+				return err
+			}
+			results = append(results, result)
+		}
+
+		printMigrationResults(results)
+
+	case "status":
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "APPLIED AT\tMIGRATION")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.State == goose.StateApplied {
+				appliedAt = status.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", appliedAt, status.Source.Path)
+		}
+		return tw.Flush()
+
+	case "version":
+		current, target, err := provider.GetVersions(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version %d, latest %d\n", current, target)
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+func printMigrationResults(results []*goose.MigrationResult) {
+	for _, result := range results {
+		fmt.Println(result)
+	}
+}
+
+// migrateOnStart applies the pending migrations before the server starts. When several
+// servers start at once, one of them applies the migrations while the others wait for
+// the advisory lock, and then find nothing left to apply.
+func migrateOnStart(db *sql.DB, logger *slog.Logger) error {
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.Up(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		logger.Info("applied migration", "migration", result.Source.Path, "duration", result.Duration)
+	}
+
+	return nil
+}
+
+// checkSchema returns an error when migrations that this binary was built with have
+// not been applied to the database, so that the server doesn't run against a schema it
+// doesn't match. A schema that is ahead is fine, as happens while a newer version is
+// being rolled out.
+func checkSchema(db *sql.DB) error {
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pending, err := provider.HasPending(ctx)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		return nil
+	}
+
+	current, target, err := provider.GetVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("database schema is behind: at version %d, expected %d; run \"api migrate up\" or start with -migrate-on-start", current, target)
+}
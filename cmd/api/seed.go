@@ -0,0 +1,67 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/ucok-man/gmoapi/cmd/api/config"
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/eventbus"
+	"github.com/ucok-man/gmoapi/internal/seed"
+)
+
+// runSeed runs the seed command, which fills the database with generated sample data.
+// It refuses to run in production.
+func runSeed(cfg config.Config, db *sql.DB, logger *slog.Logger, args []string) error {
+	opts := seed.DefaultOptions
+
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.Uint64Var(&opts.Seed, "seed", opts.Seed, "Seed of the random generator")
+	fs.IntVar(&opts.Movies, "movies", opts.Movies, "Number of movies to generate")
+	fs.IntVar(&opts.Users, "users", opts.Users, "Number of users to generate besides the admin, editor, reader and inactive users")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || opts.Movies < 0 || opts.Users < 0 {
+		return errors.New("usage: seed [-seed N] [-movies N] [-users N]")
+	}
+
+	if cfg.Env.IsProduction() {
+		return errors.New("refusing to seed a production database")
+	}
+
+	err = checkSchema(db)
+	if err != nil {
+		return err
+	}
+
+	fixtures, err := seed.Generate(opts)
+	if err != nil {
+		return err
+	}
+
+	// Publish on the event bus of the database, so that running servers learn about the
+	// new movies like they would about movies created through the API.
+	bus, err := eventbus.NewPostgres(cfg.DB.DSN, logger)
+	if err != nil {
+		return err
+	}
+	defer bus.Close()
+
+	err = seed.Load(data.NewModels(db, bus), fixtures)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("seeded %d genres, %d movies and %d users with the password %q\n", len(fixtures.Genres), len(fixtures.Movies), len(fixtures.Users), seed.Password)
+	for _, user := range fixtures.Users[:len(seed.Roles)] {
+		fmt.Printf("  %-22s %s token %s\n", user.Email, user.Tokens[0].Scope, user.Tokens[0].Plaintext)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -22,10 +23,58 @@ func (app *application) serve() error {
 		ErrorLog:     slog.NewLogLogger(app.logger.Handler(), slog.LevelError),
 	}
 
+	// Event streams stay open until the client goes away, so end them when shutting
+	// down rather than waiting for the clients.
+	srv.RegisterOnShutdown(app.events.close)
+
+	// The relay stops by itself once the event bus is closed, after the server.
+	go app.relayMovieChanges()
+
 	// Create a shutdownError channel. We will use this to receive any errors returned
 	// by the graceful Shutdown() function.
 	shutdownError := make(chan error)
 
+	// Send webhook deliveries, refresh similar movie rankings and purge expired
+	// idempotency keys in the background until the server shuts down.
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		app.dispatchWebhooks(backgroundCtx)
+	}()
+
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		app.refreshSimilarMovies(backgroundCtx)
+	}()
+
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		app.purgeIdempotencyKeys(backgroundCtx)
+	}()
+
+	// The gRPC server runs on its own port next to the REST API, and is stopped along
+	// with it.
+	grpcSrv, grpcHealth := app.newGRPCServer()
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", app.config.GRPC.Port))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		app.logger.Info("starting grpc server", "addr", grpcListener.Addr().String())
+
+		err := grpcSrv.Serve(grpcListener)
+		if err != nil {
+			app.logger.Error(err.Error(), "addr", grpcListener.Addr().String())
+		}
+	}()
+
 	go func() {
 		quit := make(chan os.Signal, 1)
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -43,11 +92,21 @@ func (app *application) serve() error {
 		// Shutdown() will return nil if the graceful shutdown was successful, or an
 		// error (which may happen because of a problem closing the listeners, or
 		// because the shutdown didn't complete before the 30-second context deadline is hit).
-		// We relay this return value to the shutdownError channel.
-		shutdownError <- srv.Shutdown(ctx)
+		// Only an error is relayed to the shutdownError channel right away, so that
+		// serve() keeps waiting for the rest of the shutdown otherwise.
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			shutdownError <- err
+		}
+
+		app.logger.Info("stopping grpc server", "addr", grpcListener.Addr().String())
+
+		app.stopGRPC(ctx, grpcSrv, grpcHealth)
 
 		app.logger.Info("completing background tasks", "addr", srv.Addr)
 
+		stopBackground()
+
 		// Call Wait() to block until our WaitGroup counter is zero.
 		app.wg.Wait()
 		shutdownError <- nil
@@ -68,7 +127,7 @@ func (app *application) serve() error {
 	// return a http.ErrServerClosed error. So if we see this error, it is actually a
 	// good thing and an indication that the graceful shutdown has started. So we check
 	// specifically for this, only returning the error if it is NOT http.ErrServerClosed.
-	err := srv.ListenAndServe()
+	err = srv.ListenAndServe()
 	if !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// similarRefreshInterval is how often stale similar movie rankings are looked for.
+	similarRefreshInterval = 30 * time.Second
+
+	// similarRefreshBatchSize is the number of stale rankings recomputed at a time.
+	similarRefreshBatchSize = 50
+)
+
+// refreshSimilarMovies keeps the similar movie rankings up to date, recomputing the
+// rankings of new and changed movies, and those that have grown old, periodically
+// until ctx is cancelled. Requests for similar movies only read the rankings.
+func (app *application) refreshSimilarMovies(ctx context.Context) {
+	ticker := time.NewTicker(similarRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while full batches come back, so that a backlog, such as the
+		// whole catalog after an import, is worked off without waiting for the next
+		// tick.
+		for ctx.Err() == nil {
+			n, err := app.refreshStaleSimilar(ctx)
+			if err != nil {
+				app.logger.Error(err.Error())
+				break
+			}
+			if n < similarRefreshBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshStaleSimilar recomputes a batch of stale rankings and returns the number of
+// movies in the batch.
+func (app *application) refreshStaleSimilar(ctx context.Context) (int, error) {
+	movies, err := app.models.Movies.StaleSimilar(similarRefreshBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, movie := range movies {
+		if ctx.Err() != nil {
+			break
+		}
+
+		err := app.models.Movies.RefreshSimilar(movie)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(movies), errors.Join(errs...)
+}
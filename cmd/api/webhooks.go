@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/webhook"
+)
+
+// webhookBatchSize is the number of deliveries claimed from the queue at once.
+const webhookBatchSize = 10
+
+// dispatchWebhooks sends the deliveries from the queue as they become due, until ctx
+// is cancelled.
+func (app *application) dispatchWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(app.config.Webhooks.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Keep going while full batches come back, so that a backlog is worked off
+		// without waiting for the next tick.
+		for ctx.Err() == nil {
+			n, err := app.sendDueWebhooks(ctx)
+			if err != nil {
+				app.logger.Error(err.Error())
+				break
+			}
+			if n < webhookBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// sendDueWebhooks claims a batch of due deliveries and attempts each of them, returning
+// the number of deliveries claimed. Deliveries that are claimed but not attempted
+// because ctx was cancelled are picked up again once their lease runs out.
+func (app *application) sendDueWebhooks(ctx context.Context) (int, error) {
+	// The lease has to outlast attempting the whole batch.
+	lease := webhookBatchSize*app.config.Webhooks.Timeout + time.Minute
+
+	deliveries, err := app.models.WebhookDeliveries.ClaimDue(webhookBatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// An attempt that has started is seen through even when the server is
+		// stopping, its duration is bounded by the webhook timeout.
+		attemptDelivery(context.Background(), app.webhooks, delivery)
+
+		err = app.models.WebhookDeliveries.RecordAttempt(delivery)
+		if err != nil {
+			return len(deliveries), err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attemptDelivery sends a delivery and records the outcome on it: a successful attempt
+// completes the delivery, and a failed one is retried after webhook.Backoff until it
+// has been attempted data.WebhookMaxAttempts times.
+func attemptDelivery(ctx context.Context, sender *webhook.Sender, delivery *data.WebhookDelivery) {
+	result := sender.Send(ctx, webhook.Message{
+		DeliveryID: delivery.ID,
+		Event:      delivery.Event,
+		URL:        delivery.URL,
+		Secret:     delivery.Secret,
+		Payload:    delivery.Payload,
+	})
+
+	delivery.Attempts++
+	delivery.ResponseStatus = result.StatusCode
+	delivery.LastError = ""
+
+	switch {
+	case result.OK():
+		delivery.Status = data.DeliverySucceeded
+	case delivery.Attempts >= data.WebhookMaxAttempts:
+		delivery.Status = data.DeliveryFailed
+		delivery.LastError = result.Err.Error()
+	default:
+		delivery.NextAttemptAt = time.Now().Add(webhook.Backoff(delivery.Attempts))
+		delivery.LastError = result.Err.Error()
+	}
+}
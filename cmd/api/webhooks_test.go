@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/webhook"
+)
+
+func TestAttemptDelivery(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ok.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	sender := webhook.New(5 * time.Second)
+
+	t.Run("success", func(t *testing.T) {
+		delivery := &data.WebhookDelivery{ID: 1, Status: data.DeliveryPending, URL: ok.URL, Payload: []byte(`{}`)}
+
+		attemptDelivery(context.Background(), sender, delivery)
+
+		if delivery.Status != data.DeliverySucceeded {
+			t.Errorf("Status = %q, want %q", delivery.Status, data.DeliverySucceeded)
+		}
+		if delivery.Attempts != 1 {
+			t.Errorf("Attempts = %d, want 1", delivery.Attempts)
+		}
+		if delivery.ResponseStatus != http.StatusOK {
+			t.Errorf("ResponseStatus = %d, want %d", delivery.ResponseStatus, http.StatusOK)
+		}
+		if delivery.LastError != "" {
+			t.Errorf("LastError = %q, want none", delivery.LastError)
+		}
+	})
+
+	t.Run("failure is retried with backoff", func(t *testing.T) {
+		delivery := &data.WebhookDelivery{ID: 2, Status: data.DeliveryPending, Attempts: 2, URL: failing.URL, Payload: []byte(`{}`)}
+
+		before := time.Now()
+		attemptDelivery(context.Background(), sender, delivery)
+
+		if delivery.Status != data.DeliveryPending {
+			t.Errorf("Status = %q, want %q", delivery.Status, data.DeliveryPending)
+		}
+		if delivery.Attempts != 3 {
+			t.Errorf("Attempts = %d, want 3", delivery.Attempts)
+		}
+		if delivery.ResponseStatus != http.StatusServiceUnavailable {
+			t.Errorf("ResponseStatus = %d, want %d", delivery.ResponseStatus, http.StatusServiceUnavailable)
+		}
+		if delivery.LastError == "" {
+			t.Error("LastError is empty, want the failure")
+		}
+
+		wait := delivery.NextAttemptAt.Sub(before)
+		if backoff := webhook.Backoff(3); wait < backoff || wait > backoff+5*time.Second {
+			t.Errorf("next attempt in %s, want about %s", wait, backoff)
+		}
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		delivery := &data.WebhookDelivery{ID: 3, Status: data.DeliveryPending, URL: failing.URL, Payload: []byte(`{}`)}
+
+		for range data.WebhookMaxAttempts - 1 {
+			attemptDelivery(context.Background(), sender, delivery)
+			if delivery.Status != data.DeliveryPending {
+				t.Fatalf("Status = %q after %d attempts, want %q", delivery.Status, delivery.Attempts, data.DeliveryPending)
+			}
+		}
+
+		attemptDelivery(context.Background(), sender, delivery)
+
+		if delivery.Status != data.DeliveryFailed {
+			t.Errorf("Status = %q, want %q", delivery.Status, data.DeliveryFailed)
+		}
+		if delivery.Attempts != data.WebhookMaxAttempts {
+			t.Errorf("Attempts = %d, want %d", delivery.Attempts, data.WebhookMaxAttempts)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/mailer"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+var mailCommands = []command{
+	{name: "test", usage: "RECIPIENT", run: testMail},
+}
+
+// testMail sends a test email with the SMTP settings of the API, to check them.
+func testMail(app *application, fs *flag.FlagSet, args []string) error {
+	if err := app.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	recipient := fs.Arg(0)
+
+	v := validator.New()
+	if v.Check(validator.Matches(recipient, validator.EmailRX), "recipient", "must be a valid email address"); !v.Valid() {
+		return validationError(v.Errors)
+	}
+
+	cfg := app.config.SMTP
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	m, err := mailer.New(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.Sender)
+	if err != nil {
+		return err
+	}
+
+	hostname, _ := os.Hostname()
+	data := map[string]any{
+		"host":     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		"sender":   cfg.Sender,
+		"hostname": hostname,
+		"sentAt":   time.Now().Format(time.RFC1123Z),
+	}
+
+	err = m.Send(recipient, "mail_test.tmpl", data)
+	if err != nil {
+		return err
+	}
+
+	return app.print(envelope{"recipient": recipient, "smtp_host": data["host"]}, func(w io.Writer) {
+		fmt.Fprintf(w, "sent a test email to %s through %s\n", recipient, data["host"])
+	})
+}
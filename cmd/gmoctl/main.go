@@ -0,0 +1,237 @@
+// Command gmoctl administers a gmoapi deployment from the command line: it manages
+// users, their permissions and tokens, imports and exports the movie catalog, and
+// checks the mail setup.
+//
+// It reads the same GMOAPI_* environment variables as the API, so it is run next to
+// the API with the same environment:
+//
+//	gmoctl [-db-dsn DSN] [-json] <group> <command> [flags] [arguments]
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/ucok-man/gmoapi/cmd/api/config"
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/eventbus"
+)
+
+type application struct {
+	config config.Config
+	models data.Models
+	stdin  io.Reader
+	stdout io.Writer
+
+	// json makes commands write their results as JSON instead of text.
+	json bool
+
+	// database is set when the command needs the database, which is then opened once
+	// its flags have been parsed.
+	database bool
+	db       *sql.DB
+	bus      eventbus.Bus
+}
+
+// command is a subcommand of a group, such as create in "gmoctl user create".
+type command struct {
+	name  string
+	usage string
+	run   func(app *application, fs *flag.FlagSet, args []string) error
+}
+
+// group is a set of commands on the same kind of record.
+type group struct {
+	name     string
+	commands []command
+
+	// database is set for groups whose commands need the database.
+	database bool
+}
+
+var groups = []group{
+	{name: "user", commands: userCommands, database: true},
+	{name: "perm", commands: permCommands, database: true},
+	{name: "token", commands: tokenCommands, database: true},
+	{name: "movie", commands: movieCommands, database: true},
+	{name: "mail", commands: mailCommands},
+}
+
+// errUsage is returned by commands that were called with the wrong arguments, after
+// they printed their usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	cfg, err := config.FromEnv()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	app := &application{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+	}
+
+	flag.StringVar(&cfg.DB.DSN, "db-dsn", cfg.DB.DSN, "PostgreSQL connection string")
+	flag.BoolVar(&app.json, "json", false, "Write results as JSON")
+	flag.Usage = usage
+	flag.Parse()
+
+	app.config = cfg
+
+	err = app.run(flag.Args())
+	if err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "gmoctl:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func (app *application) run(args []string) error {
+	if len(args) < 2 {
+		usage()
+		return errUsage
+	}
+
+	for _, g := range groups {
+		if g.name != args[0] {
+			continue
+		}
+
+		for _, cmd := range g.commands {
+			if cmd.name != args[1] {
+				continue
+			}
+
+			app.database = g.database
+			defer app.closeDB()
+
+			return cmd.run(app, newFlagSet(g.name, cmd), args[2:])
+		}
+	}
+
+	usage()
+	return errUsage
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: gmoctl [OPTIONS] <group> <command> [flags] [arguments]\n\n")
+	fmt.Fprintf(os.Stderr, "The database and mail settings are read from the GMOAPI_* environment\n")
+	fmt.Fprintf(os.Stderr, "variables of the API, see api -help.\n\n")
+
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	for _, g := range groups {
+		for _, cmd := range g.commands {
+			fmt.Fprintf(os.Stderr, "  %s %s %s\n", g.name, cmd.name, cmd.usage)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\nOptions:\n")
+	flag.PrintDefaults()
+}
+
+// newFlagSet returns the flag set of a command, which prints the usage of the command
+// when it is called with the wrong flags.
+func newFlagSet(group string, cmd command) *flag.FlagSet {
+	fs := flag.NewFlagSet(group+" "+cmd.name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: gmoctl %s %s %s\n", group, cmd.name, cmd.usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the flags of a command and checks the number of remaining
+// arguments, which must be between minArgs and maxArgs, or at least minArgs when
+// maxArgs is -1.
+// The database is opened afterwards, if the command needs it.
+func (app *application) parseFlags(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+
+	n := fs.NArg()
+	if n < minArgs || (maxArgs >= 0 && n > maxArgs) {
+		fs.Usage()
+		return errUsage
+	}
+
+	if app.database {
+		app.db, app.bus, err = openDB(app.config)
+		if err != nil {
+			return err
+		}
+		app.models = data.NewModels(app.db, app.bus)
+	}
+
+	return nil
+}
+
+func (app *application) closeDB() {
+	if app.bus != nil {
+		app.bus.Close()
+	}
+	if app.db != nil {
+		app.db.Close()
+	}
+}
+
+// openDB connects to the database of the API, along with the event bus, so that the
+// changes of commands reach the running API servers, for example to close the event
+// streams of a user whose tokens were revoked.
+func openDB(cfg config.Config) (*sql.DB, eventbus.Bus, error) {
+	if err := cfg.DB.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	db, err := sql.Open("postgres", cfg.DB.DSN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.DB.MaxOpenConn)
+	db.SetMaxIdleConns(cfg.DB.MaxIdleConn)
+	db.SetConnMaxIdleTime(cfg.DB.MaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	bus, err := eventbus.NewPostgres(cfg.DB.DSN, logger)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return db, bus, nil
+}
+
+// splitList splits a comma separated flag value, ignoring blanks.
+func splitList(value string) []string {
+	var values []string
+	for v := range strings.SplitSeq(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+var movieCommands = []command{
+	{name: "import", usage: "[-force] FILE|-", run: importMovies},
+	{name: "export", usage: "[-o FILE]", run: exportMovies},
+}
+
+// exportPageSize is the number of movies read from the database at a time.
+const exportPageSize = 100
+
+// skippedMovie is a movie of an import that wasn't inserted.
+type skippedMovie struct {
+	Index  int    `json:"index"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// importMovies inserts the movies of a JSON array, as written by export, into the
+// catalog. All movies are validated before any is inserted, and they are inserted in a
+// single transaction, so an import that fails leaves the catalog unchanged. Movies that
+// look like a movie already in the catalog, or share its external ids, are skipped.
+func importMovies(app *application, fs *flag.FlagSet, args []string) error {
+	force := fs.Bool("force", false, "Import movies even when they look like movies already in the catalog")
+
+	if err := app.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	var r io.Reader = app.stdin
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var movies []*data.Movie
+	err := json.NewDecoder(r).Decode(&movies)
+	if err != nil {
+		return fmt.Errorf("reading movies: %w", err)
+	}
+
+	taxonomy, err := app.models.Genres.GetTaxonomy()
+	if err != nil {
+		return err
+	}
+
+	for i, movie := range movies {
+		v := validator.New()
+		if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+			return fmt.Errorf("movie %d (%q): %w", i, movie.Title, validationError(v.Errors))
+		}
+	}
+
+	skipped := []skippedMovie{}
+
+	// Movies that look like duplicates are left out before anything is written, the
+	// rest are inserted in one transaction so that a failure imports none of them.
+	batch := make([]*data.Movie, 0, len(movies))
+	indexes := make([]int, 0, len(movies))
+
+	for i, movie := range movies {
+		if !*force {
+			candidates, err := app.models.Movies.FindDuplicates(movie)
+			if err != nil {
+				return err
+			}
+			if len(candidates) > 0 {
+				skipped = append(skipped, skippedMovie{i, movie.Title, fmt.Sprintf("likely duplicate of %v", candidates)})
+				continue
+			}
+		}
+
+		batch = append(batch, movie)
+		indexes = append(indexes, i)
+	}
+
+	conflicts, err := app.models.Movies.InsertMany(batch)
+	if err != nil {
+		return fmt.Errorf("no movies were imported: %w", err)
+	}
+
+	for j, err := range conflicts {
+		skipped = append(skipped, skippedMovie{indexes[j], batch[j].Title, err.Error()})
+	}
+	slices.SortFunc(skipped, func(a, b skippedMovie) int { return a.Index - b.Index })
+
+	imported := len(batch) - len(conflicts)
+
+	return app.print(envelope{"imported": imported, "skipped": skipped}, func(w io.Writer) {
+		fmt.Fprintf(w, "imported %d of %d movies\n", imported, len(movies))
+		for _, s := range skipped {
+			fmt.Fprintf(w, "skipped %d\t%s\t%s\n", s.Index, s.Title, s.Reason)
+		}
+	})
+}
+
+// exportMovies writes the whole catalog as a JSON array that import reads back. The
+// output is JSON with or without -json.
+func exportMovies(app *application, fs *flag.FlagSet, args []string) error {
+	output := fs.String("o", "-", "File to write to, stdout when -")
+
+	if err := app.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	movies := []*data.Movie{}
+
+	filter := data.Filter{
+		Page:         1,
+		PageSize:     exportPageSize,
+		Sort:         "id",
+		SortSafelist: []string{"id"},
+	}
+
+	for {
+		page, metadata, err := app.models.Movies.GetAll(data.MovieSearch{}, filter)
+		if err != nil {
+			return err
+		}
+		movies = append(movies, page...)
+
+		if filter.Page >= metadata.LastPage {
+			break
+		}
+		filter.Page++
+	}
+
+	js, err := json.MarshalIndent(movies, "", "\t")
+	if err != nil {
+		return err
+	}
+	js = append(js, '\n')
+
+	if *output == "-" {
+		_, err = app.stdout.Write(js)
+		return err
+	}
+
+	err = os.WriteFile(*output, js, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d movies to %s\n", len(movies), *output)
+	return nil
+}
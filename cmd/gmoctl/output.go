@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// envelope is the JSON output of a command, keyed like the responses of the API.
+type envelope map[string]any
+
+// print writes the result of a command: data as JSON with -json, or whatever text
+// writes otherwise. The text is aligned at tabs, so that it can be written as a table.
+func (app *application) print(data envelope, text func(w io.Writer)) error {
+	if app.json {
+		js, err := json.MarshalIndent(data, "", "\t")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(app.stdout, "%s\n", js)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(app.stdout, 0, 0, 2, ' ', 0)
+	text(tw)
+	return tw.Flush()
+}
+
+// validationError turns the errors of a validator into a single error.
+func validationError(errs map[string]string) error {
+	fields := slices.Sorted(maps.Keys(errs))
+	for i, field := range fields {
+		fields[i] = field + " " + errs[field]
+	}
+
+	return errors.New("invalid input: " + strings.Join(fields, ", "))
+}
+
+func formatTime(t time.Time) string {
+	return t.Local().Format(time.DateTime)
+}
+
+func formatList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
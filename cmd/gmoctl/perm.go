@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+var permCommands = []command{
+	{name: "grant", usage: "EMAIL CODE...", run: grantPermissions},
+	{name: "revoke", usage: "EMAIL CODE...", run: revokePermissions},
+	{name: "list", usage: "[EMAIL]", run: listPermissions},
+}
+
+func grantPermissions(app *application, fs *flag.FlagSet, args []string) error {
+	if err := app.parseFlags(fs, args, 2, -1); err != nil {
+		return err
+	}
+
+	user, err := app.getUser(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	codes := fs.Args()[1:]
+	err = app.checkPermissionCodes(codes)
+	if err != nil {
+		return err
+	}
+
+	err = app.models.Permissions.AddForUser(user.ID, codes...)
+	if err != nil {
+		return err
+	}
+
+	return app.printUser(user)
+}
+
+func revokePermissions(app *application, fs *flag.FlagSet, args []string) error {
+	if err := app.parseFlags(fs, args, 2, -1); err != nil {
+		return err
+	}
+
+	user, err := app.getUser(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	codes := fs.Args()[1:]
+	err = app.checkPermissionCodes(codes)
+	if err != nil {
+		return err
+	}
+
+	err = app.models.Permissions.RemoveForUser(user.ID, codes...)
+	if err != nil {
+		return err
+	}
+
+	return app.printUser(user)
+}
+
+// listPermissions lists the permissions of a user, or every permission that can be
+// granted when no user is given.
+func listPermissions(app *application, fs *flag.FlagSet, args []string) error {
+	if err := app.parseFlags(fs, args, 0, 1); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 1 {
+		user, err := app.getUser(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		return app.printUser(user)
+	}
+
+	permissions, err := app.models.Permissions.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return app.print(envelope{"permissions": permissions}, func(w io.Writer) {
+		for _, code := range permissions {
+			fmt.Fprintln(w, code)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+var tokenCommands = []command{
+	{name: "revoke", usage: "[-scope SCOPE] EMAIL", run: revokeTokens},
+}
+
+// tokenScopes lists the scopes that tokens can be revoked for.
+var tokenScopes = []string{data.ScopeAuthentication, data.ScopeActivation, data.ScopePasswordReset}
+
+func revokeTokens(app *application, fs *flag.FlagSet, args []string) error {
+	scope := fs.String("scope", data.ScopeAuthentication, "Scope of the tokens to revoke (authentication|activation|password-reset|all)")
+
+	if err := app.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	v := validator.New()
+	if v.Check(*scope == "all" || validator.PermittedValue(*scope, tokenScopes...), "scope", "must be one of authentication, activation, password-reset or all"); !v.Valid() {
+		return validationError(v.Errors)
+	}
+
+	user, err := app.getUser(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	scopes := []string{*scope}
+	if *scope == "all" {
+		scopes = tokenScopes
+	}
+
+	for _, scope := range scopes {
+		err = app.models.Tokens.DeleteAllForUser(scope, user.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return app.print(envelope{"user": user, "scopes": scopes}, func(w io.Writer) {
+		fmt.Fprintf(w, "revoked the %s tokens of %s\n", formatList(scopes), user.Email)
+	})
+}
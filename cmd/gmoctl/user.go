@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+var userCommands = []command{
+	{name: "create", usage: "[-activated] [-permissions CODES] [-password PASSWORD] -name NAME -email EMAIL", run: createUser},
+	{name: "activate", usage: "EMAIL", run: activateUser},
+	{name: "deactivate", usage: "EMAIL", run: deactivateUser},
+	{name: "list", usage: "[-page N] [-page-size N] [-sort FIELD]", run: listUsers},
+}
+
+// userView is a user along with their permissions, as the user commands print it.
+type userView struct {
+	*data.User
+	Permissions data.Permissions `json:"permissions"`
+}
+
+func newUserView(user *data.User, permissions data.Permissions) userView {
+	if permissions == nil {
+		permissions = data.Permissions{}
+	}
+	return userView{User: user, Permissions: permissions}
+}
+
+func createUser(app *application, fs *flag.FlagSet, args []string) error {
+	name := fs.String("name", "", "Name of the user")
+	email := fs.String("email", "", "Email address of the user")
+	password := fs.String("password", "", "Password of the user, read from the first line of stdin when empty")
+	activated := fs.Bool("activated", false, "Activate the user right away")
+	permissions := fs.String("permissions", "movies:read", "Permissions to grant (comma separated)")
+
+	if err := app.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if *password == "" {
+		line, err := bufio.NewReader(app.stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	user := &data.User{
+		Name:      *name,
+		Email:     *email,
+		Activated: *activated,
+	}
+
+	err := user.Password.Set(*password)
+	if err != nil {
+		return err
+	}
+
+	v := validator.New()
+	if data.ValidateUser(v, user); !v.Valid() {
+		return validationError(v.Errors)
+	}
+
+	codes := splitList(*permissions)
+	err = app.checkPermissionCodes(codes)
+	if err != nil {
+		return err
+	}
+
+	err = app.models.Users.Insert(user)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateEmail):
+			return fmt.Errorf("a user with the email address %s already exists", user.Email)
+		default:
+			return err
+		}
+	}
+
+	if len(codes) > 0 {
+		err = app.models.Permissions.AddForUser(user.ID, codes...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return app.printUser(user)
+}
+
+func activateUser(app *application, fs *flag.FlagSet, args []string) error {
+	if err := app.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	user, err := app.setActivated(fs.Arg(0), true)
+	if err != nil {
+		return err
+	}
+
+	// Outstanding activation tokens have no use anymore.
+	err = app.models.Tokens.DeleteAllForUser(data.ScopeActivation, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return app.printUser(user)
+}
+
+func deactivateUser(app *application, fs *flag.FlagSet, args []string) error {
+	if err := app.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	user, err := app.setActivated(fs.Arg(0), false)
+	if err != nil {
+		return err
+	}
+
+	// Sign the user out everywhere, which also closes their event streams.
+	err = app.models.Tokens.DeleteAllForUser(data.ScopeAuthentication, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return app.printUser(user)
+}
+
+func (app *application) setActivated(email string, activated bool) (*data.User, error) {
+	user, err := app.getUser(email)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Activated = activated
+
+	err = app.models.Users.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func listUsers(app *application, fs *flag.FlagSet, args []string) error {
+	var filter data.Filter
+	fs.IntVar(&filter.Page, "page", 1, "Page to list")
+	fs.IntVar(&filter.PageSize, "page-size", 50, "Number of users per page")
+	fs.StringVar(&filter.Sort, "sort", "id", "Field to sort by, prefixed with - for descending order")
+
+	if err := app.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	filter.SortSafelist = []string{"id", "name", "email", "created_at", "-id", "-name", "-email", "-created_at"}
+
+	v := validator.New()
+	if data.ValidateFilters(v, filter); !v.Valid() {
+		return validationError(v.Errors)
+	}
+
+	users, metadata, err := app.models.Users.GetAll(filter)
+	if err != nil {
+		return err
+	}
+
+	views := make([]userView, len(users))
+	for i, user := range users {
+		permissions, err := app.models.Permissions.GetAllForUser(user.ID)
+		if err != nil {
+			return err
+		}
+		views[i] = newUserView(user, permissions)
+	}
+
+	return app.print(envelope{"users": views, "metadata": metadata}, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tEMAIL\tNAME\tACTIVATED\tPERMISSIONS\tCREATED")
+		for _, view := range views {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\t%s\n", view.ID, view.Email, view.Name, view.Activated, formatList(view.Permissions), formatTime(view.CreatedAt))
+		}
+		if metadata.TotalRecords > 0 {
+			fmt.Fprintf(w, "\npage %d of %d, %d users\n", metadata.CurrentPage, metadata.LastPage, metadata.TotalRecords)
+		}
+	})
+}
+
+// getUser returns the user with the email address.
+func (app *application) getUser(email string) (*data.User, error) {
+	user, err := app.models.Users.GetByEmail(email)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, fmt.Errorf("no user with the email address %s", email)
+		default:
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// checkPermissionCodes returns an error if any of the codes isn't a known permission.
+func (app *application) checkPermissionCodes(codes []string) error {
+	known, err := app.models.Permissions.GetAll()
+	if err != nil {
+		return err
+	}
+
+	for _, code := range codes {
+		if !slices.Contains(known, code) {
+			return fmt.Errorf("unknown permission %q, must be one of %s", code, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+func (app *application) printUser(user *data.User) error {
+	permissions, err := app.models.Permissions.GetAllForUser(user.ID)
+	if err != nil {
+		return err
+	}
+
+	view := newUserView(user, permissions)
+
+	return app.print(envelope{"user": view}, func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%d\n", view.ID)
+		fmt.Fprintf(w, "Email:\t%s\n", view.Email)
+		fmt.Fprintf(w, "Name:\t%s\n", view.Name)
+		fmt.Fprintf(w, "Activated:\t%t\n", view.Activated)
+		fmt.Fprintf(w, "Permissions:\t%s\n", formatList(view.Permissions))
+		fmt.Fprintf(w, "Created:\t%s\n", formatTime(view.CreatedAt))
+	})
+}
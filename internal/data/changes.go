@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/eventbus"
+)
+
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// MovieChange is an entry of the movie change log. Seq increases with every change,
+// in the order the changes were committed. It is 0 in the changes published on the
+// event bus, which are published before they have been committed and numbered.
+type MovieChange struct {
+	Seq       int64     `json:"seq"`
+	CreatedAt time.Time `json:"created_at"`
+	MovieID   int64     `json:"movie_id"`
+	Type      string    `json:"type"`
+	Version   int32     `json:"version"`
+}
+
+type MovieChangeModel struct {
+	DB *sql.DB
+}
+
+// movieChangesLockID is the key of the advisory lock that numbers the changes.
+const movieChangesLockID = 7384016273510931457
+
+// sequence numbers the changes that have been committed since it last ran, in the order
+// they were written. Changes are written without a number, so that writers don't have
+// to wait for each other. Numbering them only once they have been committed, one
+// numbering at a time, means that a change never gets a lower number than a change that
+// has been read already: the log is only read up to the numbered changes.
+func (m MovieChangeModel) sequence(ctx context.Context) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The lock is released when the numbers are committed, so the next numbering sees
+	// them and starts where this one stopped.
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, movieChangesLockID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE movie_changes SET seq = pending.seq
+		FROM (
+			SELECT id, nextval('movie_changes_seq_seq') AS seq
+			FROM (SELECT id FROM movie_changes WHERE seq IS NULL ORDER BY id) AS unsequenced
+		) AS pending
+		WHERE movie_changes.id = pending.id`
+
+	_, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSince returns up to limit changes with a sequence number greater than since,
+// ordered by sequence number.
+func (m MovieChangeModel) GetSince(since int64, limit int) ([]*MovieChange, error) {
+	query := `
+		SELECT seq, created_at, movie_id, type, version
+		FROM movie_changes
+		WHERE seq > $1
+		ORDER BY seq
+		LIMIT $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.sequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.DB.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*MovieChange{}
+
+	for rows.Next() {
+		var change MovieChange
+		err := rows.Scan(
+			&change.Seq,
+			&change.CreatedAt,
+			&change.MovieID,
+			&change.Type,
+			&change.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, &change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// LatestSeq returns the sequence number of the latest change, or 0 when there are none.
+func (m MovieChangeModel) LatestSeq() (int64, error) {
+	query := `SELECT COALESCE(max(seq), 0) FROM movie_changes`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.sequence(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var seq int64
+	err = m.DB.QueryRowContext(ctx, query).Scan(&seq)
+	return seq, err
+}
+
+// recordMovieChange adds a change of a movie to the change log and publishes it on the
+// event bus, as part of the transaction that made it. The change is numbered once it
+// has been committed.
+func recordMovieChange(ctx context.Context, tx *sql.Tx, bus eventbus.Bus, changeType string, movieID int64, version int32) error {
+	query := `
+		INSERT INTO movie_changes (movie_id, type, version)
+		VALUES ($1, $2, $3)
+		RETURNING created_at`
+
+	change := MovieChange{MovieID: movieID, Type: changeType, Version: version}
+
+	err := tx.QueryRowContext(ctx, query, movieID, changeType, version).Scan(&change.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return bus.Publish(ctx, tx, TopicMovieChanged, change)
+}
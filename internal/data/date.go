@@ -0,0 +1,84 @@
+package data
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidDateFormat = errors.New("invalid date format, expected YYYY-MM-DD")
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day, written as "YYYY-MM-DD" in JSON and
+// stored as a nullable date column. The zero Date is stored as NULL.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date that t falls on.
+func NewDate(t time.Time) Date {
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Date) UnmarshalJSON(jsonValue []byte) error {
+	unquotedJSONValue, err := strconv.Unquote(string(jsonValue))
+	if err != nil {
+		return ErrInvalidDateFormat
+	}
+
+	t, err := time.Parse(dateLayout, unquotedJSONValue)
+	if err != nil {
+		return ErrInvalidDateFormat
+	}
+
+	*d = Date{t}
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+func (d *Date) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = NewDate(src)
+	default:
+		return errors.New("unsupported type for date")
+	}
+	return nil
+}
+
+// NullableDate is a date in the input of a partial update, which tells a date that was
+// left out from one that was set to null. Set is true when the field was present, and
+// Date is zero when it was null, which clears the date.
+type NullableDate struct {
+	Date Date
+	Set  bool
+}
+
+func (d *NullableDate) UnmarshalJSON(jsonValue []byte) error {
+	d.Set = true
+
+	if string(jsonValue) == "null" {
+		d.Date = Date{}
+		return nil
+	}
+
+	return d.Date.UnmarshalJSON(jsonValue)
+}
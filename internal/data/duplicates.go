@@ -0,0 +1,257 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// DuplicateRuntimeTolerance is how many minutes the runtimes of two movies with the
+// same title and year may differ while still being considered duplicates, since
+// catalogs often disagree on the runtime of a cut by a few minutes.
+const DuplicateRuntimeTolerance = 5
+
+// FindDuplicates returns the ids of the movies that are likely the same movie: their
+// titles match once reduced to lowercase letters and digits, they were released in the
+// same year, and their runtimes are within DuplicateRuntimeTolerance of each other.
+func (m MovieModel) FindDuplicates(movie *Movie) ([]int64, error) {
+	query := `
+		SELECT id
+		FROM movies
+		WHERE normalized_title = regexp_replace(lower($1), '[^[:alnum:]]+', '', 'g')
+		AND year = $2
+		AND abs(runtime - $3) <= $4
+		AND id <> $5
+		ORDER BY id`
+	args := []any{movie.Title, movie.Year, movie.Runtime, DuplicateRuntimeTolerance, movie.ID}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+
+	for rows.Next() {
+		var id int64
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetRedirect returns the id of the movie that the movie with the given id was merged
+// into.
+func (m MovieModel) GetRedirect(id int64) (int64, error) {
+	if id < 1 {
+		return 0, ErrRecordNotFound
+	}
+
+	query := `SELECT movie_id FROM movie_redirects WHERE old_id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var movieID int64
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(&movieID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, ErrRecordNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return movieID, nil
+}
+
+// Merge folds the duplicate into the canonical movie. The list items, alternate titles
+// and releases of the duplicate are moved to the canonical movie, external ids that
+// only the duplicate has are copied over, and the duplicate is replaced by a redirect
+// to the canonical movie. Both movies must still be at the versions that were read,
+// otherwise ErrEditConflict is returned.
+func (m MovieModel) Merge(duplicate, canonical *Movie) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the lists that hold the duplicate the same way list edits do, so that items
+	// aren't reordered while they are being moved.
+	query := `
+		SELECT id FROM lists
+		WHERE id IN (SELECT list_id FROM list_items WHERE movie_id = $1)
+		ORDER BY id
+		FOR UPDATE`
+
+	_, err = tx.ExecContext(ctx, query, duplicate.ID)
+	if err != nil {
+		return err
+	}
+
+	// Lists that already hold the canonical movie lose the duplicate, after which their
+	// positions are renumbered to close the gaps.
+	query = `
+		WITH removed AS (
+			DELETE FROM list_items
+			WHERE movie_id = $1
+			AND list_id IN (SELECT list_id FROM list_items WHERE movie_id = $2)
+			RETURNING list_id
+		)
+		UPDATE list_items SET position = ranked.position
+		FROM (
+			SELECT list_id, movie_id, row_number() OVER (PARTITION BY list_id ORDER BY position) AS position
+			FROM list_items
+			WHERE list_id IN (SELECT list_id FROM removed)
+			AND movie_id <> $1
+		) AS ranked
+		WHERE list_items.list_id = ranked.list_id
+		AND list_items.movie_id = ranked.movie_id`
+
+	_, err = tx.ExecContext(ctx, query, duplicate.ID, canonical.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `UPDATE list_items SET movie_id = $1 WHERE movie_id = $2`
+
+	_, err = tx.ExecContext(ctx, query, canonical.ID, duplicate.ID)
+	if err != nil {
+		return err
+	}
+
+	// Titles that the canonical movie already has are left behind and deleted together
+	// with the duplicate.
+	query = `
+		UPDATE movie_titles SET movie_id = $1
+		WHERE movie_id = $2
+		AND NOT EXISTS (
+			SELECT 1 FROM movie_titles AS existing
+			WHERE existing.movie_id = $1
+			AND existing.language = movie_titles.language
+			AND existing.region = movie_titles.region
+			AND existing.type = movie_titles.type
+			AND existing.title = movie_titles.title
+		)`
+
+	_, err = tx.ExecContext(ctx, query, canonical.ID, duplicate.ID)
+	if err != nil {
+		return err
+	}
+
+	// Releases in a country and of a type that the canonical movie already has are left
+	// behind as well.
+	query = `
+		UPDATE movie_releases SET movie_id = $1
+		WHERE movie_id = $2
+		AND NOT EXISTS (
+			SELECT 1 FROM movie_releases AS existing
+			WHERE existing.movie_id = $1
+			AND existing.country = movie_releases.country
+			AND existing.type = movie_releases.type
+		)`
+
+	_, err = tx.ExecContext(ctx, query, canonical.ID, duplicate.ID)
+	if err != nil {
+		return err
+	}
+
+	// Movies that were merged into the duplicate earlier now redirect to the canonical
+	// movie directly, so clients never have to follow a chain of redirects.
+	query = `UPDATE movie_redirects SET movie_id = $1 WHERE movie_id = $2`
+
+	_, err = tx.ExecContext(ctx, query, canonical.ID, duplicate.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO movie_redirects (old_id, movie_id) VALUES ($1, $2)`
+
+	_, err = tx.ExecContext(ctx, query, duplicate.ID, canonical.ID)
+	if err != nil {
+		return err
+	}
+
+	// The duplicate is deleted before the canonical movie is updated, to free up the
+	// external ids that are copied over.
+	query = `DELETE FROM movies WHERE id = $1 AND version = $2`
+
+	result, err := tx.ExecContext(ctx, query, duplicate.ID, duplicate.Version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrEditConflict
+	}
+
+	if canonical.ExternalIDs.IMDb == "" {
+		canonical.ExternalIDs.IMDb = duplicate.ExternalIDs.IMDb
+	}
+	if canonical.ExternalIDs.TMDb == 0 {
+		canonical.ExternalIDs.TMDb = duplicate.ExternalIDs.TMDb
+	}
+	if canonical.ExternalIDs.Wikidata == "" {
+		canonical.ExternalIDs.Wikidata = duplicate.ExternalIDs.Wikidata
+	}
+
+	query = `
+		UPDATE movies
+		SET imdb_id = $1, tmdb_id = $2, wikidata_id = $3, version = version + 1
+		WHERE id = $4 AND version = $5
+		RETURNING updated_at, version`
+	args := append(canonical.ExternalIDs.args(), canonical.ID, canonical.Version)
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&canonical.UpdatedAt, &canonical.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	err = recordMovieChange(ctx, tx, m.Events, ChangeDeleted, duplicate.ID, duplicate.Version)
+	if err != nil {
+		return err
+	}
+
+	err = recordMovieChange(ctx, tx, m.Events, ChangeUpdated, canonical.ID, canonical.Version)
+	if err != nil {
+		return err
+	}
+
+	err = enqueueWebhookEvent(ctx, tx, EventMovieDeleted, map[string]any{"movie": map[string]any{"id": duplicate.ID}, "merged_into": canonical.ID})
+	if err != nil {
+		return err
+	}
+
+	err = enqueueWebhookEvent(ctx, tx, EventMovieUpdated, map[string]any{"movie": canonical})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
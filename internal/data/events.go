@@ -0,0 +1,25 @@
+package data
+
+// Topics of the domain events that the models publish on the event bus, as part of
+// the transaction that caused them.
+const (
+	// TopicMovieChanged carries the MovieChange that was added to the change log.
+	TopicMovieChanged = "movie.changed"
+
+	// TopicPermissionsChanged carries a UserEvent for the user whose permissions
+	// changed.
+	TopicPermissionsChanged = "user.permissions_changed"
+
+	// TopicTokensRevoked carries a TokensRevokedEvent for the user whose tokens were
+	// deleted. Tokens of the scope may remain, see TokenModel.DeleteAllForUserExcept.
+	TopicTokensRevoked = "user.tokens_revoked"
+)
+
+type UserEvent struct {
+	UserID int64 `json:"user_id"`
+}
+
+type TokensRevokedEvent struct {
+	UserID int64  `json:"user_id"`
+	Scope  string `json:"scope"`
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+var (
+	ErrDuplicateIMDbID     = errors.New("duplicate imdb id")
+	ErrDuplicateTMDbID     = errors.New("duplicate tmdb id")
+	ErrDuplicateWikidataID = errors.New("duplicate wikidata id")
+
+	IMDbIDRX     = regexp.MustCompile(`^tt[0-9]{7,10}$`)
+	WikidataIDRX = regexp.MustCompile(`^Q[1-9][0-9]*$`)
+)
+
+// ExternalIDs holds the keys of a movie in other catalogs. Each of them is optional,
+// but unique across movies.
+type ExternalIDs struct {
+	IMDb     string `json:"imdb,omitzero"`
+	TMDb     int64  `json:"tmdb,omitzero"`
+	Wikidata string `json:"wikidata,omitzero"`
+}
+
+func ValidateExternalIDs(v *validator.Validator, ids ExternalIDs) {
+	v.Check(ids.IMDb == "" || validator.Matches(ids.IMDb, IMDbIDRX), "external_ids.imdb", "must be an IMDb title id, such as \"tt0068646\"")
+	v.Check(ids.TMDb >= 0, "external_ids.tmdb", "must be a positive integer")
+	v.Check(ids.Wikidata == "" || validator.Matches(ids.Wikidata, WikidataIDRX), "external_ids.wikidata", "must be a Wikidata item id, such as \"Q47703\"")
+}
+
+// args returns the ids as query arguments, with missing ids as NULL so that they don't
+// collide with each other on the unique constraints.
+func (ids ExternalIDs) args() []any {
+	args := []any{nil, nil, nil}
+	if ids.IMDb != "" {
+		args[0] = ids.IMDb
+	}
+	if ids.TMDb != 0 {
+		args[1] = ids.TMDb
+	}
+	if ids.Wikidata != "" {
+		args[2] = ids.Wikidata
+	}
+	return args
+}
+
+// externalIDError maps a unique constraint violation on one of the external id columns
+// to the matching error.
+func externalIDError(err error) error {
+	switch err.Error() {
+	case `pq: duplicate key value violates unique constraint "movies_imdb_id_key"`:
+		return ErrDuplicateIMDbID
+	case `pq: duplicate key value violates unique constraint "movies_tmdb_id_key"`:
+		return ErrDuplicateTMDbID
+	case `pq: duplicate key value violates unique constraint "movies_wikidata_id_key"`:
+		return ErrDuplicateWikidataID
+	default:
+		return err
+	}
+}
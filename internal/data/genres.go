@@ -0,0 +1,485 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ucok-man/gmoapi/internal/eventbus"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+var (
+	ErrDuplicateGenre = errors.New("duplicate genre")
+	ErrGenreInUse     = errors.New("genre in use")
+
+	// SlugRX matches lowercase, hyphen separated slugs such as "science-fiction".
+	SlugRX = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	nonAlphanumericRX = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type Genre struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"-"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	Version   int32     `json:"version"`
+}
+
+// GenreSlug normalises a free-text genre into slug form, so that values which only
+// differ in case, spacing or punctuation compare equal: "Sci-Fi", "sci fi" and
+// "SCI_FI" all become "sci-fi".
+func GenreSlug(value string) string {
+	return strings.Trim(nonAlphanumericRX.ReplaceAllString(strings.ToLower(value), "-"), "-")
+}
+
+func ValidateGenre(v *validator.Validator, genre *Genre) {
+	v.Check(genre.Slug != "", "slug", "must be provided")
+	v.Check(len(genre.Slug) <= 100, "slug", "must not be more than 100 bytes long")
+	v.Check(validator.Matches(genre.Slug, SlugRX), "slug", "must only contain lowercase letters, digits and single hyphens")
+	v.Check(genre.Name != "", "name", "must be provided")
+	v.Check(len(genre.Name) <= 100, "name", "must not be more than 100 bytes long")
+	v.Check(genre.Aliases != nil, "aliases", "must be provided")
+	v.Check(len(genre.Aliases) <= 20, "aliases", "must not contain more than 20 aliases")
+	v.Check(validator.Unique(genre.Aliases), "aliases", "must not contain duplicate values")
+
+	for _, alias := range genre.Aliases {
+		v.Check(GenreSlug(alias) != "", "aliases", "must not contain empty values")
+		v.Check(len(alias) <= 100, "aliases", "must not contain values more than 100 bytes long")
+	}
+}
+
+// GenreTaxonomy resolves free-text genres to the slug of the genre they refer to, by
+// slug, display name or alias.
+type GenreTaxonomy struct {
+	lookup map[string]string
+}
+
+func NewGenreTaxonomy(genres []*Genre) *GenreTaxonomy {
+	t := &GenreTaxonomy{lookup: make(map[string]string)}
+
+	// Aliases are added first so that, should an alias of one genre clash with the
+	// slug or name of another, the genre's own slug and name win.
+	for _, genre := range genres {
+		for _, alias := range genre.Aliases {
+			t.lookup[GenreSlug(alias)] = genre.Slug
+		}
+	}
+	for _, genre := range genres {
+		t.lookup[GenreSlug(genre.Name)] = genre.Slug
+		t.lookup[genre.Slug] = genre.Slug
+	}
+
+	return t
+}
+
+// Resolve returns the slug of the genre that the value refers to.
+func (t *GenreTaxonomy) Resolve(value string) (string, bool) {
+	slug, ok := t.lookup[GenreSlug(value)]
+	return slug, ok
+}
+
+// Conflicts returns the values (the slug, name and aliases of genre) that already
+// refer to a different genre in the taxonomy.
+func (t *GenreTaxonomy) Conflicts(genre *Genre) []string {
+	var conflicts []string
+
+	for _, value := range append([]string{genre.Slug, genre.Name}, genre.Aliases...) {
+		slug, ok := t.Resolve(value)
+		if ok && slug != genre.Slug {
+			conflicts = append(conflicts, value)
+		}
+	}
+
+	return conflicts
+}
+
+type GenreModel struct {
+	DB     *sql.DB
+	Events eventbus.Bus
+}
+
+func (m GenreModel) Insert(genre *Genre) error {
+	query := `
+		INSERT INTO genres (slug, name, aliases)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, version`
+	args := []any{genre.Slug, genre.Name, pq.Array(genre.Aliases)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&genre.ID, &genre.CreatedAt, &genre.Version)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "genres_slug_key"`:
+			return ErrDuplicateGenre
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (m GenreModel) Get(id int64) (*Genre, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT id, created_at, slug, name, aliases, version
+		FROM genres
+		WHERE id = $1`
+
+	var genre Genre
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(
+		&genre.ID,
+		&genre.CreatedAt,
+		&genre.Slug,
+		&genre.Name,
+		pq.Array(&genre.Aliases),
+		&genre.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &genre, nil
+}
+
+func (m GenreModel) GetAll() ([]*Genre, error) {
+	query := `
+		SELECT id, created_at, slug, name, aliases, version
+		FROM genres
+		ORDER BY slug ASC`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	genres := []*Genre{}
+
+	for rows.Next() {
+		var genre Genre
+		err := rows.Scan(
+			&genre.ID,
+			&genre.CreatedAt,
+			&genre.Slug,
+			&genre.Name,
+			pq.Array(&genre.Aliases),
+			&genre.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		genres = append(genres, &genre)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+// GetTaxonomy loads every genre into a GenreTaxonomy.
+func (m GenreModel) GetTaxonomy() (*GenreTaxonomy, error) {
+	genres, err := m.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return NewGenreTaxonomy(genres), nil
+}
+
+// Update saves the genre. When the slug changes, every movie tagged with the old slug
+// is retagged in the same transaction, and the old slug is kept as an alias so that
+// clients still using it continue to resolve to the genre.
+func (m GenreModel) Update(genre *Genre) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var oldSlug string
+	query := `SELECT slug FROM genres WHERE id = $1 AND version = $2 FOR UPDATE`
+
+	err = tx.QueryRowContext(ctx, query, genre.ID, genre.Version).Scan(&oldSlug)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	if oldSlug != genre.Slug {
+		err = retagMovies(ctx, tx, m.Events, oldSlug, genre.Slug)
+		if err != nil {
+			return err
+		}
+
+		if !containsGenreAlias(genre.Aliases, oldSlug) {
+			genre.Aliases = append(genre.Aliases, oldSlug)
+		}
+	}
+
+	query = `
+		UPDATE genres
+		SET slug = $1, name = $2, aliases = $3, version = version + 1
+		WHERE id = $4
+		RETURNING version`
+	args := []any{genre.Slug, genre.Name, pq.Array(genre.Aliases), genre.ID}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&genre.Version)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "genres_slug_key"`:
+			return ErrDuplicateGenre
+		default:
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes a genre, as long as no movie is tagged with it anymore.
+func (m GenreModel) Delete(id int64) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `
+		DELETE FROM genres
+		WHERE id = $1
+		AND NOT EXISTS (SELECT 1 FROM movies WHERE movies.genres @> ARRAY[genres.slug])
+		RETURNING slug`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var slug string
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(&slug)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		// Nothing was deleted, find out whether the genre doesn't exist or is in use.
+		_, err = m.Get(id)
+		if err != nil {
+			return err
+		}
+		return ErrGenreInUse
+	}
+
+	return nil
+}
+
+// Merge folds the source genre into the target genre: movies tagged with the source
+// are retagged with the target, the slug, name and aliases of the source become
+// aliases of the target, and the source is deleted.
+func (m GenreModel) Merge(source, target *Genre) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT id FROM genres WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+
+	_, err = tx.ExecContext(ctx, query, pq.Array([]int64{source.ID, target.ID}))
+	if err != nil {
+		return err
+	}
+
+	err = retagMovies(ctx, tx, m.Events, source.Slug, target.Slug)
+	if err != nil {
+		return err
+	}
+
+	for _, alias := range append([]string{source.Slug, source.Name}, source.Aliases...) {
+		if alias != target.Slug && !containsGenreAlias(target.Aliases, alias) {
+			target.Aliases = append(target.Aliases, alias)
+		}
+	}
+
+	query = `
+		UPDATE genres
+		SET aliases = $1, version = version + 1
+		WHERE id = $2 AND version = $3
+		RETURNING version`
+
+	err = tx.QueryRowContext(ctx, query, pq.Array(target.Aliases), target.ID, target.Version).Scan(&target.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	query = `DELETE FROM genres WHERE id = $1 AND version = $2`
+
+	result, err := tx.ExecContext(ctx, query, source.ID, source.Version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrEditConflict
+	}
+
+	return tx.Commit()
+}
+
+// retagMovies replaces the genre slug from with to on every movie tagged with it,
+// dropping the duplicate that arises when a movie was already tagged with both. Every
+// retagged movie is recorded in the change log, published on the event bus and sent to
+// the webhooks subscribed to movie updates.
+func retagMovies(ctx context.Context, tx *sql.Tx, bus eventbus.Bus, from, to string) error {
+	query := `
+		WITH retagged AS (
+			UPDATE movies SET genres = ARRAY(
+				SELECT g
+				FROM unnest(array_replace(genres, $1::text, $2::text)) WITH ORDINALITY AS u(g, i)
+				GROUP BY g
+				ORDER BY min(i)
+			), version = version + 1
+			WHERE genres @> ARRAY[$1::text]
+			RETURNING id, version
+		)
+		INSERT INTO movie_changes (movie_id, type, version)
+		SELECT id, $3, version FROM retagged ORDER BY id
+		RETURNING created_at, movie_id, type, version`
+
+	rows, err := tx.QueryContext(ctx, query, from, to, ChangeUpdated)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	changes := []MovieChange{}
+
+	for rows.Next() {
+		var change MovieChange
+		err := rows.Scan(
+			&change.CreatedAt,
+			&change.MovieID,
+			&change.Type,
+			&change.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// The connection of the transaction is busy until the rows are read, so the
+	// changes are published afterwards.
+	ids := make([]int64, len(changes))
+	for i, change := range changes {
+		err = bus.Publish(ctx, tx, TopicMovieChanged, change)
+		if err != nil {
+			return err
+		}
+		ids[i] = change.MovieID
+	}
+
+	movies, err := getMoviesTx(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		err = enqueueWebhookEvent(ctx, tx, EventMovieUpdated, map[string]any{"movie": movie})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getMoviesTx returns the movies with the ids as seen by tx, ordered by id.
+func getMoviesTx(ctx context.Context, tx *sql.Tx, ids []int64) ([]*Movie, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + movieColumns + `
+		FROM movies
+		WHERE id = ANY($1)
+		ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := []*Movie{}
+
+	for rows.Next() {
+		var movie Movie
+		err := rows.Scan(movie.scanDest()...)
+		if err != nil {
+			return nil, err
+		}
+		movies = append(movies, &movie)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+func containsGenreAlias(aliases []string, value string) bool {
+	for _, alias := range aliases {
+		if GenreSlug(alias) == GenreSlug(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// IdempotencyKey records a request that was sent with an Idempotency-Key header, and
+// the response to it once it has been handled.
+type IdempotencyKey struct {
+	// UserID is the user that sent the request, 0 for anonymous requests. Keys are
+	// only unique per user.
+	UserID int64
+	Key    string
+
+	// Fingerprint identifies the request, so that the key can't be reused for a
+	// different one.
+	Fingerprint string
+
+	// ResponseStatus is 0 while the request is being handled.
+	ResponseStatus  int
+	ResponseHeaders map[string][]string
+	ResponseBody    []byte
+}
+
+// Completed reports whether the response to the request has been stored.
+func (k *IdempotencyKey) Completed() bool {
+	return k.ResponseStatus != 0
+}
+
+func ValidateIdempotencyKey(v *validator.Validator, key string) {
+	v.Check(len(key) <= 255, "Idempotency-Key", "must not be more than 255 bytes long")
+}
+
+type IdempotencyKeyModel struct {
+	DB *sql.DB
+}
+
+// Claim stores the key for a request that is about to be handled and reports whether
+// it was claimed. The key is kept for ttl. A key that is already taken can only be
+// claimed again once it has expired, or by the same request once the lock of the
+// request that took it runs out, for example because the server stopped while handling
+// it.
+func (m IdempotencyKeyModel) Claim(key *IdempotencyKey, ttl, lock time.Duration) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, expires_at, locked_until, fingerprint)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3), NOW() + make_interval(secs => $4), $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET created_at = NOW(), expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until,
+			fingerprint = EXCLUDED.fingerprint, response_status = NULL, response_headers = NULL, response_body = NULL
+		WHERE idempotency_keys.expires_at <= NOW()
+		OR (idempotency_keys.response_status IS NULL
+			AND idempotency_keys.locked_until <= NOW()
+			AND idempotency_keys.fingerprint = EXCLUDED.fingerprint)`
+	args := []any{key.UserID, key.Key, ttl.Seconds(), lock.Seconds(), key.Fingerprint}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Get returns the key of the user unless it has expired.
+func (m IdempotencyKeyModel) Get(userID int64, key string) (*IdempotencyKey, error) {
+	query := `
+		SELECT user_id, key, fingerprint, response_status, response_headers, response_body
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND expires_at > NOW()`
+
+	var (
+		k       IdempotencyKey
+		status  sql.NullInt32
+		headers []byte
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, userID, key).Scan(
+		&k.UserID,
+		&k.Key,
+		&k.Fingerprint,
+		&status,
+		&headers,
+		&k.ResponseBody,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	k.ResponseStatus = int(status.Int32)
+
+	if headers != nil {
+		err = json.Unmarshal(headers, &k.ResponseHeaders)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &k, nil
+}
+
+// SaveResponse stores the response to the request of the key.
+func (m IdempotencyKeyModel) SaveResponse(key *IdempotencyKey) error {
+	headers, err := json.Marshal(key.ResponseHeaders)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE idempotency_keys
+		SET response_status = $1, response_headers = $2, response_body = $3
+		WHERE user_id = $4 AND key = $5 AND fingerprint = $6`
+	args := []any{key.ResponseStatus, headers, key.ResponseBody, key.UserID, key.Key, key.Fingerprint}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err = m.DB.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Release removes a key whose request didn't complete, so that it can be retried.
+func (m IdempotencyKeyModel) Release(key *IdempotencyKey) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND fingerprint = $3 AND response_status IS NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, query, key.UserID, key.Key, key.Fingerprint)
+	return err
+}
+
+// DeleteExpired removes the keys that have expired and returns how many there were.
+func (m IdempotencyKeyModel) DeleteExpired() (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= NOW()`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
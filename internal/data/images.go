@@ -0,0 +1,64 @@
+package data
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	ImagePoster   = "poster"
+	ImageBackdrop = "backdrop"
+)
+
+// ImageSizes maps the kind of a movie image to the sizes that are generated for it,
+// keyed by size name and holding the maximum width in pixels. A width of zero keeps
+// the original dimensions.
+var ImageSizes = map[string]map[string]int{
+	ImagePoster: {
+		"w185":     185,
+		"w342":     342,
+		"w500":     500,
+		"original": 0,
+	},
+	ImageBackdrop: {
+		"w300":     300,
+		"w780":     780,
+		"w1280":    1280,
+		"original": 0,
+	},
+}
+
+// ImageURLs holds the public URLs of the generated sizes of a movie image, keyed by
+// size name. It is stored as a jsonb column.
+type ImageURLs map[string]string
+
+func (u ImageURLs) Value() (driver.Value, error) {
+	if u == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(u)
+}
+
+func (u *ImageURLs) Scan(src any) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	var urls map[string]string
+	err := json.Unmarshal(b, &urls)
+	if err != nil {
+		return err
+	}
+
+	// Keep the zero value for movies without an image, so that the field is omitted
+	// from the JSON output.
+	if len(urls) == 0 {
+		*u = nil
+		return nil
+	}
+
+	*u = urls
+	return nil
+}
@@ -0,0 +1,490 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+const (
+	VisibilityPrivate  = "private"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPublic   = "public"
+)
+
+var ErrDuplicateListItem = errors.New("duplicate list item")
+
+type List struct {
+	ID          int64     `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UserID      int64     `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `json:"visibility"`
+
+	// ShareToken is only set for unlisted lists, and should only be shown to the
+	// owner of the list.
+	ShareToken string `json:"share_token,omitzero"`
+
+	Version int32 `json:"version"`
+}
+
+// VisibleTo reports whether the list can be read by the given user. Unlisted lists
+// are only visible to the owner or to whoever holds the share token.
+func (l *List) VisibleTo(user *User, shareToken string) bool {
+	if l.OwnedBy(user) {
+		return true
+	}
+
+	switch l.Visibility {
+	case VisibilityPublic:
+		return true
+	case VisibilityUnlisted:
+		return shareToken != "" && subtle.ConstantTimeCompare([]byte(shareToken), []byte(l.ShareToken)) == 1
+	default:
+		return false
+	}
+}
+
+// OwnedBy reports whether the list belongs to the given user.
+func (l *List) OwnedBy(user *User) bool {
+	return !user.IsAnonymous() && l.UserID == user.ID
+}
+
+// syncShareToken makes sure that unlisted lists always carry a share token and that
+// the token is discarded once the list is no longer unlisted.
+func (l *List) syncShareToken() {
+	switch {
+	case l.Visibility == VisibilityUnlisted && l.ShareToken == "":
+		l.ShareToken = rand.Text()
+	case l.Visibility != VisibilityUnlisted:
+		l.ShareToken = ""
+	}
+}
+
+type ListItem struct {
+	MovieID  int64     `json:"movie_id"`
+	Position int       `json:"position"`
+	Note     string    `json:"note"`
+	AddedAt  time.Time `json:"added_at"`
+	Movie    *Movie    `json:"movie,omitzero"`
+}
+
+func ValidateList(v *validator.Validator, list *List) {
+	v.Check(list.Name != "", "name", "must be provided")
+	v.Check(len(list.Name) <= 200, "name", "must not be more than 200 bytes long")
+	v.Check(len(list.Description) <= 2000, "description", "must not be more than 2000 bytes long")
+	v.Check(validator.PermittedValue(list.Visibility, VisibilityPrivate, VisibilityUnlisted, VisibilityPublic), "visibility", "must be one of private, unlisted or public")
+}
+
+func ValidateListItem(v *validator.Validator, item *ListItem) {
+	v.Check(item.MovieID > 0, "movie_id", "must be provided")
+	v.Check(item.Position >= 0, "position", "must not be negative")
+	v.Check(len(item.Note) <= 1000, "note", "must not be more than 1000 bytes long")
+}
+
+type ListModel struct {
+	DB *sql.DB
+}
+
+func (m ListModel) Insert(list *List) error {
+	list.syncShareToken()
+
+	query := `
+		INSERT INTO lists (user_id, name, description, visibility, share_token)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id, created_at, version`
+	args := []any{list.UserID, list.Name, list.Description, list.Visibility, list.ShareToken}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.DB.QueryRowContext(ctx, query, args...).Scan(&list.ID, &list.CreatedAt, &list.Version)
+}
+
+func (m ListModel) Get(id int64) (*List, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT id, created_at, user_id, name, description, visibility, COALESCE(share_token, ''), version
+		FROM lists
+		WHERE id = $1`
+
+	var list List
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(
+		&list.ID,
+		&list.CreatedAt,
+		&list.UserID,
+		&list.Name,
+		&list.Description,
+		&list.Visibility,
+		&list.ShareToken,
+		&list.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &list, nil
+}
+
+func (m ListModel) GetAllForUser(userID int64, filters Filter) ([]*List, Metadata, error) {
+	query := fmt.Sprintf(`
+		SELECT count(*) OVER(), id, created_at, user_id, name, description, visibility, COALESCE(share_token, ''), version
+		FROM lists
+		WHERE user_id = $1
+		ORDER BY %s %s, id ASC
+		LIMIT $2 OFFSET $3`, filters.sortColumn(), filters.sortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, userID, filters.limit(), filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	lists := []*List{}
+
+	for rows.Next() {
+		var list List
+		err := rows.Scan(
+			&totalRecords,
+			&list.ID,
+			&list.CreatedAt,
+			&list.UserID,
+			&list.Name,
+			&list.Description,
+			&list.Visibility,
+			&list.ShareToken,
+			&list.Version,
+		)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+
+		lists = append(lists, &list)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return lists, metadata, nil
+}
+
+func (m ListModel) Update(list *List) error {
+	list.syncShareToken()
+
+	query := `
+		UPDATE lists
+		SET name = $1, description = $2, visibility = $3, share_token = NULLIF($4, ''), version = version + 1
+		WHERE id = $5 AND version = $6
+		RETURNING version`
+	args := []any{
+		list.Name,
+		list.Description,
+		list.Visibility,
+		list.ShareToken,
+		list.ID,
+		list.Version,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&list.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (m ListModel) Delete(id int64) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `DELETE FROM lists WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (m ListModel) GetItems(listID int64) ([]*ListItem, error) {
+	query := `
+		SELECT list_items.movie_id, list_items.position, list_items.note, list_items.added_at, ` + movieColumns + `
+		FROM list_items
+		INNER JOIN movies ON movies.id = list_items.movie_id
+		WHERE list_items.list_id = $1
+		ORDER BY list_items.position ASC`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*ListItem{}
+
+	for rows.Next() {
+		var item ListItem
+		var movie Movie
+		err := rows.Scan(append([]any{&item.MovieID, &item.Position, &item.Note, &item.AddedAt}, movie.scanDest()...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Movie = &movie
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (m ListModel) GetItem(listID, movieID int64) (*ListItem, error) {
+	query := `
+		SELECT list_items.movie_id, list_items.position, list_items.note, list_items.added_at, ` + movieColumns + `
+		FROM list_items
+		INNER JOIN movies ON movies.id = list_items.movie_id
+		WHERE list_items.list_id = $1 AND list_items.movie_id = $2`
+
+	var item ListItem
+	var movie Movie
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, listID, movieID).Scan(append([]any{&item.MovieID, &item.Position, &item.Note, &item.AddedAt}, movie.scanDest()...)...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	item.Movie = &movie
+	return &item, nil
+}
+
+// AddItem inserts a movie into the list at the requested position, shifting the items
+// after it down by one. A zero position (or one past the end of the list) appends the
+// movie to the end of the list.
+func (m ListModel) AddItem(listID int64, item *ListItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	count, err := lockListItems(ctx, tx, listID)
+	if err != nil {
+		return err
+	}
+
+	if item.Position < 1 || item.Position > count+1 {
+		item.Position = count + 1
+	}
+
+	query := `
+		UPDATE list_items SET position = position + 1
+		WHERE list_id = $1 AND position >= $2`
+
+	_, err = tx.ExecContext(ctx, query, listID, item.Position)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO list_items (list_id, movie_id, position, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING added_at`
+
+	err = tx.QueryRowContext(ctx, query, listID, item.MovieID, item.Position, item.Note).Scan(&item.AddedAt)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "list_items_pkey"`:
+			return ErrDuplicateListItem
+		default:
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateItem changes the note of an item and moves it to a new position, shifting
+// the items in between. Positions outside the list are clamped to its ends.
+func (m ListModel) UpdateItem(listID int64, item *ListItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	count, err := lockListItems(ctx, tx, listID)
+	if err != nil {
+		return err
+	}
+
+	var current int
+	query := `SELECT position FROM list_items WHERE list_id = $1 AND movie_id = $2`
+
+	err = tx.QueryRowContext(ctx, query, listID, item.MovieID).Scan(&current)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	item.Position = max(1, min(item.Position, count))
+
+	switch {
+	case item.Position < current:
+		query = `
+			UPDATE list_items SET position = position + 1
+			WHERE list_id = $1 AND position >= $2 AND position < $3`
+		_, err = tx.ExecContext(ctx, query, listID, item.Position, current)
+	case item.Position > current:
+		query = `
+			UPDATE list_items SET position = position - 1
+			WHERE list_id = $1 AND position > $2 AND position <= $3`
+		_, err = tx.ExecContext(ctx, query, listID, current, item.Position)
+	}
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE list_items SET position = $1, note = $2
+		WHERE list_id = $3 AND movie_id = $4
+		RETURNING added_at`
+
+	err = tx.QueryRowContext(ctx, query, item.Position, item.Note, listID, item.MovieID).Scan(&item.AddedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveItem deletes a movie from the list and closes the gap it leaves behind.
+func (m ListModel) RemoveItem(listID, movieID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = lockListItems(ctx, tx, listID)
+	if err != nil {
+		return err
+	}
+
+	var position int
+	query := `DELETE FROM list_items WHERE list_id = $1 AND movie_id = $2 RETURNING position`
+
+	err = tx.QueryRowContext(ctx, query, listID, movieID).Scan(&position)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	query = `
+		UPDATE list_items SET position = position - 1
+		WHERE list_id = $1 AND position > $2`
+
+	_, err = tx.ExecContext(ctx, query, listID, position)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockListItems locks the parent list row so that concurrent reorderings of the same
+// list are serialized, and returns the current number of items in the list.
+func lockListItems(ctx context.Context, tx *sql.Tx, listID int64) (int, error) {
+	var count int
+
+	query := `SELECT id FROM lists WHERE id = $1 FOR UPDATE`
+
+	err := tx.QueryRowContext(ctx, query, listID).Scan(&listID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, ErrRecordNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	query = `SELECT count(*) FROM list_items WHERE list_id = $1`
+
+	err = tx.QueryRowContext(ctx, query, listID).Scan(&count)
+	return count, err
+}
@@ -3,6 +3,8 @@ package data
 import (
 	"database/sql"
 	"errors"
+
+	"github.com/ucok-man/gmoapi/internal/eventbus"
 )
 
 var (
@@ -11,17 +13,34 @@ var (
 )
 
 type Models struct {
-	Movies      MovieModel
-	Permissions PermissionModel
-	Tokens      TokenModel
-	Users       UserModel
+	Genres            GenreModel
+	IdempotencyKeys   IdempotencyKeyModel
+	Lists             ListModel
+	MovieChanges      MovieChangeModel
+	MovieReleases     MovieReleaseModel
+	MovieTitles       MovieTitleModel
+	Movies            MovieModel
+	Permissions       PermissionModel
+	Tokens            TokenModel
+	Users             UserModel
+	WebhookDeliveries WebhookDeliveryModel
+	Webhooks          WebhookModel
 }
 
-func NewModels(db *sql.DB) Models {
+// NewModels returns the models for db. The models publish their domain events on bus.
+func NewModels(db *sql.DB, bus eventbus.Bus) Models {
 	return Models{
-		Movies:      MovieModel{DB: db},
-		Permissions: PermissionModel{DB: db},
-		Tokens:      TokenModel{DB: db},
-		Users:       UserModel{DB: db},
+		Genres:            GenreModel{DB: db, Events: bus},
+		IdempotencyKeys:   IdempotencyKeyModel{DB: db},
+		Lists:             ListModel{DB: db},
+		MovieChanges:      MovieChangeModel{DB: db},
+		MovieReleases:     MovieReleaseModel{DB: db},
+		MovieTitles:       MovieTitleModel{DB: db},
+		Movies:            MovieModel{DB: db, Events: bus},
+		Permissions:       PermissionModel{DB: db, Events: bus},
+		Tokens:            TokenModel{DB: db, Events: bus},
+		Users:             UserModel{DB: db},
+		WebhookDeliveries: WebhookDeliveryModel{DB: db},
+		Webhooks:          WebhookModel{DB: db},
 	}
 }
@@ -3,73 +3,314 @@ package data
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/ucok-man/gmoapi/internal/eventbus"
 	"github.com/ucok-man/gmoapi/internal/validator"
 )
 
+const (
+	StatusAnnounced    = "announced"
+	StatusInProduction = "in_production"
+	StatusReleased     = "released"
+)
+
+// MaxAnnouncedYears is how many years ahead of the current year a movie that hasn't
+// been released yet may be catalogued.
+const MaxAnnouncedYears = 10
+
 type Movie struct {
 	ID        int64     `json:"id"`
-	CreatedAt time.Time `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Title string `json:"title"`
+
+	// DisplayTitle is the title that best suits the client's preferred languages. It
+	// isn't stored, but filled in from the alternate titles of the movie.
+	DisplayTitle string `json:"display_title,omitzero"`
+
+	// Similarity is the score of the movie when it is listed as similar to another
+	// movie, between 0 and 1. It is only set in those listings.
+	Similarity float64 `json:"similarity,omitzero"`
 
-	Title   string   `json:"title"`
 	Year    int32    `json:"year,omitzero"`
 	Runtime Runtime  `json:"runtime,omitzero"`
 	Genres  []string `json:"genres,omitzero"`
 
+	Status      string `json:"status,omitzero"`
+	ReleaseDate Date   `json:"release_date,omitzero"`
+
+	ExternalIDs ExternalIDs `json:"external_ids,omitzero"`
+
+	PosterURLs   ImageURLs `json:"poster_urls,omitzero"`
+	BackdropURLs ImageURLs `json:"backdrop_urls,omitzero"`
+
 	// The version number starts at 1 and will be incremented
 	// each time the movie information is updated
 	Version int32 `json:"version"`
+
+	// RuntimeFormat selects how the runtime is written in JSON, so that clients can
+	// ask for the format they prefer. The zero value writes RuntimeMins.
+	RuntimeFormat RuntimeFormat `json:"-"`
+}
+
+func (movie Movie) MarshalJSON() ([]byte, error) {
+	// The alias type has the fields of Movie but not this method, which would recurse.
+	type movieJSON Movie
+
+	var runtime json.RawMessage
+	if movie.Runtime != 0 {
+		var err error
+		runtime, err = movie.Runtime.MarshalJSONFormat(movie.RuntimeFormat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The runtime field is shallower than the one of the embedded movie, so it takes
+	// its place in the output.
+	return json.Marshal(struct {
+		movieJSON
+		Runtime json.RawMessage `json:"runtime,omitzero"`
+	}{movieJSON(movie), runtime})
 }
 
-func ValidateMovie(v *validator.Validator, movie *Movie) {
+// movieColumns lists the movie columns read by the queries in this package. The order
+// must match the destinations returned by Movie.scanDest.
+const movieColumns = `movies.id, movies.created_at, movies.updated_at, movies.title, movies.year, movies.runtime, movies.genres,
+	COALESCE(movies.imdb_id, ''), COALESCE(movies.tmdb_id, 0), COALESCE(movies.wikidata_id, ''),
+	movies.status, movies.release_date, movies.poster_urls, movies.backdrop_urls, movies.version`
+
+// scanDest returns the scan destinations for the columns in movieColumns.
+func (movie *Movie) scanDest() []any {
+	return []any{
+		&movie.ID,
+		&movie.CreatedAt,
+		&movie.UpdatedAt,
+		&movie.Title,
+		&movie.Year,
+		&movie.Runtime,
+		pq.Array(&movie.Genres),
+		&movie.ExternalIDs.IMDb,
+		&movie.ExternalIDs.TMDb,
+		&movie.ExternalIDs.Wikidata,
+		&movie.Status,
+		&movie.ReleaseDate,
+		&movie.PosterURLs,
+		&movie.BackdropURLs,
+		&movie.Version,
+	}
+}
+
+// ValidateMovie checks the movie, and normalises its genres to the slugs of the genres
+// in the taxonomy that they refer to. A missing year is taken from the release date.
+//
+// Released movies must lie in the past and have a runtime, while announced movies and
+// movies in production may be dated up to MaxAnnouncedYears ahead and may not have a
+// known runtime yet.
+func ValidateMovie(v *validator.Validator, movie *Movie, taxonomy *GenreTaxonomy) {
+	if movie.Year == 0 && !movie.ReleaseDate.IsZero() {
+		movie.Year = int32(movie.ReleaseDate.Year())
+	}
+
+	now := time.Now()
+	released := movie.Status == StatusReleased
+
 	v.Check(movie.Title != "", "title", "must be provided")
 	v.Check(len(movie.Title) <= 500, "title", "must not be more than 500 bytes long")
+	v.Check(validator.PermittedValue(movie.Status, StatusAnnounced, StatusInProduction, StatusReleased), "status", "must be one of announced, in_production or released")
 	v.Check(movie.Year != 0, "year", "must be provided")
 	v.Check(movie.Year >= 1888, "year", "must be greater than 1888")
-	v.Check(movie.Year <= int32(time.Now().Year()), "year", "must not be in the future")
-	v.Check(movie.Runtime != 0, "runtime", "must be provided")
-	v.Check(movie.Runtime > 0, "runtime", "must be a positive integer")
+	if released {
+		v.Check(movie.Year <= int32(now.Year()), "year", "must not be in the future for released movies")
+	} else {
+		v.Check(movie.Year <= int32(now.Year()+MaxAnnouncedYears), "year", fmt.Sprintf("must not be more than %d years in the future", MaxAnnouncedYears))
+	}
+	if !movie.ReleaseDate.IsZero() {
+		v.Check(movie.ReleaseDate.Year() == int(movie.Year), "release_date", "must be in the same year as the movie")
+		v.Check(!released || !movie.ReleaseDate.After(now), "release_date", "must not be in the future for released movies")
+	}
+	v.Check(!released || movie.Runtime != 0, "runtime", "must be provided")
+	v.Check(movie.Runtime >= 0, "runtime", "must be a positive integer")
 	v.Check(movie.Genres != nil, "genres", "must be provided")
 	v.Check(len(movie.Genres) >= 1, "genres", "must contain at least 1 genre")
 	v.Check(len(movie.Genres) <= 5, "genres", "must not contain more than 5 genres")
+
+	for i, genre := range movie.Genres {
+		slug, ok := taxonomy.Resolve(genre)
+		if !ok {
+			v.AddError("genres", fmt.Sprintf("must not contain unknown genre %q", genre))
+			continue
+		}
+		movie.Genres[i] = slug
+	}
+
 	v.Check(validator.Unique(movie.Genres), "genres", "must not contain duplicate values")
+
+	ValidateExternalIDs(v, movie.ExternalIDs)
 }
 
 // Define a MovieModel struct type which wraps a sql.DB connection pool.
 type MovieModel struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Events eventbus.Bus
 }
 
 func (m MovieModel) Insert(movie *Movie) error {
-	query := `
-        INSERT INTO movies (title, year, runtime, genres)
-        VALUES ($1, $2, $3, $4)
-        RETURNING id, created_at, version`
-	args := []any{movie.Title, movie.Year, movie.Runtime, pq.Array(movie.Genres)}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	return m.DB.QueryRowContext(ctx, query, args...).Scan(&movie.ID, &movie.CreatedAt, &movie.Version)
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = insertMovie(ctx, tx, m.Events, movie)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// InsertMany inserts the movies in a single transaction, so that either all of them are
+// stored or none is. Movies whose external ids are taken, by a movie in the catalog or
+// earlier in the batch, are left out instead of failing the batch, and returned in
+// skipped by their index with the error.
+func (m MovieModel) InsertMany(movies []*Movie) (skipped map[int]error, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	skipped = make(map[int]error)
+
+	for i, movie := range movies {
+		// A failed statement aborts the transaction, the savepoint lets it go on without
+		// the movie.
+		_, err = tx.ExecContext(ctx, `SAVEPOINT insert_movie`)
+		if err != nil {
+			return nil, err
+		}
+
+		insertErr := insertMovie(ctx, tx, m.Events, movie)
+		switch {
+		case errors.Is(insertErr, ErrDuplicateIMDbID), errors.Is(insertErr, ErrDuplicateTMDbID), errors.Is(insertErr, ErrDuplicateWikidataID):
+			_, err = tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT insert_movie`)
+			if err != nil {
+				return nil, err
+			}
+			skipped[i] = insertErr
+		case insertErr != nil:
+			return nil, fmt.Errorf("movie %d (%q): %w", i, movie.Title, insertErr)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return skipped, nil
+}
+
+// insertMovie inserts the movie as part of tx, and records the change.
+func insertMovie(ctx context.Context, tx *sql.Tx, events eventbus.Bus, movie *Movie) error {
+	query := `
+        INSERT INTO movies (title, year, runtime, genres, status, release_date, imdb_id, tmdb_id, wikidata_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at, updated_at, version`
+	args := []any{movie.Title, movie.Year, movie.Runtime, pq.Array(movie.Genres), movie.Status, movie.ReleaseDate}
+	args = append(args, movie.ExternalIDs.args()...)
+
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&movie.ID, &movie.CreatedAt, &movie.UpdatedAt, &movie.Version)
+	if err != nil {
+		return externalIDError(err)
+	}
+
+	err = recordMovieChange(ctx, tx, events, ChangeCreated, movie.ID, movie.Version)
+	if err != nil {
+		return err
+	}
+
+	return enqueueWebhookEvent(ctx, tx, EventMovieCreated, map[string]any{"movie": movie})
+}
+
+// MovieSearch holds the criteria that GetAll filters movies by. Zero values match every
+// movie.
+type MovieSearch struct {
+	Title       string
+	Genres      []string
+	Statuses    []string
+	ExternalIDs ExternalIDs
+
+	// UpdatedSince only matches movies that were changed at or after it, for clients
+	// that sync incrementally.
+	UpdatedSince time.Time
+
+	// When CertificationCountry is set, only movies that were certified in that country
+	// are matched, and all of their certifications there must be in Certifications.
+	CertificationCountry string
+	Certifications       []string
 }
 
-func (m MovieModel) GetAll(title string, genres []string, filters Filter) ([]*Movie, Metadata, error) {
+func (m MovieModel) GetAll(search MovieSearch, filters Filter) ([]*Movie, Metadata, error) {
 	query := fmt.Sprintf(`
-        SELECT count(*) OVER(), id, created_at, title, year, runtime, genres, version
+        SELECT count(*) OVER(), %s
         FROM movies
-        WHERE (to_tsvector('simple', title) @@ plainto_tsquery('simple', $1) OR $1 = '')
+        WHERE (
+            to_tsvector('simple', title) @@ plainto_tsquery('simple', $1)
+            OR EXISTS (
+                SELECT 1 FROM movie_titles
+                WHERE movie_titles.movie_id = movies.id
+                AND to_tsvector('simple', movie_titles.title) @@ plainto_tsquery('simple', $1)
+            )
+            OR $1 = ''
+        )
         AND (genres @> $2 OR $2 = '{}')
-        ORDER BY %s %s, id ASC
-        LIMIT $3 OFFSET $4`, filters.sortColumn(), filters.sortDirection())
+        AND (status = ANY($3) OR $3 = '{}')
+        AND (imdb_id = $4 OR $4 IS NULL)
+        AND (tmdb_id = $5 OR $5 IS NULL)
+        AND (wikidata_id = $6 OR $6 IS NULL)
+        AND ($7 = '' OR (
+            EXISTS (
+                SELECT 1 FROM movie_releases
+                WHERE movie_releases.movie_id = movies.id
+                AND movie_releases.country = $7 AND movie_releases.certification <> ''
+            )
+            AND NOT EXISTS (
+                SELECT 1 FROM movie_releases
+                WHERE movie_releases.movie_id = movies.id
+                AND movie_releases.country = $7 AND movie_releases.certification <> ''
+                AND NOT (movie_releases.certification = ANY($8))
+            )
+        ))
+        AND (updated_at >= $9 OR $9 IS NULL)
+        ORDER BY %s %s NULLS LAST, id ASC
+        LIMIT $10 OFFSET $11`, movieColumns, filters.sortColumn(), filters.sortDirection())
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	args := []any{title, pq.Array(genres), filters.limit(), filters.offset()}
+	args := []any{search.Title, pq.Array(search.Genres), pq.Array(search.Statuses)}
+	args = append(args, search.ExternalIDs.args()...)
+	args = append(args, search.CertificationCountry, pq.Array(search.Certifications))
+
+	var updatedSince any
+	if !search.UpdatedSince.IsZero() {
+		updatedSince = search.UpdatedSince
+	}
+	args = append(args, updatedSince, filters.limit(), filters.offset())
 
 	rows, err := m.DB.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -83,16 +324,8 @@ func (m MovieModel) GetAll(title string, genres []string, filters Filter) ([]*Mo
 
 	for rows.Next() {
 		var movie Movie
-		err := rows.Scan(
-			&totalRecords, // ambil nilai count dari window function
-			&movie.ID,
-			&movie.CreatedAt,
-			&movie.Title,
-			&movie.Year,
-			&movie.Runtime,
-			pq.Array(&movie.Genres),
-			&movie.Version,
-		)
+		// ambil nilai count dari window function, lalu kolom movie
+		err := rows.Scan(append([]any{&totalRecords}, movie.scanDest()...)...)
 		if err != nil {
 			return nil, Metadata{}, err
 		}
@@ -117,7 +350,7 @@ func (m MovieModel) Get(id int64) (*Movie, error) {
 	}
 
 	query := `
-		SELECT id, created_at, title, year, runtime, genres, version
+		SELECT ` + movieColumns + `
 		FROM movies
 		WHERE id = $1`
 
@@ -126,15 +359,7 @@ func (m MovieModel) Get(id int64) (*Movie, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := m.DB.QueryRowContext(ctx, query, id).Scan(
-		&movie.ID,
-		&movie.CreatedAt,
-		&movie.Title,
-		&movie.Year,
-		&movie.Runtime,
-		pq.Array(&movie.Genres),
-		&movie.Version,
-	)
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(movie.scanDest()...)
 
 	if err != nil {
 		switch {
@@ -148,56 +373,127 @@ func (m MovieModel) Get(id int64) (*Movie, error) {
 	return &movie, nil
 }
 
+// GetByExternalIDs returns the movie matching every external id that is set in ids.
+func (m MovieModel) GetByExternalIDs(ids ExternalIDs) (*Movie, error) {
+	if ids == (ExternalIDs{}) {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT ` + movieColumns + `
+		FROM movies
+		WHERE (imdb_id = $1 OR $1 IS NULL)
+		AND (tmdb_id = $2 OR $2 IS NULL)
+		AND (wikidata_id = $3 OR $3 IS NULL)`
+
+	var movie Movie
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, ids.args()...).Scan(movie.scanDest()...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &movie, nil
+}
+
 func (m MovieModel) Update(movie *Movie) error {
 	query := `
         UPDATE movies
-        SET title = $1, year = $2, runtime = $3, genres = $4, version = version + 1
-        WHERE id = $5 AND version = $6
-        RETURNING version`
+        SET title = $1, year = $2, runtime = $3, genres = $4, status = $5, release_date = $6,
+            poster_urls = $7, backdrop_urls = $8, imdb_id = $9, tmdb_id = $10, wikidata_id = $11,
+            version = version + 1
+        WHERE id = $12 AND version = $13
+        RETURNING updated_at, version`
 
 	args := []any{
 		movie.Title,
 		movie.Year,
 		movie.Runtime,
 		pq.Array(movie.Genres),
-		movie.ID,
-		movie.Version,
+		movie.Status,
+		movie.ReleaseDate,
+		movie.PosterURLs,
+		movie.BackdropURLs,
 	}
+	args = append(args, movie.ExternalIDs.args()...)
+	args = append(args, movie.ID, movie.Version)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&movie.Version)
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&movie.UpdatedAt, &movie.Version)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
 			return ErrEditConflict
 		default:
-			return err
+			return externalIDError(err)
 		}
 	}
-	return nil
+
+	err = recordMovieChange(ctx, tx, m.Events, ChangeUpdated, movie.ID, movie.Version)
+	if err != nil {
+		return err
+	}
+
+	err = enqueueWebhookEvent(ctx, tx, EventMovieUpdated, map[string]any{"movie": movie})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (m MovieModel) Delete(id int64) error {
 	if id < 1 {
 		return ErrRecordNotFound
 	}
-	query := `DELETE FROM movies WHERE id = $1`
+	query := `DELETE FROM movies WHERE id = $1 RETURNING version`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := m.DB.ExecContext(ctx, query, id)
+	tx, err := m.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+	defer tx.Rollback()
+
+	var version int32
+
+	err = tx.QueryRowContext(ctx, query, id).Scan(&version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	err = recordMovieChange(ctx, tx, m.Events, ChangeDeleted, id, version)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
+
+	err = enqueueWebhookEvent(ctx, tx, EventMovieDeleted, map[string]any{"movie": map[string]any{"id": id}})
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return tx.Commit()
 }
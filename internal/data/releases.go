@@ -0,0 +1,187 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+const (
+	ReleaseTheatrical = "theatrical"
+	ReleaseDigital    = "digital"
+	ReleasePhysical   = "physical"
+)
+
+var ErrDuplicateMovieRelease = errors.New("duplicate movie release")
+
+// CertificationSystems lists the age certifications of each supported country, keyed
+// by ISO 3166-1 alpha-2 code and ordered from the least to the most restrictive.
+// Releases in other countries may carry any certification, but can't be filtered by
+// certification.
+var CertificationSystems = map[string][]string{
+	// MPAA
+	"US": {"G", "PG", "PG-13", "R", "NC-17"},
+	// BBFC
+	"GB": {"U", "PG", "12A", "12", "15", "18", "R18"},
+	// FSK
+	"DE": {"0", "6", "12", "16", "18"},
+	// CNC
+	"FR": {"U", "10", "12", "16", "18"},
+	// Eirin
+	"JP": {"G", "PG12", "R15+", "R18+"},
+	// ACB
+	"AU": {"G", "PG", "M", "MA15+", "R18+", "X18+"},
+}
+
+// CertificationsUpTo returns the certifications of the country's system that are no
+// more restrictive than maximum. It reports false when the country has no known system
+// or maximum isn't part of it.
+func CertificationsUpTo(country, maximum string) ([]string, bool) {
+	system, ok := CertificationSystems[country]
+	if !ok {
+		return nil, false
+	}
+
+	i := slices.Index(system, maximum)
+	if i < 0 {
+		return nil, false
+	}
+
+	return system[:i+1], true
+}
+
+// MovieRelease is the release of a movie in a country, along with the age
+// certification it received there.
+type MovieRelease struct {
+	ID            int64     `json:"id"`
+	CreatedAt     time.Time `json:"-"`
+	MovieID       int64     `json:"movie_id"`
+	Country       string    `json:"country"`
+	Type          string    `json:"type"`
+	ReleaseDate   Date      `json:"release_date"`
+	Certification string    `json:"certification,omitzero"`
+}
+
+func ValidateMovieRelease(v *validator.Validator, release *MovieRelease) {
+	v.Check(validator.Matches(release.Country, RegionRX), "country", "must be an uppercase ISO 3166-1 alpha-2 code, such as \"US\"")
+	v.Check(validator.PermittedValue(release.Type, ReleaseTheatrical, ReleaseDigital, ReleasePhysical), "type", "must be one of theatrical, digital or physical")
+	v.Check(!release.ReleaseDate.IsZero(), "release_date", "must be provided")
+	v.Check(len(release.Certification) <= 20, "certification", "must not be more than 20 bytes long")
+
+	if system, ok := CertificationSystems[release.Country]; ok && release.Certification != "" {
+		v.Check(slices.Contains(system, release.Certification), "certification", "must be a certification of the country's rating system")
+	}
+}
+
+type MovieReleaseModel struct {
+	DB *sql.DB
+}
+
+func (m MovieReleaseModel) Insert(release *MovieRelease) error {
+	query := `
+		INSERT INTO movie_releases (movie_id, country, type, release_date, certification)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+	args := []any{release.MovieID, release.Country, release.Type, release.ReleaseDate, release.Certification}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&release.ID, &release.CreatedAt)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "movie_releases_unique_idx"`:
+			return ErrDuplicateMovieRelease
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAllForMovie returns the releases of a movie, ordered by country and date.
+func (m MovieReleaseModel) GetAllForMovie(movieID int64) ([]*MovieRelease, error) {
+	releases, err := m.GetAllForMovies([]int64{movieID})
+	if err != nil {
+		return nil, err
+	}
+
+	if releases[movieID] == nil {
+		return []*MovieRelease{}, nil
+	}
+	return releases[movieID], nil
+}
+
+// GetAllForMovies returns the releases of several movies at once, keyed by movie ID.
+func (m MovieReleaseModel) GetAllForMovies(movieIDs []int64) (map[int64][]*MovieRelease, error) {
+	query := `
+		SELECT id, created_at, movie_id, country, type, release_date, certification
+		FROM movie_releases
+		WHERE movie_id = ANY($1)
+		ORDER BY movie_id, country, release_date, id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(movieIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	releases := make(map[int64][]*MovieRelease)
+
+	for rows.Next() {
+		var release MovieRelease
+		err := rows.Scan(
+			&release.ID,
+			&release.CreatedAt,
+			&release.MovieID,
+			&release.Country,
+			&release.Type,
+			&release.ReleaseDate,
+			&release.Certification,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		releases[release.MovieID] = append(releases[release.MovieID], &release)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// Delete removes a release, as long as it belongs to the given movie.
+func (m MovieReleaseModel) Delete(movieID, id int64) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `DELETE FROM movie_releases WHERE id = $1 AND movie_id = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, id, movieID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
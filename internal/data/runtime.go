@@ -3,52 +3,175 @@ package data
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
 var ErrInvalidRuntimeFormat = errors.New("invalid runtime format")
 
+// MaxRuntime is the longest runtime accepted, in minutes. The longest films ever
+// released run for several hundred hours, so this leaves plenty of room.
+const MaxRuntime = 60000
+
+// RuntimeFormat selects how a Runtime is written in JSON.
+type RuntimeFormat string
+
+const (
+	// RuntimeMins writes "135 mins", and is the default.
+	RuntimeMins RuntimeFormat = "mins"
+	// RuntimeMinutesInt writes the plain number of minutes, 135.
+	RuntimeMinutesInt RuntimeFormat = "minutes_int"
+	// RuntimeISO8601 writes an ISO 8601 duration, "PT2H15M".
+	RuntimeISO8601 RuntimeFormat = "iso8601"
+	// RuntimeHuman writes hours and minutes, "2h 15m".
+	RuntimeHuman RuntimeFormat = "human"
+)
+
+// RuntimeFormats lists the supported runtime formats.
+var RuntimeFormats = []RuntimeFormat{RuntimeMins, RuntimeMinutesInt, RuntimeISO8601, RuntimeHuman}
+
+var (
+	runtimeMinsRX  = regexp.MustCompile(`^(\d+) ?mins?$`)
+	runtimeHumanRX = regexp.MustCompile(`^(?:(\d+) ?h)? ?(?:(\d+) ?m)?$`)
+	runtimeISORX   = regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+)
+
 // Declare a custom Runtime type, yang menggunakan underlying type int32
 type Runtime int32
 
 // Implement a MarshalJSON() method pada Runtime type
 // Method ini akan membuat output string JSON dengan format "<runtime> mins"
 func (r Runtime) MarshalJSON() ([]byte, error) {
-	// Buat string runtime dengan format yang diinginkan
-	jsonValue := fmt.Sprintf("%d mins", r)
+	return r.MarshalJSONFormat(RuntimeMins)
+}
 
-	// Gunakan strconv.Quote() untuk membungkus string dalam tanda kutip
-	quotedJSONValue := strconv.Quote(jsonValue)
+// MarshalJSONFormat writes the runtime in the given format. Unknown formats fall back
+// to RuntimeMins.
+func (r Runtime) MarshalJSONFormat(format RuntimeFormat) ([]byte, error) {
+	hours, minutes := r/60, r%60
 
-	// Convert string ke byte slice dan return
-	return []byte(quotedJSONValue), nil
+	var jsonValue string
+
+	switch format {
+	case RuntimeMinutesInt:
+		return []byte(strconv.Itoa(int(r))), nil
+	case RuntimeISO8601:
+		switch {
+		case hours == 0:
+			jsonValue = fmt.Sprintf("PT%dM", minutes)
+		case minutes == 0:
+			jsonValue = fmt.Sprintf("PT%dH", hours)
+		default:
+			jsonValue = fmt.Sprintf("PT%dH%dM", hours, minutes)
+		}
+	case RuntimeHuman:
+		switch {
+		case hours == 0:
+			jsonValue = fmt.Sprintf("%dm", minutes)
+		case minutes == 0:
+			jsonValue = fmt.Sprintf("%dh", hours)
+		default:
+			jsonValue = fmt.Sprintf("%dh %dm", hours, minutes)
+		}
+	default:
+		jsonValue = fmt.Sprintf("%d mins", r)
+	}
+
+	// Gunakan strconv.Quote() untuk membungkus string dalam tanda kutip
+	return []byte(strconv.Quote(jsonValue)), nil
 }
 
-// Implementasi UnmarshalJSON agar Runtime bisa diparse dari string "<runtime> mins"
+// UnmarshalJSON accepts a runtime in any of these forms:
+//
+//   - a JSON integer number of minutes: 135
+//   - "<n> mins": "135 mins"
+//   - hours and/or minutes: "2h 15m", "2h", "45m"
+//   - an ISO 8601 duration in whole minutes: "PT135M", "PT2H15M"
 func (r *Runtime) UnmarshalJSON(jsonValue []byte) error {
-	// Hilangkan tanda kutip (" ") dari JSON string
-	unquotedJSONValue, err := strconv.Unquote(string(jsonValue))
-	if err != nil {
-		return ErrInvalidRuntimeFormat
+	// By convention a JSON null leaves the value unchanged.
+	if string(jsonValue) == "null" {
+		return nil
 	}
 
-	// Pisahkan string jadi bagian angka dan kata "mins"
-	parts := strings.Split(unquotedJSONValue, " ")
+	var s string
 
-	// Validasi format, harus tepat 2 bagian: "<angka> mins"
-	if len(parts) != 2 || parts[1] != "mins" {
-		return ErrInvalidRuntimeFormat
+	if len(jsonValue) > 0 && jsonValue[0] == '"' {
+		// Hilangkan tanda kutip (" ") dari JSON string
+		unquotedJSONValue, err := strconv.Unquote(string(jsonValue))
+		if err != nil {
+			return ErrInvalidRuntimeFormat
+		}
+		s = strings.TrimSpace(unquotedJSONValue)
+	} else {
+		s = string(jsonValue)
 	}
 
-	// Ubah angka string jadi int32
-	i, err := strconv.ParseInt(parts[0], 10, 32)
+	minutes, err := parseRuntime(s)
 	if err != nil {
-		return ErrInvalidRuntimeFormat
+		return err
 	}
 
-	// Assign ke pointer Runtime (pakai dereference *)
-	*r = Runtime(i)
-
+	*r = Runtime(minutes)
 	return nil
 }
+
+func parseRuntime(s string) (int64, error) {
+	upper := strings.ToUpper(s)
+	lower := strings.ToLower(s)
+
+	switch {
+	case s == "":
+		return 0, fmt.Errorf("%w: must not be empty", ErrInvalidRuntimeFormat)
+
+	case strings.TrimLeft(s, "0123456789") == "":
+		return runtimeMinutes(0, s)
+
+	case strings.HasPrefix(s, "-"):
+		return 0, fmt.Errorf("%w: must not be negative", ErrInvalidRuntimeFormat)
+
+	case runtimeMinsRX.MatchString(lower):
+		return runtimeMinutes(0, runtimeMinsRX.FindStringSubmatch(lower)[1])
+
+	case runtimeISORX.MatchString(upper) && upper != "PT":
+		parts := runtimeISORX.FindStringSubmatch(upper)
+		if parts[3] != "" && strings.TrimLeft(parts[3], "0") != "" {
+			return 0, fmt.Errorf("%w: ISO 8601 durations must be whole minutes", ErrInvalidRuntimeFormat)
+		}
+		return runtimeMinutes(parseRuntimePart(parts[1]), parts[2])
+
+	case runtimeHumanRX.MatchString(lower) && strings.TrimSpace(lower) != "":
+		parts := runtimeHumanRX.FindStringSubmatch(lower)
+		if parts[1] != "" && parseRuntimePart(parts[2]) >= 60 {
+			return 0, fmt.Errorf("%w: minutes must be less than 60 when hours are given", ErrInvalidRuntimeFormat)
+		}
+		return runtimeMinutes(parseRuntimePart(parts[1]), parts[2])
+
+	default:
+		return 0, fmt.Errorf(`%w: must be a number of minutes, "<n> mins", "<h>h <m>m" or an ISO 8601 duration such as "PT2H15M"`, ErrInvalidRuntimeFormat)
+	}
+}
+
+// runtimeMinutes adds up hours and the minutes in s, checking the result against
+// MaxRuntime. A negative hours value means it was out of range already.
+func runtimeMinutes(hours int64, s string) (int64, error) {
+	minutes := parseRuntimePart(s)
+	if hours < 0 || minutes < 0 || hours*60+minutes > MaxRuntime {
+		return 0, fmt.Errorf("%w: must not be more than %d minutes", ErrInvalidRuntimeFormat, MaxRuntime)
+	}
+	return hours*60 + minutes, nil
+}
+
+// parseRuntimePart parses a run of digits, returning 0 when it's empty and -1 when it
+// doesn't fit, so that huge values are reported as out of range.
+func parseRuntimePart(s string) int64 {
+	if s == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return -1
+	}
+	return i
+}
@@ -0,0 +1,178 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+func TestRuntimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Runtime
+	}{
+		{`135`, 135},
+		{`"135"`, 135},
+		{`"135 mins"`, 135},
+		{`"135mins"`, 135},
+		{`"1 min"`, 1},
+		{`" 135 mins "`, 135},
+		{`"135 MINS"`, 135},
+		{`"2h 15m"`, 135},
+		{`"2h15m"`, 135},
+		{`"2h"`, 120},
+		{`"45m"`, 45},
+		{`"90m"`, 90},
+		{`"PT2H15M"`, 135},
+		{`"PT135M"`, 135},
+		{`"PT2H"`, 120},
+		{`"PT2H15M0S"`, 135},
+		{`"pt2h15m"`, 135},
+		{strconv.Itoa(MaxRuntime), MaxRuntime},
+		{`"1000h"`, MaxRuntime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var r Runtime
+			err := json.Unmarshal([]byte(tt.json), &r)
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) failed: %v", tt.json, err)
+			}
+			if r != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.json, r, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeUnmarshalJSONNull(t *testing.T) {
+	r := Runtime(135)
+	err := json.Unmarshal([]byte(`null`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != 135 {
+		t.Errorf("null changed the runtime to %d", r)
+	}
+}
+
+func TestRuntimeUnmarshalJSONRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"negative integer", `-5`},
+		{"negative string", `"-5 mins"`},
+		{"empty", `""`},
+		{"over the cap", strconv.Itoa(MaxRuntime + 1)},
+		{"over the cap in hours", `"1001h"`},
+		{"over the cap in ISO 8601", `"PT1001H"`},
+		{"too large to parse", `99999999999`},
+		{"fraction", `135.5`},
+		{"minutes past the hour", `"2h 60m"`},
+		{"unknown unit", `"135 minutes"`},
+		{"text", `"long"`},
+		{"ISO 8601 without a duration", `"PT"`},
+		{"ISO 8601 days", `"P1D"`},
+		{"ISO 8601 seconds", `"PT2H15M30S"`},
+		{"ISO 8601 fraction", `"PT1.5H"`},
+		{"ISO 8601 order", `"PT15M2H"`},
+		{"malformed string", `"135 mins`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Runtime
+			err := r.UnmarshalJSON([]byte(tt.json))
+			if !errors.Is(err, ErrInvalidRuntimeFormat) {
+				t.Errorf("UnmarshalJSON(%s) = %v, want ErrInvalidRuntimeFormat", tt.json, err)
+			}
+		})
+	}
+}
+
+// A zero runtime stands for an unknown one, which only movies that haven't been
+// released may have.
+func TestRuntimeZeroRejectedForReleasedMovies(t *testing.T) {
+	var r Runtime
+	err := json.Unmarshal([]byte(`"0 mins"`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	movie := &Movie{Title: "Alien", Year: 1979, Runtime: r, Status: StatusReleased}
+
+	v := validator.New()
+	ValidateMovie(v, movie, nil)
+	if v.Errors["runtime"] == "" {
+		t.Error("a released movie with a zero runtime passed validation")
+	}
+
+	movie.Runtime = -1
+	v = validator.New()
+	ValidateMovie(v, movie, nil)
+	if v.Errors["runtime"] == "" {
+		t.Error("a negative runtime passed validation")
+	}
+}
+
+func TestRuntimeMarshalJSONFormat(t *testing.T) {
+	tests := []struct {
+		runtime Runtime
+		format  RuntimeFormat
+		want    string
+	}{
+		{135, RuntimeMins, `"135 mins"`},
+		{135, "", `"135 mins"`},
+		{135, "unknown", `"135 mins"`},
+		{135, RuntimeMinutesInt, `135`},
+		{135, RuntimeISO8601, `"PT2H15M"`},
+		{120, RuntimeISO8601, `"PT2H"`},
+		{45, RuntimeISO8601, `"PT45M"`},
+		{135, RuntimeHuman, `"2h 15m"`},
+		{120, RuntimeHuman, `"2h"`},
+		{45, RuntimeHuman, `"45m"`},
+	}
+
+	for _, tt := range tests {
+		js, err := tt.runtime.MarshalJSONFormat(tt.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(js) != tt.want {
+			t.Errorf("%d in %q = %s, want %s", tt.runtime, tt.format, js, tt.want)
+		}
+	}
+
+	js, err := json.Marshal(Runtime(135))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(js) != `"135 mins"` {
+		t.Errorf("MarshalJSON = %s, want the mins format", js)
+	}
+}
+
+func TestRuntimeRoundTrip(t *testing.T) {
+	for _, format := range RuntimeFormats {
+		for _, runtime := range []Runtime{1, 45, 60, 135, 600, MaxRuntime} {
+			js, err := runtime.MarshalJSONFormat(format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got Runtime
+			err = json.Unmarshal(js, &got)
+			if err != nil {
+				t.Fatalf("%d in %q: reading back %s failed: %v", runtime, format, js, err)
+			}
+			if got != runtime {
+				t.Errorf("%d in %q: read back %s as %d", runtime, format, js, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// The weights of the signals that make up the similarity score of two movies, which
+// add up to 1. Genre overlap is measured as the Jaccard index of the genres, year
+// proximity falls off linearly to zero at SimilarYearRange years apart, and title
+// similarity is the trigram similarity of the titles.
+const (
+	SimilarGenreWeight = 0.6
+	SimilarYearWeight  = 0.25
+	SimilarTitleWeight = 0.15
+	SimilarYearRange   = 20
+)
+
+const (
+	// SimilarMaxLimit is the most similar movies that can be requested at once, and
+	// SimilarMaxExclude the most ids that a request can leave out.
+	SimilarMaxLimit   = 50
+	SimilarMaxExclude = 50
+
+	// SimilarCacheSize is the number of similar movies that are stored per movie. It
+	// leaves room for the excluded ids, so that a request gets as many movies as it
+	// asked for even when all of the ids it leaves out are among the best matches.
+	SimilarCacheSize = SimilarMaxLimit + SimilarMaxExclude
+
+	// SimilarCacheTTL is how long a ranking is kept before it is recomputed to take
+	// movies that were added or changed since into account. Changes to the movie itself
+	// make its ranking stale right away.
+	SimilarCacheTTL = 24 * time.Hour
+)
+
+// GetSimilar returns the movies that are most similar to the given movie, best match
+// first, leaving out the excluded ids. The rankings are only read here: they are
+// computed in the background by RefreshSimilar, so a movie that was just added has no
+// similar movies yet, and a movie that was just changed keeps its previous ranking
+// until it is recomputed.
+func (m MovieModel) GetSimilar(movie *Movie, limit int, exclude []int64) ([]*Movie, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := fmt.Sprintf(`
+		SELECT movie_similarities.score, %s
+		FROM movie_similarities
+		INNER JOIN movies ON movies.id = movie_similarities.similar_id
+		WHERE movie_similarities.movie_id = $1
+		AND NOT (movies.id = ANY($2))
+		ORDER BY movie_similarities.score DESC, movies.id ASC
+		LIMIT $3`, movieColumns)
+
+	rows, err := m.DB.QueryContext(ctx, query, movie.ID, pq.Array(exclude), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := []*Movie{}
+
+	for rows.Next() {
+		var similar Movie
+		err := rows.Scan(append([]any{&similar.Similarity}, similar.scanDest()...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, &similar)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// StaleSimilar returns up to limit movies whose ranking is missing, was computed for
+// an older version of the movie or is older than SimilarCacheTTL, the movies without a
+// ranking first. The version of each movie is set, ready for RefreshSimilar.
+func (m MovieModel) StaleSimilar(limit int) ([]*Movie, error) {
+	query := `
+		SELECT movies.id, movies.version
+		FROM movies
+		LEFT JOIN movie_similarity_cache ON movie_similarity_cache.movie_id = movies.id
+		WHERE movie_similarity_cache.movie_id IS NULL
+		OR movie_similarity_cache.movie_version <> movies.version
+		OR movie_similarity_cache.computed_at < $1
+		ORDER BY movie_similarity_cache.computed_at ASC NULLS FIRST, movies.id ASC
+		LIMIT $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, time.Now().Add(-SimilarCacheTTL), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := []*Movie{}
+
+	for rows.Next() {
+		var movie Movie
+		err := rows.Scan(&movie.ID, &movie.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, &movie)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// RefreshSimilar recomputes the SimilarCacheSize most similar movies of the movie, and
+// records that they were computed for its version. Only movies sharing at least one
+// genre are considered, which lets the genre index do most of the work on large
+// catalogs. It is meant to run in the background, so it gets a longer timeout than the
+// queries of requests.
+func (m MovieModel) RefreshSimilar(movie *Movie) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Upserting the cache row first also locks it, so concurrent refreshes of the same
+	// movie take turns instead of clashing on the rankings.
+	query := `
+		INSERT INTO movie_similarity_cache (movie_id, movie_version, computed_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (movie_id) DO UPDATE
+		SET movie_version = EXCLUDED.movie_version, computed_at = EXCLUDED.computed_at`
+
+	_, err = tx.ExecContext(ctx, query, movie.ID, movie.Version)
+	if err != nil {
+		return err
+	}
+	query = `DELETE FROM movie_similarities WHERE movie_id = $1`
+
+	_, err = tx.ExecContext(ctx, query, movie.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO movie_similarities (movie_id, similar_id, score)
+		SELECT $1, candidates.id, candidates.score
+		FROM (
+			SELECT other.id,
+				$2 * (
+					SELECT count(*) FROM (SELECT unnest(movie.genres) INTERSECT SELECT unnest(other.genres)) AS shared
+				)::double precision / (
+					SELECT count(*) FROM (SELECT unnest(movie.genres) UNION SELECT unnest(other.genres)) AS combined
+				)
+				+ $3 * greatest(0, 1 - abs(movie.year - other.year) / $4::double precision)
+				+ $5 * similarity(movie.title, other.title) AS score
+			FROM movies AS movie
+			INNER JOIN movies AS other ON other.genres && movie.genres AND other.id <> movie.id
+			WHERE movie.id = $1
+		) AS candidates
+		ORDER BY candidates.score DESC, candidates.id ASC
+		LIMIT $6`
+	args := []any{movie.ID, SimilarGenreWeight, SimilarYearWeight, SimilarYearRange, SimilarTitleWeight, SimilarCacheSize}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
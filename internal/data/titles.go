@@ -0,0 +1,239 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+const (
+	TitleOriginal   = "original"
+	TitleWorking    = "working"
+	TitleTranslated = "translated"
+)
+
+var (
+	ErrDuplicateMovieTitle = errors.New("duplicate movie title")
+
+	// LanguageRX matches ISO 639-1 language codes, and RegionRX matches ISO 3166-1
+	// alpha-2 region codes.
+	LanguageRX = regexp.MustCompile(`^[a-z]{2}$`)
+	RegionRX   = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// MovieTitle is an alternate title of a movie, such as the title it was released under
+// in a given language and region.
+type MovieTitle struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"-"`
+	MovieID   int64     `json:"movie_id"`
+	Title     string    `json:"title"`
+	Language  string    `json:"language"`
+	Region    string    `json:"region,omitzero"`
+	Type      string    `json:"type"`
+}
+
+func ValidateMovieTitle(v *validator.Validator, title *MovieTitle) {
+	v.Check(title.Title != "", "title", "must be provided")
+	v.Check(len(title.Title) <= 500, "title", "must not be more than 500 bytes long")
+	v.Check(validator.Matches(title.Language, LanguageRX), "language", "must be a lowercase ISO 639-1 code, such as \"en\"")
+	v.Check(title.Region == "" || validator.Matches(title.Region, RegionRX), "region", "must be an uppercase ISO 3166-1 alpha-2 code, such as \"US\"")
+	v.Check(validator.PermittedValue(title.Type, TitleOriginal, TitleWorking, TitleTranslated), "type", "must be one of original, working or translated")
+}
+
+// Locale is a language, optionally narrowed down to a region, that a client prefers
+// titles in.
+type Locale struct {
+	Language string
+	Region   string
+}
+
+// DisplayTitle picks the alternate title that best suits the locales, which are
+// ordered by preference. For each locale a title for the exact region is preferred over
+// a title without region, which in turn is preferred over a title for another region.
+// Working titles are only used when nothing else matches. The primary title of the
+// movie is returned when no alternate title matches any of the locales.
+func DisplayTitle(movie *Movie, titles []*MovieTitle, locales []Locale) string {
+	for _, locale := range locales {
+		var best *MovieTitle
+		bestScore := -1
+
+		for _, title := range titles {
+			if title.Language != locale.Language {
+				continue
+			}
+
+			score := 0
+			switch {
+			case locale.Region != "" && title.Region == locale.Region:
+				score += 4
+			case title.Region == "":
+				score += 2
+			}
+			if title.Type != TitleWorking {
+				score++
+			}
+
+			if score > bestScore {
+				best, bestScore = title, score
+			}
+		}
+
+		if best != nil {
+			return best.Title
+		}
+	}
+
+	return movie.Title
+}
+
+type MovieTitleModel struct {
+	DB *sql.DB
+}
+
+// Insert adds an alternate title to a movie. The title changes the display title of the
+// movie in some languages, so the movie counts as updated.
+func (m MovieTitleModel) Insert(title *MovieTitle) error {
+	query := `
+		INSERT INTO movie_titles (movie_id, title, language, region, type)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+	args := []any{title.MovieID, title.Title, title.Language, title.Region, title.Type}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&title.ID, &title.CreatedAt)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "movie_titles_unique_idx"`:
+			return ErrDuplicateMovieTitle
+		default:
+			return err
+		}
+	}
+
+	err = touchMovie(ctx, tx, title.MovieID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAllForMovie returns the alternate titles of a movie, ordered by language, region
+// and type.
+func (m MovieTitleModel) GetAllForMovie(movieID int64) ([]*MovieTitle, error) {
+	titles, err := m.GetAllForMovies([]int64{movieID})
+	if err != nil {
+		return nil, err
+	}
+
+	if titles[movieID] == nil {
+		return []*MovieTitle{}, nil
+	}
+	return titles[movieID], nil
+}
+
+// GetAllForMovies returns the alternate titles of several movies at once, keyed by
+// movie ID, so that a page of movies doesn't need a query per movie.
+func (m MovieTitleModel) GetAllForMovies(movieIDs []int64) (map[int64][]*MovieTitle, error) {
+	query := `
+		SELECT id, created_at, movie_id, title, language, region, type
+		FROM movie_titles
+		WHERE movie_id = ANY($1)
+		ORDER BY movie_id, language, region, type, id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(movieIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	titles := make(map[int64][]*MovieTitle)
+
+	for rows.Next() {
+		var title MovieTitle
+		err := rows.Scan(
+			&title.ID,
+			&title.CreatedAt,
+			&title.MovieID,
+			&title.Title,
+			&title.Language,
+			&title.Region,
+			&title.Type,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		titles[title.MovieID] = append(titles[title.MovieID], &title)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return titles, nil
+}
+
+// Delete removes an alternate title, as long as it belongs to the given movie. Like
+// Insert, it counts as an update of the movie.
+func (m MovieTitleModel) Delete(movieID, id int64) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `DELETE FROM movie_titles WHERE id = $1 AND movie_id = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, id, movieID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	err = touchMovie(ctx, tx, movieID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// touchMovie moves the updated_at time of a movie forward, as part of a transaction that
+// changes what the movie looks like in responses without changing the movie row, so
+// that If-Modified-Since and updated_since see the change. The trigger on movies sets
+// the time.
+func touchMovie(ctx context.Context, tx *sql.Tx, movieID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE movies SET updated_at = NOW() WHERE id = $1`, movieID)
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/ucok-man/gmoapi/internal/eventbus"
 	"github.com/ucok-man/gmoapi/internal/validator"
 )
 
@@ -14,6 +15,7 @@ const (
 	ScopeActivation     = "activation"
 	ScopeAuthentication = "authentication"
 	ScopePasswordReset  = "password-reset"
+	ScopeEmailChange    = "email-change"
 )
 
 type Token struct {
@@ -53,7 +55,8 @@ func ValidateTokenPlaintext(v *validator.Validator, tokenPlaintext string) {
 }
 
 type TokenModel struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Events eventbus.Bus
 }
 
 func (m TokenModel) New(userID int64, ttl time.Duration, scope string) (*Token, error) {
@@ -81,9 +84,42 @@ func (m TokenModel) DeleteAllForUser(scope string, userID int64) error {
         DELETE FROM tokens
         WHERE scope = $1 AND user_id = $2`
 
+	return m.deleteForUser(scope, userID, query, scope, userID)
+}
+
+// DeleteAllForUserExcept deletes the tokens of the scope of the user, except the token
+// with the plaintext, such as the token that the request asking for it was made with.
+func (m TokenModel) DeleteAllForUserExcept(scope string, userID int64, tokenPlaintext string) error {
+	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
+
+	query := `
+        DELETE FROM tokens
+        WHERE scope = $1 AND user_id = $2 AND hash <> $3`
+
+	return m.deleteForUser(scope, userID, query, scope, userID, tokenHash[:])
+}
+
+// deleteForUser runs the query deleting tokens of the scope of the user, and publishes
+// TopicTokensRevoked with it.
+func (m TokenModel) deleteForUser(scope string, userID int64, query string, args ...any) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	_, err := m.DB.ExecContext(ctx, query, scope, userID)
-	return err
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = m.Events.Publish(ctx, tx, TopicTokensRevoked, TokensRevokedEvent{UserID: userID, Scope: scope})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ucok-man/gmoapi/internal/validator"
@@ -19,7 +20,7 @@ type User struct {
 	Email     string    `json:"email"`
 	Password  password  `json:"-"`
 	Activated bool      `json:"activated"`
-	Version   int       `json:"-"`
+	Version   int       `json:"version"`
 }
 
 func (u *User) IsAnonymous() bool {
@@ -49,6 +50,7 @@ type UserModel struct {
 	DB *sql.DB
 }
 
+// Insert adds the user, and queues the user.registered webhook event along with it.
 func (m UserModel) Insert(user *User) error {
 	query := `
 		INSERT INTO users (name, email, password_hash, activated)
@@ -59,7 +61,13 @@ func (m UserModel) Insert(user *User) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&user.ID, &user.CreatedAt, &user.Version)
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&user.ID, &user.CreatedAt, &user.Version)
 	if err != nil {
 		switch {
 		case err.Error() == `pq: duplicate key value violates unique constraint "users_email_key"`:
@@ -68,7 +76,13 @@ func (m UserModel) Insert(user *User) error {
 			return err
 		}
 	}
-	return nil
+
+	err = enqueueWebhookEvent(ctx, tx, EventUserRegistered, map[string]any{"user": user})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (m UserModel) GetByEmail(email string) (*User, error) {
@@ -174,3 +188,182 @@ func (m UserModel) GetForToken(tokenScope, tokenPlaintext string) (*User, error)
 
 	return &user, nil
 }
+
+// GetAll returns a page of the users, for administration.
+func (m UserModel) GetAll(filters Filter) ([]*User, Metadata, error) {
+	query := fmt.Sprintf(`
+		SELECT count(*) OVER(), id, created_at, name, email, password_hash, activated, version
+		FROM users
+		ORDER BY %s %s, id ASC
+		LIMIT $1 OFFSET $2`, filters.sortColumn(), filters.sortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, filters.limit(), filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	users := []*User{}
+
+	for rows.Next() {
+		var user User
+		err := rows.Scan(
+			&totalRecords,
+			&user.ID,
+			&user.CreatedAt,
+			&user.Name,
+			&user.Email,
+			&user.Password.hash,
+			&user.Activated,
+			&user.Version,
+		)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return users, metadata, nil
+}
+
+// SetPendingEmail records the email address that the user is changing to, and returns a
+// new token with the email-change scope that confirms it. Earlier pending changes and
+// their tokens are replaced, so that only the last requested address can be confirmed.
+func (m UserModel) SetPendingEmail(userID int64, email string, ttl time.Duration) (*Token, error) {
+	token := generateToken(userID, ttl, ScopeEmailChange)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Updating the user first locks their row, which keeps concurrent changes from
+	// interleaving between the address and the tokens.
+	result, err := tx.ExecContext(ctx, `UPDATE users SET pending_email = $1 WHERE id = $2`, email, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrRecordNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM tokens WHERE user_id = $1 AND scope = $2`, userID, ScopeEmailChange)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO tokens (hash, user_id, expiry, scope)
+		VALUES ($1, $2, $3, $4)`,
+		token.Hash, token.UserID, token.Expiry, token.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, tx.Commit()
+}
+
+// ConfirmPendingEmail makes the pending email address of the user their email address,
+// given the email-change token that was sent to it, and updates the email and version
+// of the user. The token is used up, and so are the activation and password reset
+// tokens, which were sent to the old address.
+//
+// It returns ErrRecordNotFound when the token is invalid or expired, or belongs to
+// another user, and ErrDuplicateEmail when the token is valid but another user has taken
+// the address since the change was requested.
+func (m UserModel) ConfirmPendingEmail(user *User, tokenPlaintext string) error {
+	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the row of the user before the token is checked. A change requested
+	// concurrently either committed before, and then the token has been replaced, or
+	// waits until this one is done.
+	_, err = tx.ExecContext(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// The token is checked before the address is swapped, so that nothing about the
+	// pending address is revealed to a request without a valid token.
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM tokens
+		WHERE hash = $1 AND user_id = $2 AND scope = $3 AND expiry > $4`,
+		tokenHash[:], user.ID, ScopeEmailChange, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrRecordNotFound
+	}
+
+	query := `
+		UPDATE users
+		SET email = pending_email, pending_email = NULL, version = version + 1
+		WHERE id = $1 AND pending_email IS NOT NULL
+		RETURNING email, version`
+
+	var email string
+	var version int
+
+	err = tx.QueryRowContext(ctx, query, user.ID).Scan(&email, &version)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "users_email_key"`:
+			return ErrDuplicateEmail
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM tokens
+		WHERE user_id = $1 AND scope IN ($2, $3, $4)`,
+		user.ID, ScopeEmailChange, ScopeActivation, ScopePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	user.Email = email
+	user.Version = version
+
+	return nil
+}
@@ -0,0 +1,515 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// The events that webhooks can subscribe to.
+const (
+	EventMovieCreated   = "movie.created"
+	EventMovieUpdated   = "movie.updated"
+	EventMovieDeleted   = "movie.deleted"
+	EventUserRegistered = "user.registered"
+)
+
+var WebhookEvents = []string{EventMovieCreated, EventMovieUpdated, EventMovieDeleted, EventUserRegistered}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	// WebhookMaxAttempts is how often a delivery is attempted before it is given up.
+	WebhookMaxAttempts = 8
+
+	// WebhookDisableAfter is the number of failed attempts in a row after which a
+	// webhook is disabled, across all of its deliveries.
+	WebhookDisableAfter = 20
+)
+
+type Webhook struct {
+	ID                  int64     `json:"id"`
+	CreatedAt           time.Time `json:"created_at"`
+	URL                 string    `json:"url"`
+	Secret              string    `json:"secret,omitzero"`
+	Events              []string  `json:"events"`
+	Active              bool      `json:"active"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Version             int32     `json:"version"`
+}
+
+func ValidateWebhook(v *validator.Validator, webhook *Webhook) {
+	v.Check(webhook.URL != "", "url", "must be provided")
+	v.Check(len(webhook.URL) <= 2048, "url", "must not be more than 2048 bytes long")
+
+	u, err := url.Parse(webhook.URL)
+	v.Check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "url", "must be an absolute http or https URL")
+
+	v.Check(len(webhook.Events) > 0, "events", "must contain at least 1 event")
+	v.Check(validator.Unique(webhook.Events), "events", "must not contain duplicate values")
+
+	for _, event := range webhook.Events {
+		v.Check(validator.PermittedValue(event, WebhookEvents...), "events", fmt.Sprintf("must only contain %s, %s, %s or %s", EventMovieCreated, EventMovieUpdated, EventMovieDeleted, EventUserRegistered))
+	}
+}
+
+type WebhookModel struct {
+	DB *sql.DB
+}
+
+// Insert adds the webhook along with a newly generated secret, which is only ever
+// returned here.
+func (m WebhookModel) Insert(webhook *Webhook) error {
+	webhook.Secret = "whsec_" + rand.Text()
+
+	query := `
+		INSERT INTO webhooks (url, secret, events)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, active, consecutive_failures, version`
+	args := []any{webhook.URL, webhook.Secret, pq.Array(webhook.Events)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.DB.QueryRowContext(ctx, query, args...).Scan(
+		&webhook.ID,
+		&webhook.CreatedAt,
+		&webhook.Active,
+		&webhook.ConsecutiveFailures,
+		&webhook.Version,
+	)
+}
+
+func (m WebhookModel) Get(id int64) (*Webhook, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT id, created_at, url, events, active, consecutive_failures, version
+		FROM webhooks
+		WHERE id = $1`
+
+	var webhook Webhook
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(
+		&webhook.ID,
+		&webhook.CreatedAt,
+		&webhook.URL,
+		pq.Array(&webhook.Events),
+		&webhook.Active,
+		&webhook.ConsecutiveFailures,
+		&webhook.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &webhook, nil
+}
+
+func (m WebhookModel) GetAll(filters Filter) ([]*Webhook, Metadata, error) {
+	query := fmt.Sprintf(`
+		SELECT count(*) OVER(), id, created_at, url, events, active, consecutive_failures, version
+		FROM webhooks
+		ORDER BY %s %s, id ASC
+		LIMIT $1 OFFSET $2`, filters.sortColumn(), filters.sortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, filters.limit(), filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	webhooks := []*Webhook{}
+
+	for rows.Next() {
+		var webhook Webhook
+		err := rows.Scan(
+			&totalRecords,
+			&webhook.ID,
+			&webhook.CreatedAt,
+			&webhook.URL,
+			pq.Array(&webhook.Events),
+			&webhook.Active,
+			&webhook.ConsecutiveFailures,
+			&webhook.Version,
+		)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+
+		webhooks = append(webhooks, &webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return webhooks, metadata, nil
+}
+
+// Update saves the URL, events and active flag of the webhook. Re-enabling a webhook
+// starts its count of failed attempts afresh.
+func (m WebhookModel) Update(webhook *Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $1, events = $2, active = $3,
+			consecutive_failures = CASE WHEN $3 AND NOT active THEN 0 ELSE consecutive_failures END,
+			version = version + 1
+		WHERE id = $4 AND version = $5
+		RETURNING consecutive_failures, version`
+	args := []any{webhook.URL, pq.Array(webhook.Events), webhook.Active, webhook.ID, webhook.Version}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&webhook.ConsecutiveFailures, &webhook.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (m WebhookModel) Delete(id int64) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `DELETE FROM webhooks WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// WebhookDelivery is a single event sent to a webhook, along with the outcome of the
+// last attempt to send it.
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	WebhookID      int64           `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at,omitzero"`
+	LastAttemptAt  time.Time       `json:"last_attempt_at,omitzero"`
+	ResponseStatus int             `json:"response_status,omitzero"`
+	LastError      string          `json:"last_error,omitzero"`
+
+	// The URL and secret of the webhook, only set on claimed deliveries.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+const webhookDeliveryColumns = `webhook_deliveries.id, webhook_deliveries.created_at,
+	webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload,
+	webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at,
+	webhook_deliveries.last_attempt_at, webhook_deliveries.response_status, webhook_deliveries.last_error`
+
+// scanDest returns the scan destinations of webhookDeliveryColumns. The nullable
+// timestamps are scanned into next and last, which setTimes copies over afterwards.
+func (d *WebhookDelivery) scanDest(next, last *sql.NullTime) []any {
+	return []any{
+		&d.ID,
+		&d.CreatedAt,
+		&d.WebhookID,
+		&d.Event,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		next,
+		last,
+		&d.ResponseStatus,
+		&d.LastError,
+	}
+}
+
+func (d *WebhookDelivery) setTimes(next, last sql.NullTime) {
+	d.NextAttemptAt = next.Time
+	d.LastAttemptAt = last.Time
+}
+
+type WebhookDeliveryModel struct {
+	DB *sql.DB
+}
+
+// enqueueWebhookEvent queues a delivery of the event to every active webhook subscribed
+// to it, as part of the transaction that made the change. The deliveries are queued if
+// and only if the change is committed, so no event is lost when the server stops in
+// between, and none is sent for a change that was rolled back. The payload carries the
+// event name, the time it happened and the data.
+func enqueueWebhookEvent(ctx context.Context, tx *sql.Tx, event string, data map[string]any) error {
+	payload, err := json.Marshal(map[string]any{
+		"event":       event,
+		"occurred_at": time.Now().UTC(),
+		"data":        data,
+	})
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+		SELECT id, $1, $2, NOW()
+		FROM webhooks
+		WHERE active AND $1 = ANY(events)
+		ORDER BY id`
+
+	_, err = tx.ExecContext(ctx, query, event, string(payload))
+	return err
+}
+
+// GetAllForWebhook returns the delivery log of a webhook.
+func (m WebhookDeliveryModel) GetAllForWebhook(webhookID int64, filters Filter) ([]*WebhookDelivery, Metadata, error) {
+	query := fmt.Sprintf(`
+		SELECT count(*) OVER(), %s
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY %s %s
+		LIMIT $2 OFFSET $3`, webhookDeliveryColumns, filters.sortColumn(), filters.sortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, webhookID, filters.limit(), filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	deliveries := []*WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var next, last sql.NullTime
+
+		err := rows.Scan(append([]any{&totalRecords}, delivery.scanDest(&next, &last)...)...)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+		delivery.setTimes(next, last)
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return deliveries, metadata, nil
+}
+
+// Redeliver queues a new delivery with the event and payload of an earlier delivery of
+// the webhook, leaving the log of the earlier delivery as it is.
+func (m WebhookDeliveryModel) Redeliver(webhookID, id int64) (*WebhookDelivery, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+		SELECT webhook_id, event, payload, NOW()
+		FROM webhook_deliveries
+		WHERE id = $1 AND webhook_id = $2
+		RETURNING %s`, webhookDeliveryColumns)
+
+	var delivery WebhookDelivery
+	var next, last sql.NullTime
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, id, webhookID).Scan(delivery.scanDest(&next, &last)...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+	delivery.setTimes(next, last)
+
+	return &delivery, nil
+}
+
+// ClaimDue returns up to limit pending deliveries of active webhooks that are due, and
+// postpones them by lease so that no other worker picks them up in the meantime. A
+// delivery that isn't recorded before the lease runs out, for example because the
+// server stopped, is attempted again.
+func (m WebhookDeliveryModel) ClaimDue(limit int, lease time.Duration) ([]*WebhookDelivery, error) {
+	query := fmt.Sprintf(`
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhooks
+		WHERE webhooks.id = webhook_deliveries.webhook_id
+		AND webhook_deliveries.id IN (
+			SELECT webhook_deliveries.id
+			FROM webhook_deliveries
+			INNER JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+			WHERE webhook_deliveries.status = 'pending'
+			AND webhook_deliveries.next_attempt_at <= NOW()
+			AND webhooks.active
+			ORDER BY webhook_deliveries.next_attempt_at, webhook_deliveries.id
+			LIMIT $1
+			FOR UPDATE OF webhook_deliveries SKIP LOCKED
+		)
+		RETURNING %s, webhooks.url, webhooks.secret`, webhookDeliveryColumns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var next, last sql.NullTime
+
+		err := rows.Scan(append(delivery.scanDest(&next, &last), &delivery.URL, &delivery.Secret)...)
+		if err != nil {
+			return nil, err
+		}
+		delivery.setTimes(next, last)
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt saves the outcome of an attempt to send a delivery: its status,
+// attempts, next attempt, response status and error. A failed attempt counts towards
+// disabling the webhook, a successful one resets the count.
+func (m WebhookDeliveryModel) RecordAttempt(delivery *WebhookDelivery) error {
+	var next sql.NullTime
+	if delivery.Status == DeliveryPending {
+		next = sql.NullTime{Time: delivery.NextAttemptAt, Valid: true}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = NOW(),
+			response_status = $4, last_error = $5
+		WHERE id = $6
+		RETURNING last_attempt_at`
+	args := []any{delivery.Status, delivery.Attempts, next, delivery.ResponseStatus, delivery.LastError, delivery.ID}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&delivery.LastAttemptAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	// Lock the webhook, so that attempts of its deliveries that finish at the same time
+	// count one after the other.
+	webhook := Webhook{ID: delivery.WebhookID}
+
+	query = `SELECT consecutive_failures, active FROM webhooks WHERE id = $1 FOR UPDATE`
+
+	err = tx.QueryRowContext(ctx, query, webhook.ID).Scan(&webhook.ConsecutiveFailures, &webhook.Active)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	webhook.recordAttempt(delivery.Status == DeliverySucceeded)
+
+	query = `UPDATE webhooks SET consecutive_failures = $1, active = $2 WHERE id = $3`
+
+	_, err = tx.ExecContext(ctx, query, webhook.ConsecutiveFailures, webhook.Active, webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// recordAttempt counts an attempt to send one of the deliveries of the webhook. A
+// successful attempt resets the count of failures in a row, and the webhook is disabled
+// once WebhookDisableAfter attempts have failed in a row.
+func (w *Webhook) recordAttempt(succeeded bool) {
+	if succeeded {
+		w.ConsecutiveFailures = 0
+		return
+	}
+
+	w.ConsecutiveFailures++
+	if w.ConsecutiveFailures >= WebhookDisableAfter {
+		w.Active = false
+	}
+}
@@ -0,0 +1,34 @@
+package data
+
+import "testing"
+
+func TestWebhookRecordAttempt(t *testing.T) {
+	webhook := &Webhook{Active: true}
+
+	for i := 1; i < WebhookDisableAfter; i++ {
+		webhook.recordAttempt(false)
+		if !webhook.Active {
+			t.Fatalf("disabled after %d failures, want %d", i, WebhookDisableAfter)
+		}
+	}
+
+	webhook.recordAttempt(true)
+	if webhook.ConsecutiveFailures != 0 {
+		t.Fatalf("ConsecutiveFailures = %d after a success, want 0", webhook.ConsecutiveFailures)
+	}
+
+	for range WebhookDisableAfter - 1 {
+		webhook.recordAttempt(false)
+	}
+	if !webhook.Active {
+		t.Fatal("disabled before the failures in a row reached the limit")
+	}
+
+	webhook.recordAttempt(false)
+	if webhook.Active {
+		t.Errorf("still active after %d failures in a row", WebhookDisableAfter)
+	}
+	if webhook.ConsecutiveFailures != WebhookDisableAfter {
+		t.Errorf("ConsecutiveFailures = %d, want %d", webhook.ConsecutiveFailures, WebhookDisableAfter)
+	}
+}
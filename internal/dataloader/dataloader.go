@@ -0,0 +1,75 @@
+// Package dataloader batches the lookups that resolvers make one key at a time into a
+// single query per batch, so that resolving a field of every item in a list doesn't
+// cost a query per item.
+package dataloader
+
+import "sync"
+
+// BatchFunc loads the values of several keys at once. Keys that have no value may be
+// left out of the map, they are loaded as the zero value.
+type BatchFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+// Loader collects the keys passed to Load and loads them with a single call to its
+// BatchFunc once one of the values is needed. Loaded values are cached for the life of
+// the loader, which should therefore be created per request.
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	entries map[K]*entry[V]
+	pending []K
+}
+
+type entry[V any] struct {
+	value  V
+	err    error
+	loaded bool
+}
+
+func New[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:   batch,
+		entries: make(map[K]*entry[V]),
+	}
+}
+
+// Load queues key for the next batch and returns a thunk that returns its value. The
+// batch is loaded when the first of its thunks is called, so every key that should be
+// part of it has to be queued before any of the thunks is called.
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	e, ok := l.entries[key]
+	if !ok {
+		e = &entry[V]{}
+		l.entries[key] = e
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !e.loaded {
+			l.dispatch()
+		}
+		return e.value, e.err
+	}
+}
+
+// dispatch loads the pending keys. The caller must hold l.mu.
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(keys)
+	for _, key := range keys {
+		e := l.entries[key]
+		e.value, e.err, e.loaded = values[key], err, true
+
+		// Don't cache failures, so that a later Load of the key tries again.
+		if err != nil {
+			delete(l.entries, key)
+		}
+	}
+}
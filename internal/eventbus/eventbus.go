@@ -0,0 +1,152 @@
+// Package eventbus carries domain events between the replicas of the API. Events are
+// published with Postgres NOTIFY, as part of the transaction that caused them, and
+// every replica fans them out to its in-process subscribers through LISTEN.
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+)
+
+// TopicResync is delivered to every subscriber after events may have been missed, for
+// example when the connection to the database was lost for a while. Subscribers that
+// keep state derived from events should rebuild it.
+const TopicResync = "bus.resync"
+
+// subscriptionBuffer is the number of events a subscription holds before further events
+// are dropped for it.
+const subscriptionBuffer = 64
+
+type Event struct {
+	Topic string          `json:"topic"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// Decode unmarshals the data of the event into dst.
+func (e Event) Decode(dst any) error {
+	return json.Unmarshal(e.Data, dst)
+}
+
+// Execer is satisfied by *sql.DB and *sql.Tx, so that events can be published as part
+// of a transaction.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Bus interface {
+	// Publish sends an event with data marshalled as JSON to the subscribers of the
+	// topic. When q is a transaction, the event is only sent once it commits.
+	Publish(ctx context.Context, q Execer, topic string, data any) error
+
+	// Subscribe returns a subscription to the given topics, and to TopicResync.
+	Subscribe(topics ...string) *Subscription
+
+	// Close stops the bus and closes all subscriptions.
+	Close() error
+}
+
+type Subscription struct {
+	// C receives the events. It is closed when the subscription or the bus is closed.
+	C <-chan Event
+
+	c      chan Event
+	topics map[string]bool
+	hub    *hub
+	once   sync.Once
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// hub fans events out to the subscriptions of a bus.
+type hub struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+func newHub() *hub {
+	return &hub{subs: make(map[*Subscription]struct{})}
+}
+
+func (h *hub) subscribe(topics []string) *Subscription {
+	c := make(chan Event, subscriptionBuffer)
+
+	sub := &Subscription{
+		C:      c,
+		c:      c,
+		topics: map[string]bool{TopicResync: true},
+		hub:    h,
+	}
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		sub.once.Do(func() { close(c) })
+		return sub
+	}
+
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+func (h *hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subs, sub)
+	sub.once.Do(func() { close(sub.c) })
+}
+
+// broadcast delivers the event to the subscriptions of its topic. Events never block
+// the bus: a subscription that has fallen behind by a full buffer misses the event.
+func (h *hub) broadcast(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		if !sub.topics[event.Topic] {
+			continue
+		}
+
+		select {
+		case sub.c <- event:
+		default:
+		}
+	}
+}
+
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		sub.once.Do(func() { close(sub.c) })
+	}
+}
+
+func encode(topic string, data any) (Event, []byte, error) {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, nil, err
+	}
+
+	event := Event{Topic: topic, Data: js}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Event{}, nil, err
+	}
+
+	return event, payload, nil
+}
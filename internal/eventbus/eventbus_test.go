@@ -0,0 +1,246 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-sub.C:
+		if !ok {
+			t.Fatal("subscription closed, want an event")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func expectNone(t *testing.T, sub *Subscription) {
+	t.Helper()
+
+	select {
+	case event, ok := <-sub.C:
+		if ok {
+			t.Fatalf("received a %s event, want none", event.Topic)
+		}
+	default:
+	}
+}
+
+func TestMemoryOrdering(t *testing.T) {
+	bus := NewMemory()
+	defer bus.Close()
+
+	sub := bus.Subscribe("movie.changed")
+	defer sub.Close()
+
+	for i := range 10 {
+		err := bus.Publish(context.Background(), nil, "movie.changed", map[string]int{"id": i})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := range 10 {
+		var data struct{ ID int }
+		err := receive(t, sub).Decode(&data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.ID != i {
+			t.Fatalf("event %d carries id %d, events are out of order", i, data.ID)
+		}
+	}
+}
+
+func TestMemoryTopics(t *testing.T) {
+	bus := NewMemory()
+	defer bus.Close()
+
+	movies := bus.Subscribe("movie.changed")
+	defer movies.Close()
+	tokens := bus.Subscribe("tokens.revoked")
+	defer tokens.Close()
+
+	bus.Publish(context.Background(), nil, "movie.changed", nil)
+
+	if event := receive(t, movies); event.Topic != "movie.changed" {
+		t.Errorf("topic = %q, want movie.changed", event.Topic)
+	}
+	expectNone(t, tokens)
+
+	// Every subscription receives TopicResync, whatever topics it asked for.
+	bus.Publish(context.Background(), nil, TopicResync, nil)
+
+	receive(t, movies)
+	receive(t, tokens)
+}
+
+func TestSlowSubscriberDoesNotBlock(t *testing.T) {
+	bus := NewMemory()
+	defer bus.Close()
+
+	slow := bus.Subscribe("movie.changed")
+	defer slow.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range subscriptionBuffer + 10 {
+			bus.Publish(context.Background(), nil, "movie.changed", nil)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a subscriber that is not reading")
+	}
+
+	if n := len(slow.C); n != subscriptionBuffer {
+		t.Errorf("%d events buffered, want %d", n, subscriptionBuffer)
+	}
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	bus := NewMemory()
+	defer bus.Close()
+
+	sub := bus.Subscribe("movie.changed")
+	sub.Close()
+	sub.Close()
+
+	if _, ok := <-sub.C; ok {
+		t.Fatal("received on a closed subscription")
+	}
+
+	bus.hub.mu.Lock()
+	n := len(bus.hub.subs)
+	bus.hub.mu.Unlock()
+	if n != 0 {
+		t.Errorf("hub holds %d subscriptions after Close, want 0", n)
+	}
+
+	// Publishing after a subscription is gone must not send on its closed channel.
+	err := bus.Publish(context.Background(), nil, "movie.changed", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBusClose(t *testing.T) {
+	bus := NewMemory()
+
+	sub := bus.Subscribe("movie.changed")
+	bus.Close()
+
+	if _, ok := <-sub.C; ok {
+		t.Fatal("subscription still open after the bus closed")
+	}
+	sub.Close()
+
+	late := bus.Subscribe("movie.changed")
+	if _, ok := <-late.C; ok {
+		t.Fatal("subscription to a closed bus is open")
+	}
+	late.Close()
+}
+
+type execRecorder struct {
+	query string
+	args  []any
+}
+
+func (e *execRecorder) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.query = query
+	e.args = args
+	return nil, nil
+}
+
+func TestPostgresPublish(t *testing.T) {
+	bus := &Postgres{}
+
+	var exec execRecorder
+	err := bus.Publish(context.Background(), &exec, "movie.changed", map[string]int{"id": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(exec.query, "pg_notify") {
+		t.Errorf("query = %q, want a pg_notify call", exec.query)
+	}
+	if len(exec.args) != 2 || exec.args[0] != Channel {
+		t.Fatalf("args = %v, want the channel and the payload", exec.args)
+	}
+	if payload := exec.args[1].(string); payload != `{"topic":"movie.changed","data":{"id":1}}` {
+		t.Errorf("payload = %s", payload)
+	}
+}
+
+func TestPostgresPublishPayloadLimit(t *testing.T) {
+	bus := &Postgres{}
+
+	// The payload wraps the data in {"topic":"t","data":"..."}.
+	const overhead = len(`{"topic":"t","data":""}`)
+
+	var exec execRecorder
+	err := bus.Publish(context.Background(), &exec, "t", strings.Repeat("a", maxPayloadSize-overhead))
+	if err != nil {
+		t.Fatalf("payload of exactly %d bytes rejected: %v", maxPayloadSize, err)
+	}
+
+	exec = execRecorder{}
+	err = bus.Publish(context.Background(), &exec, "t", strings.Repeat("a", maxPayloadSize-overhead+1))
+	if err == nil {
+		t.Fatalf("payload over %d bytes accepted", maxPayloadSize)
+	}
+	if exec.query != "" {
+		t.Error("oversized event was sent to the database")
+	}
+}
+
+func TestPostgresNotifications(t *testing.T) {
+	notify := make(chan *pq.Notification)
+
+	bus := &Postgres{
+		hub:      newHub(),
+		listener: &pq.Listener{Notify: notify},
+		logger:   slog.New(slog.DiscardHandler),
+		done:     make(chan struct{}),
+	}
+	go bus.run()
+
+	sub := bus.Subscribe("movie.changed")
+
+	notify <- &pq.Notification{Channel: Channel, Extra: `{"topic":"movie.changed","data":{"id":7}}`}
+	if event := receive(t, sub); event.Topic != "movie.changed" || string(event.Data) != `{"id":7}` {
+		t.Errorf("received %s %s", event.Topic, event.Data)
+	}
+
+	// A malformed notification is dropped without stopping the bus.
+	notify <- &pq.Notification{Channel: Channel, Extra: `not json`}
+
+	// The listener sends nil after it reconnected.
+	notify <- nil
+	if event := receive(t, sub); event.Topic != TopicResync {
+		t.Errorf("topic = %q after a reconnect, want %q", event.Topic, TopicResync)
+	}
+
+	close(notify)
+	<-bus.done
+
+	if _, ok := <-sub.C; ok {
+		t.Error("subscription still open after the listener stopped")
+	}
+}
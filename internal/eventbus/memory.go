@@ -0,0 +1,34 @@
+package eventbus
+
+import "context"
+
+// Memory is a Bus that delivers events to the subscribers in the same process only. It
+// stands in for the Postgres bus in tests and single-process setups. Events are
+// delivered as soon as they are published, without waiting for a transaction passed
+// to Publish to commit.
+type Memory struct {
+	hub *hub
+}
+
+func NewMemory() *Memory {
+	return &Memory{hub: newHub()}
+}
+
+func (b *Memory) Publish(ctx context.Context, q Execer, topic string, data any) error {
+	event, _, err := encode(topic, data)
+	if err != nil {
+		return err
+	}
+
+	b.hub.broadcast(event)
+	return nil
+}
+
+func (b *Memory) Subscribe(topics ...string) *Subscription {
+	return b.hub.subscribe(topics)
+}
+
+func (b *Memory) Close() error {
+	b.hub.close()
+	return nil
+}
@@ -0,0 +1,125 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Channel is the Postgres notification channel that events are sent on.
+const Channel = "gmoapi_events"
+
+// maxPayloadSize is the largest payload that NOTIFY accepts, in bytes.
+const maxPayloadSize = 8000
+
+// pingInterval is how long the listener may go without notifications before its
+// connection is checked, so that a broken connection is noticed and re-established.
+const pingInterval = 90 * time.Second
+
+// Postgres is a Bus that publishes events with NOTIFY and receives them with LISTEN, so
+// that they reach the subscribers of every process connected to the database.
+type Postgres struct {
+	hub      *hub
+	listener *pq.Listener
+	logger   *slog.Logger
+	done     chan struct{}
+}
+
+// NewPostgres opens a dedicated listening connection to the database at dsn. The
+// connection is re-established automatically when it is lost, after which the
+// subscribers receive TopicResync.
+func NewPostgres(dsn string, logger *slog.Logger) (*Postgres, error) {
+	b := &Postgres{
+		hub:    newHub(),
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+
+	b.listener = pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			logger.Error("event bus disconnected", "error", err)
+		case pq.ListenerEventConnectionAttemptFailed:
+			logger.Error("event bus failed to reconnect", "error", err)
+		case pq.ListenerEventReconnected:
+			logger.Info("event bus reconnected")
+		}
+	})
+
+	err := b.listener.Listen(Channel)
+	if err != nil {
+		b.listener.Close()
+		return nil, err
+	}
+
+	go b.run()
+
+	return b, nil
+}
+
+func (b *Postgres) run() {
+	defer close(b.done)
+	defer b.hub.close()
+
+	ping := time.NewTimer(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case n, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+
+			// A nil notification follows a reconnect, notifications sent while the
+			// connection was down are lost.
+			if n == nil {
+				b.hub.broadcast(Event{Topic: TopicResync})
+				break
+			}
+
+			var event Event
+			err := json.Unmarshal([]byte(n.Extra), &event)
+			if err != nil {
+				b.logger.Error("event bus received a malformed event", "error", err)
+				break
+			}
+			b.hub.broadcast(event)
+
+		case <-ping.C:
+			// A failed ping makes the listener reconnect by itself.
+			go b.listener.Ping()
+		}
+
+		ping.Reset(pingInterval)
+	}
+}
+
+func (b *Postgres) Publish(ctx context.Context, q Execer, topic string, data any) error {
+	_, payload, err := encode(topic, data)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) > maxPayloadSize {
+		return fmt.Errorf("eventbus: %s event of %d bytes exceeds the NOTIFY limit", topic, len(payload))
+	}
+
+	_, err = q.ExecContext(ctx, `SELECT pg_notify($1, $2)`, Channel, string(payload))
+	return err
+}
+
+func (b *Postgres) Subscribe(topics ...string) *Subscription {
+	return b.hub.subscribe(topics)
+}
+
+// Close closes the listening connection and all subscriptions.
+func (b *Postgres) Close() error {
+	err := b.listener.Close()
+	<-b.done
+	return err
+}
@@ -0,0 +1,154 @@
+package imaging
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+
+	// Refuse to decode images larger than this many pixels, to protect against
+	// decompression bombs that are small on the wire but huge in memory.
+	maxPixels = 50_000_000
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrImageTooLarge     = errors.New("image dimensions too large")
+)
+
+// Decode sniffs the content type of the data read from r, and decodes it if it is a
+// JPEG or PNG image. It returns the decoded image along with its format.
+func Decode(r io.ReadSeeker) (image.Image, string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, "", ErrUnsupportedFormat
+	}
+
+	var format string
+	switch http.DetectContentType(head[:n]) {
+	case "image/jpeg":
+		format = FormatJPEG
+	case "image/png":
+		format = FormatPNG
+	default:
+		return nil, "", ErrUnsupportedFormat
+	}
+
+	// Read just the header first, so that we can check the dimensions before
+	// allocating memory for the pixels.
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.Width*cfg.Height > maxPixels {
+		return nil, "", ErrImageTooLarge
+	}
+
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, "", err
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return img, format, nil
+}
+
+// Encode writes the image to w in the given format.
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	case FormatPNG:
+		return png.Encode(w, img)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// ContentType returns the MIME type for the given format.
+func ContentType(format string) string {
+	switch format {
+	case FormatJPEG:
+		return "image/jpeg"
+	case FormatPNG:
+		return "image/png"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Extension returns the file extension, without the leading dot, for the given format.
+func Extension(format string) string {
+	switch format {
+	case FormatJPEG:
+		return "jpg"
+	default:
+		return format
+	}
+}
+
+// Resize scales the image down so that it is at most width pixels wide, keeping the
+// aspect ratio. Images that are already narrow enough are returned unchanged. Every
+// destination pixel is the average of the source pixels it covers (a box filter),
+// which gives good results for downscaling.
+func Resize(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	if width <= 0 || width >= srcW {
+		return src
+	}
+
+	height := max(1, srcH*width/srcW)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/height)
+
+		for x := range width {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.RGBA64Model.Convert(src.At(sx, sy)).(color.RGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
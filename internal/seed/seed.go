@@ -0,0 +1,410 @@
+// Package seed generates sample data for development databases and for tests: a genre
+// taxonomy, movies, and users with permissions and tokens. Everything is generated from
+// a fixed random seed, so the same options always give the same fixtures, down to the
+// plaintext of the tokens.
+//
+// Generate builds the fixtures in memory, Load inserts them into a database:
+//
+//	fixtures, err := seed.Generate(seed.DefaultOptions)
+//	if err != nil {
+//		return err
+//	}
+//	err = seed.Load(models, fixtures)
+package seed
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// Password is the password of every seeded user.
+const Password = "pa55word"
+
+// TokenTTL is how long the seeded tokens are valid, counted from when they are
+// generated.
+const TokenTTL = 30 * 24 * time.Hour
+
+// baseYear is the last year that seeded movies were released in. It is fixed rather
+// than the current year so that the fixtures don't change over time.
+const baseYear = 2025
+
+// Movies and users are drawn from separate streams of the random generator, so that
+// the users, and their tokens, don't change with the number of movies.
+const (
+	streamMovies = 1
+	streamUsers  = 2
+)
+
+// Options selects how much data is generated.
+type Options struct {
+	// Seed is the seed of the random generator, fixtures generated from the same seed
+	// are the same.
+	Seed uint64
+
+	// Movies is the number of movies to generate.
+	Movies int
+
+	// Users is the number of users to generate besides the users of Roles.
+	Users int
+}
+
+// DefaultOptions are the options of the seed command when no flags are given.
+var DefaultOptions = Options{Seed: 1, Movies: 100, Users: 10}
+
+// Role describes a user that is always seeded, so that there is a user with each set of
+// permissions to sign in as.
+type Role struct {
+	Name        string
+	Email       string
+	Permissions data.Permissions
+	Activated   bool
+}
+
+// Roles are the users that are seeded regardless of the options.
+var Roles = []Role{
+	{
+		Name:        "Admin",
+		Email:       "admin@gmoapi.test",
+		Permissions: data.Permissions{"movies:read", "movies:write", "movies:merge", "genres:write", "webhooks:manage", "metrics:read"},
+		Activated:   true,
+	},
+	{
+		Name:        "Editor",
+		Email:       "editor@gmoapi.test",
+		Permissions: data.Permissions{"movies:read", "movies:write"},
+		Activated:   true,
+	},
+	{
+		Name:        "Reader",
+		Email:       "reader@gmoapi.test",
+		Permissions: data.Permissions{"movies:read"},
+		Activated:   true,
+	},
+	{
+		Name:        "Inactive",
+		Email:       "inactive@gmoapi.test",
+		Permissions: data.Permissions{"movies:read"},
+		Activated:   false,
+	},
+}
+
+// User is a seeded user along with what is needed to act as them: their password and
+// the plaintext of their tokens.
+type User struct {
+	*data.User
+	Password    string
+	Permissions data.Permissions
+
+	// Tokens holds an authentication token for activated users, and an activation
+	// token for the others.
+	Tokens []*data.Token
+}
+
+// Token returns the token of the user with the given scope, or nil.
+func (u *User) Token(scope string) *data.Token {
+	for _, token := range u.Tokens {
+		if token.Scope == scope {
+			return token
+		}
+	}
+	return nil
+}
+
+// Fixtures is the data generated for a set of options.
+type Fixtures struct {
+	Genres []*data.Genre
+	Movies []*data.Movie
+	Users  []*User
+}
+
+// User returns the user with the email address, or nil.
+func (f *Fixtures) User(email string) *User {
+	for _, user := range f.Users {
+		if user.Email == email {
+			return user
+		}
+	}
+	return nil
+}
+
+// Generate generates the fixtures for the options. Only the expiry of the tokens and
+// the password hashes, which are salted, differ between calls with the same options.
+func Generate(opts Options) (*Fixtures, error) {
+	f := &Fixtures{
+		Genres: generateGenres(),
+	}
+
+	taxonomy := data.NewGenreTaxonomy(f.Genres)
+	g := &generator{
+		rng:    rand.New(rand.NewPCG(opts.Seed, streamMovies)),
+		titles: make(map[string]bool),
+		imdb:   make(map[string]bool),
+		tmdb:   make(map[int64]bool),
+	}
+
+	for range opts.Movies {
+		movie := g.movie(f.Genres)
+
+		v := validator.New()
+		if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+			return nil, fmt.Errorf("seed: generated an invalid movie %q: %v", movie.Title, v.Errors)
+		}
+
+		f.Movies = append(f.Movies, movie)
+	}
+
+	g.rng = rand.New(rand.NewPCG(opts.Seed, streamUsers))
+
+	for _, role := range Roles {
+		user, err := g.user(role.Name, role.Email, role.Permissions, role.Activated)
+		if err != nil {
+			return nil, err
+		}
+		f.Users = append(f.Users, user)
+	}
+
+	for i := range opts.Users {
+		first := firstNames[g.rng.IntN(len(firstNames))]
+		last := lastNames[g.rng.IntN(len(lastNames))]
+		email := fmt.Sprintf("%s.%s.%d@example.com", strings.ToLower(first), strings.ToLower(last), i+1)
+
+		// Most people who sign up also activate their account.
+		activated := g.rng.IntN(5) != 0
+
+		user, err := g.user(first+" "+last, email, data.Permissions{"movies:read"}, activated)
+		if err != nil {
+			return nil, err
+		}
+		f.Users = append(f.Users, user)
+	}
+
+	return f, nil
+}
+
+// Load inserts the fixtures into the database. Genres that exist already are kept, but
+// a user that exists already makes Load fail before any user or movie is inserted, as
+// the database has been seeded before.
+func Load(models data.Models, f *Fixtures) error {
+	for _, genre := range f.Genres {
+		err := models.Genres.Insert(genre)
+		if err != nil && !errors.Is(err, data.ErrDuplicateGenre) {
+			return err
+		}
+	}
+
+	for _, user := range f.Users {
+		_, err := models.Users.GetByEmail(user.Email)
+		switch {
+		case err == nil:
+			return fmt.Errorf("seed: the user %s exists already, the database has been seeded before", user.Email)
+		case !errors.Is(err, data.ErrRecordNotFound):
+			return err
+		}
+	}
+
+	for _, user := range f.Users {
+		err := models.Users.Insert(user.User)
+		if err != nil {
+			return err
+		}
+
+		err = models.Permissions.AddForUser(user.ID, user.Permissions...)
+		if err != nil {
+			return err
+		}
+
+		for _, token := range user.Tokens {
+			token.UserID = user.ID
+
+			err = models.Tokens.Insert(token)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, movie := range f.Movies {
+		err := models.Movies.Insert(movie)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateGenres() []*data.Genre {
+	genres := make([]*data.Genre, len(genreNames))
+	for i, name := range genreNames {
+		genres[i] = &data.Genre{
+			Slug:    data.GenreSlug(name),
+			Name:    name,
+			Aliases: slices.Clone(genreAliases[name]),
+		}
+		if genres[i].Aliases == nil {
+			genres[i].Aliases = []string{}
+		}
+	}
+	return genres
+}
+
+// generator draws the fixtures from the random generator, keeping the values that must
+// be unique apart.
+type generator struct {
+	rng    *rand.Rand
+	titles map[string]bool
+	imdb   map[string]bool
+	tmdb   map[int64]bool
+}
+
+func (g *generator) movie(genres []*data.Genre) *data.Movie {
+	movie := &data.Movie{
+		Title:  g.title(),
+		Status: data.StatusReleased,
+	}
+
+	// One movie in ten hasn't been released yet.
+	switch g.rng.IntN(20) {
+	case 0:
+		movie.Status = data.StatusAnnounced
+	case 1:
+		movie.Status = data.StatusInProduction
+	}
+
+	if movie.Status == data.StatusReleased {
+		movie.Year = int32(1950 + g.rng.IntN(baseYear-1950+1))
+		movie.Runtime = data.Runtime(80 + g.rng.IntN(111))
+	} else {
+		movie.Year = int32(baseYear + 1 + g.rng.IntN(4))
+		// The runtime of an unreleased movie is often not known yet.
+		if g.rng.IntN(2) == 0 {
+			movie.Runtime = data.Runtime(90 + g.rng.IntN(71))
+		}
+	}
+
+	released := time.Date(int(movie.Year), time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, g.rng.IntN(365))
+	movie.ReleaseDate = data.NewDate(released)
+
+	picked := make([]string, 0, 3)
+	for _, i := range g.rng.Perm(len(genres))[:1+g.rng.IntN(3)] {
+		picked = append(picked, genres[i].Slug)
+	}
+	movie.Genres = picked
+
+	if movie.Status == data.StatusReleased {
+		movie.ExternalIDs.IMDb = g.imdbID()
+		movie.ExternalIDs.TMDb = g.tmdbID()
+	}
+
+	return movie
+}
+
+// title returns a title that no other generated movie has.
+func (g *generator) title() string {
+	for {
+		adjective := adjectives[g.rng.IntN(len(adjectives))]
+		noun := nouns[g.rng.IntN(len(nouns))]
+		other := nouns[g.rng.IntN(len(nouns))]
+
+		var title string
+		switch g.rng.IntN(6) {
+		case 0:
+			title = "The " + adjective + " " + noun
+		case 1:
+			title = noun + " of the " + other
+		case 2:
+			title = adjective + " " + noun
+		case 3:
+			title = "The " + noun + " and the " + other
+		case 4:
+			title = "The Last " + noun
+		default:
+			title = places[g.rng.IntN(len(places))] + " " + noun
+		}
+
+		// A few movies are sequels.
+		if g.rng.IntN(12) == 0 {
+			title += fmt.Sprintf(" %d", 2+g.rng.IntN(3))
+		}
+
+		if !g.titles[title] {
+			g.titles[title] = true
+			return title
+		}
+	}
+}
+
+func (g *generator) imdbID() string {
+	for {
+		id := fmt.Sprintf("tt%07d", 100000+g.rng.IntN(9900000))
+		if !g.imdb[id] {
+			g.imdb[id] = true
+			return id
+		}
+	}
+}
+
+func (g *generator) tmdbID() int64 {
+	for {
+		id := int64(1 + g.rng.IntN(999999))
+		if !g.tmdb[id] {
+			g.tmdb[id] = true
+			return id
+		}
+	}
+}
+
+func (g *generator) user(name, email string, permissions data.Permissions, activated bool) (*User, error) {
+	user := &User{
+		User: &data.User{
+			Name:      name,
+			Email:     email,
+			Activated: activated,
+		},
+		Password:    Password,
+		Permissions: slices.Clone(permissions),
+	}
+
+	err := user.User.Password.Set(Password)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := data.ScopeAuthentication
+	if !activated {
+		scope = data.ScopeActivation
+	}
+	user.Tokens = append(user.Tokens, g.token(scope))
+
+	return user, nil
+}
+
+// tokenAlphabet holds the characters of token plaintexts, the same as those of the
+// tokens the API issues.
+const tokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// token returns a token with a plaintext drawn from the random generator, so that tests
+// can sign in with it. The user id is set when the token is loaded.
+func (g *generator) token(scope string) *data.Token {
+	plaintext := make([]byte, 26)
+	for i := range plaintext {
+		plaintext[i] = tokenAlphabet[g.rng.IntN(len(tokenAlphabet))]
+	}
+
+	hash := sha256.Sum256(plaintext)
+
+	return &data.Token{
+		Plaintext: string(plaintext),
+		Hash:      hash[:],
+		Expiry:    time.Now().Add(TokenTTL),
+		Scope:     scope,
+	}
+}
@@ -0,0 +1,166 @@
+package seed
+
+import (
+	"crypto/sha256"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ucok-man/gmoapi/internal/data"
+	"github.com/ucok-man/gmoapi/internal/validator"
+)
+
+// testOptions keep the number of users small, as hashing their passwords is slow.
+var testOptions = Options{Seed: 7, Movies: 50, Users: 1}
+
+func generate(t *testing.T, opts Options) *Fixtures {
+	t.Helper()
+
+	f, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// sameUsers compares the users, down to the plaintext of their tokens, but not the
+// password hashes and token expiries that differ between calls.
+func sameUsers(t *testing.T, a, b []*User) {
+	t.Helper()
+
+	if len(a) != len(b) {
+		t.Fatalf("%d users, then %d", len(a), len(b))
+	}
+
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Email != b[i].Email || a[i].Activated != b[i].Activated {
+			t.Errorf("user %d is %q <%s>, then %q <%s>", i, a[i].Name, a[i].Email, b[i].Name, b[i].Email)
+		}
+		if !slices.Equal(a[i].Permissions, b[i].Permissions) {
+			t.Errorf("user %s has permissions %v, then %v", a[i].Email, a[i].Permissions, b[i].Permissions)
+		}
+		if len(a[i].Tokens) != len(b[i].Tokens) {
+			t.Fatalf("user %s has %d tokens, then %d", a[i].Email, len(a[i].Tokens), len(b[i].Tokens))
+		}
+		for j := range a[i].Tokens {
+			if a[i].Tokens[j].Plaintext != b[i].Tokens[j].Plaintext || a[i].Tokens[j].Scope != b[i].Tokens[j].Scope {
+				t.Errorf("user %s has token %s, then %s", a[i].Email, a[i].Tokens[j].Plaintext, b[i].Tokens[j].Plaintext)
+			}
+		}
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	a := generate(t, testOptions)
+	b := generate(t, testOptions)
+
+	if !reflect.DeepEqual(a.Genres, b.Genres) {
+		t.Error("the genres differ between calls with the same options")
+	}
+	if !reflect.DeepEqual(a.Movies, b.Movies) {
+		t.Error("the movies differ between calls with the same options")
+	}
+	sameUsers(t, a.Users, b.Users)
+}
+
+func TestGenerateSeeds(t *testing.T) {
+	t.Parallel()
+
+	a := generate(t, testOptions)
+
+	other := testOptions
+	other.Seed++
+	b := generate(t, other)
+
+	if reflect.DeepEqual(a.Movies, b.Movies) {
+		t.Error("different seeds generated the same movies")
+	}
+}
+
+func TestGenerateUsersDontDependOnMovies(t *testing.T) {
+	t.Parallel()
+
+	a := generate(t, testOptions)
+
+	more := testOptions
+	more.Movies *= 2
+	b := generate(t, more)
+
+	if !reflect.DeepEqual(a.Movies, b.Movies[:len(a.Movies)]) {
+		t.Error("generating more movies changed the first ones")
+	}
+	sameUsers(t, a.Users, b.Users)
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	t.Parallel()
+
+	f := generate(t, testOptions)
+
+	if len(f.Movies) != testOptions.Movies {
+		t.Errorf("%d movies, want %d", len(f.Movies), testOptions.Movies)
+	}
+	if len(f.Users) != len(Roles)+testOptions.Users {
+		t.Errorf("%d users, want %d", len(f.Users), len(Roles)+testOptions.Users)
+	}
+
+	taxonomy := data.NewGenreTaxonomy(f.Genres)
+	titles := make(map[string]bool)
+	imdb := make(map[string]bool)
+
+	for _, movie := range f.Movies {
+		v := validator.New()
+		if data.ValidateMovie(v, movie, taxonomy); !v.Valid() {
+			t.Errorf("movie %q is invalid: %v", movie.Title, v.Errors)
+		}
+
+		if titles[movie.Title] {
+			t.Errorf("title %q is generated twice", movie.Title)
+		}
+		titles[movie.Title] = true
+
+		if id := movie.ExternalIDs.IMDb; id != "" {
+			if imdb[id] {
+				t.Errorf("IMDb id %s is generated twice", id)
+			}
+			imdb[id] = true
+		}
+	}
+
+	for _, role := range Roles {
+		user := f.User(role.Email)
+		if user == nil {
+			t.Errorf("no user for the %s role", role.Name)
+			continue
+		}
+
+		scope := data.ScopeAuthentication
+		if !role.Activated {
+			scope = data.ScopeActivation
+		}
+
+		token := user.Token(scope)
+		if token == nil {
+			t.Errorf("user %s has no %s token", role.Email, scope)
+			continue
+		}
+		if hash := sha256.Sum256([]byte(token.Plaintext)); !slices.Equal(token.Hash, hash[:]) {
+			t.Errorf("the hash of the token of %s doesn't match its plaintext", role.Email)
+		}
+
+		v := validator.New()
+		if data.ValidateTokenPlaintext(v, token.Plaintext); !v.Valid() {
+			t.Errorf("token %s of %s is invalid: %v", token.Plaintext, role.Email, v.Errors)
+		}
+	}
+
+	ok, err := f.Users[0].User.Password.Matches(Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("the password of a seeded user doesn't match Password")
+	}
+}
@@ -0,0 +1,47 @@
+package seed
+
+// genreNames are the genres of the seeded taxonomy, the genres of the sample movies
+// that were once part of the migrations.
+var genreNames = []string{
+	"Action", "Adventure", "Animation", "Biography", "Comedy", "Crime", "Drama",
+	"Family", "Fantasy", "Film-Noir", "History", "Horror", "Music", "Musical",
+	"Mystery", "Romance", "Sci-Fi", "Thriller", "War", "Western",
+}
+
+var genreAliases = map[string][]string{
+	"Biography": {"Biopic"},
+	"Sci-Fi":    {"Science Fiction", "SF"},
+	"War":       {"War Film"},
+}
+
+var adjectives = []string{
+	"Silent", "Broken", "Hidden", "Golden", "Crimson", "Endless", "Forgotten",
+	"Burning", "Frozen", "Wild", "Lonely", "Secret", "Shattered", "Electric",
+	"Midnight", "Distant", "Savage", "Quiet", "Restless", "Fallen", "Hollow",
+	"Brave", "Velvet", "Iron", "Paper", "Neon", "Last", "Second", "Lost", "Bright",
+}
+
+var nouns = []string{
+	"Harbor", "Kingdom", "Horizon", "Garden", "River", "Mirror", "Empire", "Storm",
+	"Promise", "Frontier", "Shadow", "Voyage", "Letter", "Summer", "Winter",
+	"Station", "Witness", "Orchard", "Island", "Signal", "Crown", "Lighthouse",
+	"Canyon", "Carnival", "Sparrow", "Comet", "Labyrinth", "Heist", "Symphony",
+	"Detective", "Stranger", "Machine", "Desert", "Tide", "Night", "Ballad",
+}
+
+var places = []string{
+	"Brooklyn", "Casablanca", "Tokyo", "Marseille", "Havana", "Berlin", "Bombay",
+	"Lisbon", "Manhattan", "Saigon", "Vienna", "Chinatown", "Dakota", "Sahara",
+}
+
+var firstNames = []string{
+	"Alice", "Bima", "Carlos", "Dewi", "Elena", "Farhan", "Grace", "Hiro", "Ines",
+	"Jonas", "Kartika", "Liam", "Maya", "Noah", "Olivia", "Putri", "Rafael", "Sari",
+	"Tomas", "Uma", "Viktor", "Wulan", "Yusuf", "Zara",
+}
+
+var lastNames = []string{
+	"Anderson", "Baptiste", "Chen", "Dubois", "Evans", "Fischer", "Gunawan",
+	"Hernandez", "Ivanova", "Johansson", "Kusuma", "Larsen", "Martin", "Nakamura",
+	"Okafor", "Pratama", "Rossi", "Santoso", "Tanaka", "Wijaya",
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage is the interface that file storage backends must implement. Objects are
+// addressed by slash-separated keys such as "movies/1/poster/w500.jpg".
+type Storage interface {
+	// Put stores the content read from r under the given key, replacing any object
+	// already stored there.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Delete removes the object stored under the given key. Deleting a key that
+	// doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the public URL at which the object stored under key is served.
+	URL(key string) string
+
+	// Key is the inverse of URL. It reports false if the URL wasn't produced by
+	// this storage backend.
+	Key(url string) (string, bool)
+}
@@ -0,0 +1,144 @@
+// Package webhook signs and sends webhook deliveries, and lets receivers verify them.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The headers sent along with every delivery.
+const (
+	HeaderEvent     = "Gmoapi-Event"
+	HeaderDelivery  = "Gmoapi-Delivery"
+	HeaderTimestamp = "Gmoapi-Timestamp"
+	HeaderSignature = "Gmoapi-Signature"
+)
+
+// signaturePrefix names the algorithm of a signature, as in "sha256=<hex>".
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of a delivery, the HMAC-SHA256 of "<timestamp>.<body>"
+// keyed with the secret of the webhook. The timestamp is part of the signed content so
+// that receivers can reject old deliveries that are replayed.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature matches the timestamp and body, and the
+// timestamp is no older than tolerance. A tolerance of zero skips the age check.
+func Verify(secret string, timestamp int64, body []byte, signature string, tolerance time.Duration) bool {
+	if tolerance > 0 && time.Since(time.Unix(timestamp, 0)).Abs() > tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Message is a single delivery to be sent to a webhook.
+type Message struct {
+	DeliveryID int64
+	Event      string
+	URL        string
+	Secret     string
+	Payload    []byte
+}
+
+// Result describes the outcome of sending a message. StatusCode is zero when no
+// response was received.
+type Result struct {
+	StatusCode int
+	Err        error
+}
+
+// OK reports whether the receiver accepted the delivery.
+func (r Result) OK() bool {
+	return r.Err == nil
+}
+
+type Sender struct {
+	client *http.Client
+}
+
+// New returns a Sender whose requests time out after timeout. Redirects are not
+// followed, receivers have to respond at the URL that was registered.
+func New(timeout time.Duration) *Sender {
+	return NewWithClient(&http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	})
+}
+
+// NewWithClient returns a Sender that sends its requests with client.
+func NewWithClient(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// Send POSTs the payload of the message to its URL. Any 2xx response counts as a
+// successful delivery.
+func (s *Sender) Send(ctx context.Context, msg Message) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.URL, bytes.NewReader(msg.Payload))
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gmoapi-webhook")
+	req.Header.Set(HeaderEvent, msg.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(msg.DeliveryID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(msg.Secret, timestamp, msg.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return Result{Err: err}
+	}
+	defer res.Body.Close()
+
+	// Drain a little of the body so that the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return Result{StatusCode: res.StatusCode, Err: fmt.Errorf("receiver responded with %s", res.Status)}
+	}
+
+	return Result{StatusCode: res.StatusCode}
+}
+
+// Backoff returns how long to wait before retrying a delivery that has failed the
+// given number of times: 30s, 1m, 2m, 4m and so on, up to 6 hours.
+func Backoff(attempts int) time.Duration {
+	const (
+		base    = 30 * time.Second
+		maximum = 6 * time.Hour
+	)
+
+	if attempts < 1 {
+		return base
+	}
+
+	d := base
+	for range attempts - 1 {
+		d *= 2
+		if d >= maximum {
+			return maximum
+		}
+	}
+	return d
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"movie.created"}`)
+	now := time.Now().Unix()
+
+	signature := Sign(secret, now, body)
+
+	if !strings.HasPrefix(signature, "sha256=") {
+		t.Fatalf("signature %q has no sha256= prefix", signature)
+	}
+	if Sign(secret, now, body) != signature {
+		t.Fatal("signing the same content twice gave different signatures")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+		tolerance time.Duration
+		want      bool
+	}{
+		{"valid", secret, now, body, signature, 5 * time.Minute, true},
+		{"valid without age check", secret, now, body, signature, 0, true},
+		{"wrong secret", "whsec_other", now, body, signature, 5 * time.Minute, false},
+		{"tampered body", secret, now, []byte(`{"event":"movie.deleted"}`), signature, 5 * time.Minute, false},
+		{"tampered timestamp", secret, now + 1, body, signature, 5 * time.Minute, false},
+		{"missing prefix", secret, now, body, strings.TrimPrefix(signature, "sha256="), 5 * time.Minute, false},
+		{"empty signature", secret, now, body, "", 5 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Verify(tt.secret, tt.timestamp, tt.body, tt.signature, tt.tolerance)
+			if got != tt.want {
+				t.Errorf("Verify() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyTolerance(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{}`)
+
+	old := time.Now().Add(-10 * time.Minute).Unix()
+	signature := Sign(secret, old, body)
+
+	if Verify(secret, old, body, signature, 5*time.Minute) {
+		t.Error("a delivery older than the tolerance was accepted")
+	}
+	if !Verify(secret, old, body, signature, 15*time.Minute) {
+		t.Error("a delivery within the tolerance was rejected")
+	}
+	if !Verify(secret, old, body, signature, 0) {
+		t.Error("a zero tolerance still checked the age")
+	}
+
+	future := time.Now().Add(10 * time.Minute).Unix()
+	if Verify(secret, future, body, Sign(secret, future, body), 5*time.Minute) {
+		t.Error("a delivery from too far in the future was accepted")
+	}
+}
+
+func TestSend(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"event":"movie.created","data":{"movie":{"id":1}}}`)
+
+	var got *http.Request
+	var gotBody []byte
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	result := New(5*time.Second).Send(context.Background(), Message{
+		DeliveryID: 42,
+		Event:      "movie.created",
+		URL:        receiver.URL,
+		Secret:     secret,
+		Payload:    payload,
+	})
+
+	if !result.OK() {
+		t.Fatalf("Send() failed: %v", result.Err)
+	}
+	if result.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %d, want %d", result.StatusCode, http.StatusNoContent)
+	}
+
+	if got.Method != http.MethodPost {
+		t.Errorf("method = %s, want POST", got.Method)
+	}
+	if string(gotBody) != string(payload) {
+		t.Errorf("body = %s, want %s", gotBody, payload)
+	}
+	if ct := got.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if event := got.Header.Get(HeaderEvent); event != "movie.created" {
+		t.Errorf("%s = %q, want movie.created", HeaderEvent, event)
+	}
+	if id := got.Header.Get(HeaderDelivery); id != "42" {
+		t.Errorf("%s = %q, want 42", HeaderDelivery, id)
+	}
+
+	timestamp, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s is not a unix time: %v", HeaderTimestamp, err)
+	}
+	if !Verify(secret, timestamp, gotBody, got.Header.Get(HeaderSignature), time.Minute) {
+		t.Error("the receiver could not verify the signature")
+	}
+}
+
+func TestSendFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "client error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusGone)
+			},
+			wantStatus: http.StatusGone,
+		},
+		{
+			name: "redirect is not followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/elsewhere", http.StatusFound)
+			},
+			wantStatus: http.StatusFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := httptest.NewServer(tt.handler)
+			defer receiver.Close()
+
+			result := New(5*time.Second).Send(context.Background(), Message{URL: receiver.URL, Payload: []byte(`{}`)})
+
+			if result.OK() {
+				t.Fatal("Send() succeeded, want a failure")
+			}
+			if result.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", result.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSendTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer receiver.Close()
+	defer close(release)
+
+	result := New(50*time.Millisecond).Send(context.Background(), Message{URL: receiver.URL, Payload: []byte(`{}`)})
+
+	if result.OK() {
+		t.Fatal("Send() succeeded, want a timeout")
+	}
+	if result.StatusCode != 0 {
+		t.Errorf("StatusCode = %d, want 0 when no response was received", result.StatusCode)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{8, 64 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
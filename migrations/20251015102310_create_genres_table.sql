@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS genres (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    slug text UNIQUE NOT NULL,
+    name text NOT NULL,
+    aliases text[] NOT NULL DEFAULT '{}',
+    version integer NOT NULL DEFAULT 1
+);
+
+-- Build the taxonomy from the free-text genres that are already in use. Values that
+-- only differ in case or punctuation (e.g. "Sci-Fi" and "sci fi") share one slug, and
+-- the spellings that weren't picked as the display name are kept as aliases.
+WITH used AS (
+    SELECT DISTINCT g AS value,
+        trim(BOTH '-' FROM regexp_replace(lower(g), '[^a-z0-9]+', '-', 'g')) AS slug
+    FROM movies, unnest(genres) AS g
+)
+INSERT INTO genres (slug, name, aliases)
+SELECT slug, min(value), array_remove(array_agg(value ORDER BY value), min(value))
+FROM used
+WHERE slug <> ''
+GROUP BY slug;
+
+-- Backfill the movies with the canonical slugs, dropping duplicates while keeping the
+-- original order.
+UPDATE movies SET genres = ARRAY(
+    SELECT slug
+    FROM (
+        SELECT trim(BOTH '-' FROM regexp_replace(lower(g), '[^a-z0-9]+', '-', 'g')) AS slug, i
+        FROM unnest(genres) WITH ORDINALITY AS u(g, i)
+    ) AS normalized
+    GROUP BY slug
+    ORDER BY min(i)
+);
+
+INSERT INTO permissions (code)
+VALUES ('genres:write');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE movies SET genres = ARRAY(
+    SELECT COALESCE(genres.name, u.g)
+    FROM unnest(movies.genres) WITH ORDINALITY AS u(g, i)
+    LEFT JOIN genres ON genres.slug = u.g
+    ORDER BY i
+);
+
+DELETE FROM permissions WHERE code = 'genres:write';
+DROP TABLE IF EXISTS genres;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS movie_titles (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    movie_id bigint NOT NULL REFERENCES movies ON DELETE CASCADE,
+    title text NOT NULL,
+    language text NOT NULL,
+    region text NOT NULL DEFAULT '',
+    type text NOT NULL DEFAULT 'translated',
+    CONSTRAINT movie_titles_language_check CHECK (language ~ '^[a-z]{2}$'),
+    CONSTRAINT movie_titles_region_check CHECK (region = '' OR region ~ '^[A-Z]{2}$'),
+    CONSTRAINT movie_titles_type_check CHECK (type IN ('original', 'working', 'translated'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS movie_titles_unique_idx ON movie_titles (movie_id, language, region, type, title);
+CREATE INDEX IF NOT EXISTS movie_titles_title_idx ON movie_titles USING GIN (to_tsvector('simple', title));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS movie_titles;
+-- +goose StatementEnd
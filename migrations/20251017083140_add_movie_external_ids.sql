@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS imdb_id text UNIQUE;
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS tmdb_id bigint UNIQUE;
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS wikidata_id text UNIQUE;
+
+ALTER TABLE movies ADD CONSTRAINT movies_imdb_id_check CHECK (imdb_id ~ '^tt[0-9]{7,10}$');
+ALTER TABLE movies ADD CONSTRAINT movies_tmdb_id_check CHECK (tmdb_id > 0);
+ALTER TABLE movies ADD CONSTRAINT movies_wikidata_id_check CHECK (wikidata_id ~ '^Q[1-9][0-9]*$');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE movies DROP COLUMN IF EXISTS imdb_id;
+ALTER TABLE movies DROP COLUMN IF EXISTS tmdb_id;
+ALTER TABLE movies DROP COLUMN IF EXISTS wikidata_id;
+-- +goose StatementEnd
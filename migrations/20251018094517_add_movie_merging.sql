@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The title reduced to lowercase letters and digits, so that "The Godfather" and
+-- "the godfather!" are recognised as the same title when looking for duplicates.
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS normalized_title text
+    GENERATED ALWAYS AS (regexp_replace(lower(title), '[^[:alnum:]]+', '', 'g')) STORED;
+
+CREATE INDEX IF NOT EXISTS movies_normalized_title_idx ON movies (normalized_title, year);
+
+-- Movies that were merged into another movie, so that their old ids keep resolving.
+CREATE TABLE IF NOT EXISTS movie_redirects (
+    old_id bigint PRIMARY KEY,
+    movie_id bigint NOT NULL REFERENCES movies ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS movie_redirects_movie_id_idx ON movie_redirects (movie_id);
+
+INSERT INTO permissions (code)
+VALUES ('movies:merge');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM permissions WHERE code = 'movies:merge';
+DROP TABLE IF EXISTS movie_redirects;
+ALTER TABLE movies DROP COLUMN IF EXISTS normalized_title;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- pg_trgm stays installed on the way down, other objects may depend on it.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Records for which version of a movie the similar movies were last computed, so that
+-- stale rankings are recomputed when the movie changes or the ranking gets too old.
+CREATE TABLE IF NOT EXISTS movie_similarity_cache (
+    movie_id bigint PRIMARY KEY REFERENCES movies ON DELETE CASCADE,
+    movie_version integer NOT NULL,
+    computed_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS movie_similarities (
+    movie_id bigint NOT NULL REFERENCES movie_similarity_cache ON DELETE CASCADE,
+    similar_id bigint NOT NULL REFERENCES movies ON DELETE CASCADE,
+    score double precision NOT NULL,
+    PRIMARY KEY (movie_id, similar_id)
+);
+
+CREATE INDEX IF NOT EXISTS movie_similarities_score_idx ON movie_similarities (movie_id, score DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS movie_similarities;
+DROP TABLE IF EXISTS movie_similarity_cache;
+-- +goose StatementEnd
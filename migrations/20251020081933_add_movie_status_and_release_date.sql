@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'released';
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS release_date date;
+
+ALTER TABLE movies ADD CONSTRAINT movies_status_check CHECK (status IN ('announced', 'in_production', 'released'));
+
+-- Only released movies are bound to the current year, announced films may be catalogued
+-- years ahead of their release.
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS movies_year_check;
+ALTER TABLE movies ADD CONSTRAINT movies_year_check CHECK (
+    year >= 1888 AND (status <> 'released' OR year <= date_part('year', now()))
+);
+ALTER TABLE movies ADD CONSTRAINT movies_release_date_check CHECK (
+    release_date IS NULL OR date_part('year', release_date) = year
+);
+
+CREATE INDEX IF NOT EXISTS movies_status_idx ON movies (status);
+CREATE INDEX IF NOT EXISTS movies_release_date_idx ON movies (release_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS movies_release_date_idx;
+DROP INDEX IF EXISTS movies_status_idx;
+
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS movies_release_date_check;
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS movies_year_check;
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS movies_status_check;
+
+-- Movies announced for a future year would violate the original constraint, so it is
+-- only enforced for rows written from now on.
+ALTER TABLE movies ADD CONSTRAINT movies_year_check CHECK (year BETWEEN 1888 AND date_part('year', now())) NOT VALID;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS release_date;
+ALTER TABLE movies DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS movie_releases (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    movie_id bigint NOT NULL REFERENCES movies ON DELETE CASCADE,
+    country text NOT NULL,
+    type text NOT NULL,
+    release_date date NOT NULL,
+    certification text NOT NULL DEFAULT '',
+    CONSTRAINT movie_releases_country_check CHECK (country ~ '^[A-Z]{2}$'),
+    CONSTRAINT movie_releases_type_check CHECK (type IN ('theatrical', 'digital', 'physical'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS movie_releases_unique_idx ON movie_releases (movie_id, country, type);
+CREATE INDEX IF NOT EXISTS movie_releases_certification_idx ON movie_releases (country, certification);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS movie_releases;
+-- +goose StatementEnd
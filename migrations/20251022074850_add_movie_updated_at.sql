@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW();
+UPDATE movies SET updated_at = created_at;
+
+-- Keep updated_at current on every update, including the bulk updates that retag or
+-- merge movies, so that no change slips past clients syncing with updated_since.
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_set_updated_at
+BEFORE UPDATE ON movies
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE INDEX IF NOT EXISTS movies_updated_at_idx ON movies (updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS movies_updated_at_idx;
+DROP TRIGGER IF EXISTS movies_set_updated_at ON movies;
+DROP FUNCTION IF EXISTS set_updated_at();
+ALTER TABLE movies DROP COLUMN IF EXISTS updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS movie_changes (
+    seq bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    movie_id bigint NOT NULL,
+    type text NOT NULL,
+    version integer NOT NULL,
+    CONSTRAINT movie_changes_type_check CHECK (type IN ('created', 'updated', 'deleted'))
+);
+
+-- Start the log with the current catalog, so that replicas can bootstrap from seq 0.
+INSERT INTO movie_changes (movie_id, type, version)
+SELECT id, 'created', version FROM movies ORDER BY id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS movie_changes;
+-- +goose StatementEnd
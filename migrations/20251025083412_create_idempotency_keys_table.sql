@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- An idempotency key is claimed by the first request that carries it, and holds the
+-- response to that request once it has been handled. user_id is 0 for keys sent by
+-- anonymous requests.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id bigint NOT NULL,
+    key text NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    expires_at timestamp(0) with time zone NOT NULL,
+    locked_until timestamp with time zone NOT NULL,
+    fingerprint text NOT NULL,
+    response_status integer,
+    response_headers jsonb,
+    response_body bytea,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
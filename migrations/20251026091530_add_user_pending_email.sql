@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- pending_email holds the address a user is changing to until they confirm it with the
+-- token sent there. It isn't unique: the address is only claimed once it is confirmed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email citext;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Changes are no longer numbered when they are written, which needed a table lock to
+-- keep the numbers in commit order. They are written with a NULL seq, and numbered from
+-- the same sequence once they have been committed, see MovieChangeModel. id only keeps
+-- the order of the changes that are waiting for their number.
+ALTER TABLE movie_changes DROP CONSTRAINT IF EXISTS movie_changes_pkey;
+ALTER TABLE movie_changes ALTER COLUMN seq DROP DEFAULT, ALTER COLUMN seq DROP NOT NULL;
+ALTER TABLE movie_changes ADD COLUMN IF NOT EXISTS id bigserial PRIMARY KEY;
+
+CREATE UNIQUE INDEX IF NOT EXISTS movie_changes_seq_idx ON movie_changes (seq);
+CREATE INDEX IF NOT EXISTS movie_changes_unsequenced_idx ON movie_changes (id) WHERE seq IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE movie_changes SET seq = pending.seq
+FROM (
+    SELECT id, nextval('movie_changes_seq_seq') AS seq
+    FROM (SELECT id FROM movie_changes WHERE seq IS NULL ORDER BY id) AS unsequenced
+) AS pending
+WHERE movie_changes.id = pending.id;
+
+DROP INDEX IF EXISTS movie_changes_unsequenced_idx;
+DROP INDEX IF EXISTS movie_changes_seq_idx;
+ALTER TABLE movie_changes DROP COLUMN IF EXISTS id;
+ALTER TABLE movie_changes ALTER COLUMN seq SET DEFAULT nextval('movie_changes_seq_seq'), ALTER COLUMN seq SET NOT NULL;
+ALTER TABLE movie_changes ADD PRIMARY KEY (seq);
+-- +goose StatementEnd
@@ -0,0 +1,125 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The sample movies used to be inserted by the 20250929041910_dump_sample_movie.sql
+-- migration, now `api seed` generates sample data instead. Remove the sample movies
+-- from databases that have them, leaving any that have been edited since, and record
+-- their deletion so that clients syncing the change log drop them as well. Genres are
+-- not compared, the genres migration rewrote them as slugs.
+WITH sample (title, year, runtime) AS (
+    VALUES
+    ('The Shawshank Redemption', 1994, 142),
+    ('The Godfather', 1972, 175),
+    ('The Dark Knight', 2008, 152),
+    ('Pulp Fiction', 1994, 154),
+    ('The Lord of the Rings: The Return of the King', 2003, 201),
+    ('Forrest Gump', 1994, 142),
+    ('Inception', 2010, 148),
+    ('The Matrix', 1999, 136),
+    ('Schindler''s List', 1993, 195),
+    ('Parasite', 2019, 132),
+    ('Goodfellas', 1990, 146),
+    ('Spirited Away', 2001, 125),
+    ('Fight Club', 1999, 139),
+    ('The Green Mile', 1999, 189),
+    ('Gladiator', 2000, 155),
+    ('Interstellar', 2014, 169),
+    ('Alien', 1979, 117),
+    ('E.T. the Extra-Terrestrial', 1982, 115),
+    ('Mad Max: Fury Road', 2015, 120),
+    ('Coco', 2017, 105),
+    ('The Silence of the Lambs', 1991, 118),
+    ('Se7en', 1995, 127),
+    ('The Usual Suspects', 1995, 106),
+    ('Saving Private Ryan', 1998, 169),
+    ('The Lion King', 1994, 88),
+    ('Back to the Future', 1985, 116),
+    ('The Prestige', 2006, 130),
+    ('Whiplash', 2014, 106),
+    ('The Departed', 2006, 151),
+    ('City of God', 2002, 130),
+    ('The Pianist', 2002, 150),
+    ('Avengers: Endgame', 2019, 181),
+    ('Titanic', 1997, 195),
+    ('Shutter Island', 2010, 138),
+    ('The Social Network', 2010, 120),
+    ('Joker', 2019, 122),
+    ('The Grand Budapest Hotel', 2014, 99),
+    ('WALL·E', 2008, 98),
+    ('Inside Out', 2015, 95),
+    ('A Beautiful Mind', 2001, 135),
+    ('Apocalypse Now', 1979, 147),
+    ('Casablanca', 1942, 102),
+    ('Citizen Kane', 1941, 119),
+    ('Lawrence of Arabia', 1962, 222),
+    ('Psycho', 1960, 109),
+    ('2001: A Space Odyssey', 1968, 149),
+    ('The Good, the Bad and the Ugly', 1966, 178),
+    ('Dr. Strangelove', 1964, 95),
+    ('Singin'' in the Rain', 1952, 103),
+    ('It''s a Wonderful Life', 1946, 130),
+    ('Metropolis', 1927, 153),
+    ('Rear Window', 1954, 112),
+    ('North by Northwest', 1959, 136),
+    ('Some Like It Hot', 1959, 121),
+    ('The Wizard of Oz', 1939, 102),
+    ('Sunset Boulevard', 1950, 110),
+    ('12 Angry Men', 1957, 96),
+    ('Gone with the Wind', 1939, 221),
+    ('Chinatown', 1974, 131),
+    ('Rashomon', 1950, 88),
+    ('Dune', 2021, 155),
+    ('Everything Everywhere All at Once', 2022, 139),
+    ('The Batman', 2022, 176),
+    ('Oppenheimer', 2023, 180),
+    ('Barbie', 2023, 114),
+    ('Spider-Man: Into the Spider-Verse', 2018, 117),
+    ('Spider-Man: Across the Spider-Verse', 2023, 140),
+    ('The Irishman', 2019, 209),
+    ('Soul', 2020, 100),
+    ('Inside Llewyn Davis', 2013, 104),
+    ('La La Land', 2016, 128),
+    ('The Revenant', 2015, 156),
+    ('Birdman', 2014, 119),
+    ('Her', 2013, 126),
+    ('Moonlight', 2016, 111),
+    ('The Shape of Water', 2017, 123),
+    ('Jojo Rabbit', 2019, 108),
+    ('Black Panther', 2018, 134),
+    ('The Whale', 2022, 117),
+    ('Tenet', 2020, 150),
+    ('Toy Story', 1995, 81),
+    ('Toy Story 3', 2010, 103),
+    ('Finding Nemo', 2003, 100),
+    ('Finding Dory', 2016, 97),
+    ('Up', 2009, 96),
+    ('Ratatouille', 2007, 111),
+    ('Monsters, Inc.', 2001, 92),
+    ('Monsters University', 2013, 104),
+    ('Shrek', 2001, 90),
+    ('Shrek 2', 2004, 93),
+    ('Kung Fu Panda', 2008, 92),
+    ('Kung Fu Panda 2', 2011, 91),
+    ('Frozen', 2013, 102),
+    ('Frozen II', 2019, 103),
+    ('Zootopia', 2016, 108),
+    ('Moana', 2016, 107),
+    ('Encanto', 2021, 102),
+    ('Despicable Me', 2010, 95),
+    ('Despicable Me 2', 2013, 98),
+    ('How to Train Your Dragon', 2010, 98)
+),
+deleted AS (
+    DELETE FROM movies
+    USING sample
+    WHERE movies.title = sample.title
+    AND movies.year = sample.year
+    AND movies.runtime = sample.runtime
+    AND movies.version = 1
+    RETURNING movies.id, movies.version
+)
+INSERT INTO movie_changes (movie_id, type, version)
+SELECT id, 'deleted', version FROM deleted ORDER BY id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- The deleted sample movies are not restored, `api seed` fills a development database.
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- More similar movies are now stored per movie, to leave room for excluded ids. Mark
+-- every ranking as expired so that the background refresh recomputes them, while the
+-- current rankings are served until then.
+UPDATE movie_similarity_cache SET computed_at = '-infinity';
+-- +goose StatementEnd
+
+-- +goose Down
+-- The rankings are recomputed regardless, nothing to undo.
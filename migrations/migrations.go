@@ -0,0 +1,29 @@
+// Package migrations holds the SQL migrations of the database, embedded into the
+// binaries so that they can migrate the database without extra tooling.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// NewProvider returns a goose provider that applies the migrations to db. Migrations
+// are run under a Postgres advisory lock, so that servers started at the same time
+// don't apply them twice.
+func NewProvider(db *sql.DB) (*goose.Provider, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider(goose.DialectPostgres, db, FS,
+		goose.WithSessionLocker(locker),
+		goose.WithDisableGlobalRegistry(true),
+	)
+}
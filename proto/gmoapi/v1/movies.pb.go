@@ -0,0 +1,1140 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: gmoapi/v1/movies.proto
+
+package gmoapiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Movie struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Title     string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Year      int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
+	// The runtime in minutes, or 0 when it isn't known yet.
+	RuntimeMinutes int32    `protobuf:"varint,6,opt,name=runtime_minutes,json=runtimeMinutes,proto3" json:"runtime_minutes,omitempty"`
+	Genres         []string `protobuf:"bytes,7,rep,name=genres,proto3" json:"genres,omitempty"`
+	// One of announced, in_production or released.
+	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// The release date as YYYY-MM-DD, or empty when it isn't known.
+	ReleaseDate string       `protobuf:"bytes,9,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	ExternalIds *ExternalIDs `protobuf:"bytes,10,opt,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty"`
+	// The URLs of the generated sizes of the poster and backdrop, keyed by size name.
+	PosterUrls    map[string]string `protobuf:"bytes,11,rep,name=poster_urls,json=posterUrls,proto3" json:"poster_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	BackdropUrls  map[string]string `protobuf:"bytes,12,rep,name=backdrop_urls,json=backdropUrls,proto3" json:"backdrop_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Version       int32             `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Movie) Reset() {
+	*x = Movie{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Movie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Movie) ProtoMessage() {}
+
+func (x *Movie) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Movie.ProtoReflect.Descriptor instead.
+func (*Movie) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Movie) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Movie) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Movie) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Movie) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Movie) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Movie) GetRuntimeMinutes() int32 {
+	if x != nil {
+		return x.RuntimeMinutes
+	}
+	return 0
+}
+
+func (x *Movie) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Movie) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Movie) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Movie) GetExternalIds() *ExternalIDs {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+func (x *Movie) GetPosterUrls() map[string]string {
+	if x != nil {
+		return x.PosterUrls
+	}
+	return nil
+}
+
+func (x *Movie) GetBackdropUrls() map[string]string {
+	if x != nil {
+		return x.BackdropUrls
+	}
+	return nil
+}
+
+func (x *Movie) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// ExternalIDs holds the keys of a movie in other catalogs. Each of them is optional.
+type ExternalIDs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imdb          string                 `protobuf:"bytes,1,opt,name=imdb,proto3" json:"imdb,omitempty"`
+	Tmdb          int64                  `protobuf:"varint,2,opt,name=tmdb,proto3" json:"tmdb,omitempty"`
+	Wikidata      string                 `protobuf:"bytes,3,opt,name=wikidata,proto3" json:"wikidata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalIDs) Reset() {
+	*x = ExternalIDs{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalIDs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalIDs) ProtoMessage() {}
+
+func (x *ExternalIDs) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalIDs.ProtoReflect.Descriptor instead.
+func (*ExternalIDs) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExternalIDs) GetImdb() string {
+	if x != nil {
+		return x.Imdb
+	}
+	return ""
+}
+
+func (x *ExternalIDs) GetTmdb() int64 {
+	if x != nil {
+		return x.Tmdb
+	}
+	return 0
+}
+
+func (x *ExternalIDs) GetWikidata() string {
+	if x != nil {
+		return x.Wikidata
+	}
+	return ""
+}
+
+// MovieInput holds the fields of a movie that clients may set.
+type MovieInput struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Year           int32                  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	RuntimeMinutes int32                  `protobuf:"varint,3,opt,name=runtime_minutes,json=runtimeMinutes,proto3" json:"runtime_minutes,omitempty"`
+	Genres         []string               `protobuf:"bytes,4,rep,name=genres,proto3" json:"genres,omitempty"`
+	// Defaults to released when a movie is created.
+	Status        string       `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ReleaseDate   string       `protobuf:"bytes,6,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	ExternalIds   *ExternalIDs `protobuf:"bytes,7,opt,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovieInput) Reset() {
+	*x = MovieInput{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovieInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieInput) ProtoMessage() {}
+
+func (x *MovieInput) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieInput.ProtoReflect.Descriptor instead.
+func (*MovieInput) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MovieInput) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MovieInput) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *MovieInput) GetRuntimeMinutes() int32 {
+	if x != nil {
+		return x.RuntimeMinutes
+	}
+	return 0
+}
+
+func (x *MovieInput) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *MovieInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MovieInput) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *MovieInput) GetExternalIds() *ExternalIDs {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPage   int32                  `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	FirstPage     int32                  `protobuf:"varint,3,opt,name=first_page,json=firstPage,proto3" json:"first_page,omitempty"`
+	LastPage      int32                  `protobuf:"varint,4,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"`
+	TotalRecords  int32                  `protobuf:"varint,5,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Metadata) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *Metadata) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *Metadata) GetFirstPage() int32 {
+	if x != nil {
+		return x.FirstPage
+	}
+	return 0
+}
+
+func (x *Metadata) GetLastPage() int32 {
+	if x != nil {
+		return x.LastPage
+	}
+	return 0
+}
+
+func (x *Metadata) GetTotalRecords() int32 {
+	if x != nil {
+		return x.TotalRecords
+	}
+	return 0
+}
+
+type ListMoviesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Title            string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Genres           []string               `protobuf:"bytes,2,rep,name=genres,proto3" json:"genres,omitempty"`
+	Statuses         []string               `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	CertificationMax string                 `protobuf:"bytes,4,opt,name=certification_max,json=certificationMax,proto3" json:"certification_max,omitempty"`
+	Country          string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	Imdb             string                 `protobuf:"bytes,6,opt,name=imdb,proto3" json:"imdb,omitempty"`
+	Tmdb             int64                  `protobuf:"varint,7,opt,name=tmdb,proto3" json:"tmdb,omitempty"`
+	Wikidata         string                 `protobuf:"bytes,8,opt,name=wikidata,proto3" json:"wikidata,omitempty"`
+	UpdatedSince     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	// Defaults to 1.
+	Page int32 `protobuf:"varint,10,opt,name=page,proto3" json:"page,omitempty"`
+	// Defaults to 20.
+	PageSize int32 `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Defaults to id.
+	Sort          string `protobuf:"bytes,12,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoviesRequest) Reset() {
+	*x = ListMoviesRequest{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoviesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoviesRequest) ProtoMessage() {}
+
+func (x *ListMoviesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoviesRequest.ProtoReflect.Descriptor instead.
+func (*ListMoviesRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMoviesRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *ListMoviesRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListMoviesRequest) GetCertificationMax() string {
+	if x != nil {
+		return x.CertificationMax
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetImdb() string {
+	if x != nil {
+		return x.Imdb
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetTmdb() int64 {
+	if x != nil {
+		return x.Tmdb
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetWikidata() string {
+	if x != nil {
+		return x.Wikidata
+	}
+	return ""
+}
+
+func (x *ListMoviesRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *ListMoviesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMoviesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListMoviesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movies        []*Movie               `protobuf:"bytes,1,rep,name=movies,proto3" json:"movies,omitempty"`
+	Metadata      *Metadata              `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoviesResponse) Reset() {
+	*x = ListMoviesResponse{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoviesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoviesResponse) ProtoMessage() {}
+
+func (x *ListMoviesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoviesResponse.ProtoReflect.Descriptor instead.
+func (*ListMoviesResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMoviesResponse) GetMovies() []*Movie {
+	if x != nil {
+		return x.Movies
+	}
+	return nil
+}
+
+func (x *ListMoviesResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetMovieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMovieRequest) Reset() {
+	*x = GetMovieRequest{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMovieRequest) ProtoMessage() {}
+
+func (x *GetMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMovieRequest.ProtoReflect.Descriptor instead.
+func (*GetMovieRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMovieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMovieResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movie         *Movie                 `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMovieResponse) Reset() {
+	*x = GetMovieResponse{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMovieResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMovieResponse) ProtoMessage() {}
+
+func (x *GetMovieResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMovieResponse.ProtoReflect.Descriptor instead.
+func (*GetMovieResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMovieResponse) GetMovie() *Movie {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+type CreateMovieRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Movie *MovieInput            `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	// Create the movie even if likely duplicates exist.
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMovieRequest) Reset() {
+	*x = CreateMovieRequest{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMovieRequest) ProtoMessage() {}
+
+func (x *CreateMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMovieRequest.ProtoReflect.Descriptor instead.
+func (*CreateMovieRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateMovieRequest) GetMovie() *MovieInput {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+func (x *CreateMovieRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type CreateMovieResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movie         *Movie                 `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMovieResponse) Reset() {
+	*x = CreateMovieResponse{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMovieResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMovieResponse) ProtoMessage() {}
+
+func (x *CreateMovieResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMovieResponse.ProtoReflect.Descriptor instead.
+func (*CreateMovieResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateMovieResponse) GetMovie() *Movie {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+type UpdateMovieRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Movie *MovieInput            `protobuf:"bytes,2,opt,name=movie,proto3" json:"movie,omitempty"`
+	// The fields of movie to update, such as "title" or "external_ids". At least one
+	// field must be listed.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMovieRequest) Reset() {
+	*x = UpdateMovieRequest{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMovieRequest) ProtoMessage() {}
+
+func (x *UpdateMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMovieRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMovieRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateMovieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMovieRequest) GetMovie() *MovieInput {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+func (x *UpdateMovieRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateMovieResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movie         *Movie                 `protobuf:"bytes,1,opt,name=movie,proto3" json:"movie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMovieResponse) Reset() {
+	*x = UpdateMovieResponse{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMovieResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMovieResponse) ProtoMessage() {}
+
+func (x *UpdateMovieResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMovieResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMovieResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateMovieResponse) GetMovie() *Movie {
+	if x != nil {
+		return x.Movie
+	}
+	return nil
+}
+
+type DeleteMovieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMovieRequest) Reset() {
+	*x = DeleteMovieRequest{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMovieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMovieRequest) ProtoMessage() {}
+
+func (x *DeleteMovieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMovieRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMovieRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteMovieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMovieResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMovieResponse) Reset() {
+	*x = DeleteMovieResponse{}
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMovieResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMovieResponse) ProtoMessage() {}
+
+func (x *DeleteMovieResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_movies_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMovieResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMovieResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_movies_proto_rawDescGZIP(), []int{13}
+}
+
+var File_gmoapi_v1_movies_proto protoreflect.FileDescriptor
+
+const file_gmoapi_v1_movies_proto_rawDesc = "" +
+	"\n" +
+	"\x16gmoapi/v1/movies.proto\x12\tgmoapi.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x05\n" +
+	"\x05Movie\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x12\n" +
+	"\x04year\x18\x05 \x01(\x05R\x04year\x12'\n" +
+	"\x0fruntime_minutes\x18\x06 \x01(\x05R\x0eruntimeMinutes\x12\x16\n" +
+	"\x06genres\x18\a \x03(\tR\x06genres\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12!\n" +
+	"\frelease_date\x18\t \x01(\tR\vreleaseDate\x129\n" +
+	"\fexternal_ids\x18\n" +
+	" \x01(\v2\x16.gmoapi.v1.ExternalIDsR\vexternalIds\x12A\n" +
+	"\vposter_urls\x18\v \x03(\v2 .gmoapi.v1.Movie.PosterUrlsEntryR\n" +
+	"posterUrls\x12G\n" +
+	"\rbackdrop_urls\x18\f \x03(\v2\".gmoapi.v1.Movie.BackdropUrlsEntryR\fbackdropUrls\x12\x18\n" +
+	"\aversion\x18\r \x01(\x05R\aversion\x1a=\n" +
+	"\x0fPosterUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11BackdropUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Q\n" +
+	"\vExternalIDs\x12\x12\n" +
+	"\x04imdb\x18\x01 \x01(\tR\x04imdb\x12\x12\n" +
+	"\x04tmdb\x18\x02 \x01(\x03R\x04tmdb\x12\x1a\n" +
+	"\bwikidata\x18\x03 \x01(\tR\bwikidata\"\xed\x01\n" +
+	"\n" +
+	"MovieInput\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\x05R\x04year\x12'\n" +
+	"\x0fruntime_minutes\x18\x03 \x01(\x05R\x0eruntimeMinutes\x12\x16\n" +
+	"\x06genres\x18\x04 \x03(\tR\x06genres\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\frelease_date\x18\x06 \x01(\tR\vreleaseDate\x129\n" +
+	"\fexternal_ids\x18\a \x01(\v2\x16.gmoapi.v1.ExternalIDsR\vexternalIds\"\xab\x01\n" +
+	"\bMetadata\x12!\n" +
+	"\fcurrent_page\x18\x01 \x01(\x05R\vcurrentPage\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"first_page\x18\x03 \x01(\x05R\tfirstPage\x12\x1b\n" +
+	"\tlast_page\x18\x04 \x01(\x05R\blastPage\x12#\n" +
+	"\rtotal_records\x18\x05 \x01(\x05R\ftotalRecords\"\xee\x02\n" +
+	"\x11ListMoviesRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06genres\x18\x02 \x03(\tR\x06genres\x12\x1a\n" +
+	"\bstatuses\x18\x03 \x03(\tR\bstatuses\x12+\n" +
+	"\x11certification_max\x18\x04 \x01(\tR\x10certificationMax\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12\x12\n" +
+	"\x04imdb\x18\x06 \x01(\tR\x04imdb\x12\x12\n" +
+	"\x04tmdb\x18\a \x01(\x03R\x04tmdb\x12\x1a\n" +
+	"\bwikidata\x18\b \x01(\tR\bwikidata\x12?\n" +
+	"\rupdated_since\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12\x12\n" +
+	"\x04page\x18\n" +
+	" \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\v \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04sort\x18\f \x01(\tR\x04sort\"o\n" +
+	"\x12ListMoviesResponse\x12(\n" +
+	"\x06movies\x18\x01 \x03(\v2\x10.gmoapi.v1.MovieR\x06movies\x12/\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x13.gmoapi.v1.MetadataR\bmetadata\"!\n" +
+	"\x0fGetMovieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\":\n" +
+	"\x10GetMovieResponse\x12&\n" +
+	"\x05movie\x18\x01 \x01(\v2\x10.gmoapi.v1.MovieR\x05movie\"W\n" +
+	"\x12CreateMovieRequest\x12+\n" +
+	"\x05movie\x18\x01 \x01(\v2\x15.gmoapi.v1.MovieInputR\x05movie\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"=\n" +
+	"\x13CreateMovieResponse\x12&\n" +
+	"\x05movie\x18\x01 \x01(\v2\x10.gmoapi.v1.MovieR\x05movie\"\x8e\x01\n" +
+	"\x12UpdateMovieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12+\n" +
+	"\x05movie\x18\x02 \x01(\v2\x15.gmoapi.v1.MovieInputR\x05movie\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"=\n" +
+	"\x13UpdateMovieResponse\x12&\n" +
+	"\x05movie\x18\x01 \x01(\v2\x10.gmoapi.v1.MovieR\x05movie\"$\n" +
+	"\x12DeleteMovieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x15\n" +
+	"\x13DeleteMovieResponse2\x89\x03\n" +
+	"\rMoviesService\x12I\n" +
+	"\n" +
+	"ListMovies\x12\x1c.gmoapi.v1.ListMoviesRequest\x1a\x1d.gmoapi.v1.ListMoviesResponse\x12C\n" +
+	"\bGetMovie\x12\x1a.gmoapi.v1.GetMovieRequest\x1a\x1b.gmoapi.v1.GetMovieResponse\x12L\n" +
+	"\vCreateMovie\x12\x1d.gmoapi.v1.CreateMovieRequest\x1a\x1e.gmoapi.v1.CreateMovieResponse\x12L\n" +
+	"\vUpdateMovie\x12\x1d.gmoapi.v1.UpdateMovieRequest\x1a\x1e.gmoapi.v1.UpdateMovieResponse\x12L\n" +
+	"\vDeleteMovie\x12\x1d.gmoapi.v1.DeleteMovieRequest\x1a\x1e.gmoapi.v1.DeleteMovieResponseB5Z3github.com/ucok-man/gmoapi/proto/gmoapi/v1;gmoapiv1b\x06proto3"
+
+var (
+	file_gmoapi_v1_movies_proto_rawDescOnce sync.Once
+	file_gmoapi_v1_movies_proto_rawDescData []byte
+)
+
+func file_gmoapi_v1_movies_proto_rawDescGZIP() []byte {
+	file_gmoapi_v1_movies_proto_rawDescOnce.Do(func() {
+		file_gmoapi_v1_movies_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gmoapi_v1_movies_proto_rawDesc), len(file_gmoapi_v1_movies_proto_rawDesc)))
+	})
+	return file_gmoapi_v1_movies_proto_rawDescData
+}
+
+var file_gmoapi_v1_movies_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_gmoapi_v1_movies_proto_goTypes = []any{
+	(*Movie)(nil),                 // 0: gmoapi.v1.Movie
+	(*ExternalIDs)(nil),           // 1: gmoapi.v1.ExternalIDs
+	(*MovieInput)(nil),            // 2: gmoapi.v1.MovieInput
+	(*Metadata)(nil),              // 3: gmoapi.v1.Metadata
+	(*ListMoviesRequest)(nil),     // 4: gmoapi.v1.ListMoviesRequest
+	(*ListMoviesResponse)(nil),    // 5: gmoapi.v1.ListMoviesResponse
+	(*GetMovieRequest)(nil),       // 6: gmoapi.v1.GetMovieRequest
+	(*GetMovieResponse)(nil),      // 7: gmoapi.v1.GetMovieResponse
+	(*CreateMovieRequest)(nil),    // 8: gmoapi.v1.CreateMovieRequest
+	(*CreateMovieResponse)(nil),   // 9: gmoapi.v1.CreateMovieResponse
+	(*UpdateMovieRequest)(nil),    // 10: gmoapi.v1.UpdateMovieRequest
+	(*UpdateMovieResponse)(nil),   // 11: gmoapi.v1.UpdateMovieResponse
+	(*DeleteMovieRequest)(nil),    // 12: gmoapi.v1.DeleteMovieRequest
+	(*DeleteMovieResponse)(nil),   // 13: gmoapi.v1.DeleteMovieResponse
+	nil,                           // 14: gmoapi.v1.Movie.PosterUrlsEntry
+	nil,                           // 15: gmoapi.v1.Movie.BackdropUrlsEntry
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 17: google.protobuf.FieldMask
+}
+var file_gmoapi_v1_movies_proto_depIdxs = []int32{
+	16, // 0: gmoapi.v1.Movie.created_at:type_name -> google.protobuf.Timestamp
+	16, // 1: gmoapi.v1.Movie.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: gmoapi.v1.Movie.external_ids:type_name -> gmoapi.v1.ExternalIDs
+	14, // 3: gmoapi.v1.Movie.poster_urls:type_name -> gmoapi.v1.Movie.PosterUrlsEntry
+	15, // 4: gmoapi.v1.Movie.backdrop_urls:type_name -> gmoapi.v1.Movie.BackdropUrlsEntry
+	1,  // 5: gmoapi.v1.MovieInput.external_ids:type_name -> gmoapi.v1.ExternalIDs
+	16, // 6: gmoapi.v1.ListMoviesRequest.updated_since:type_name -> google.protobuf.Timestamp
+	0,  // 7: gmoapi.v1.ListMoviesResponse.movies:type_name -> gmoapi.v1.Movie
+	3,  // 8: gmoapi.v1.ListMoviesResponse.metadata:type_name -> gmoapi.v1.Metadata
+	0,  // 9: gmoapi.v1.GetMovieResponse.movie:type_name -> gmoapi.v1.Movie
+	2,  // 10: gmoapi.v1.CreateMovieRequest.movie:type_name -> gmoapi.v1.MovieInput
+	0,  // 11: gmoapi.v1.CreateMovieResponse.movie:type_name -> gmoapi.v1.Movie
+	2,  // 12: gmoapi.v1.UpdateMovieRequest.movie:type_name -> gmoapi.v1.MovieInput
+	17, // 13: gmoapi.v1.UpdateMovieRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 14: gmoapi.v1.UpdateMovieResponse.movie:type_name -> gmoapi.v1.Movie
+	4,  // 15: gmoapi.v1.MoviesService.ListMovies:input_type -> gmoapi.v1.ListMoviesRequest
+	6,  // 16: gmoapi.v1.MoviesService.GetMovie:input_type -> gmoapi.v1.GetMovieRequest
+	8,  // 17: gmoapi.v1.MoviesService.CreateMovie:input_type -> gmoapi.v1.CreateMovieRequest
+	10, // 18: gmoapi.v1.MoviesService.UpdateMovie:input_type -> gmoapi.v1.UpdateMovieRequest
+	12, // 19: gmoapi.v1.MoviesService.DeleteMovie:input_type -> gmoapi.v1.DeleteMovieRequest
+	5,  // 20: gmoapi.v1.MoviesService.ListMovies:output_type -> gmoapi.v1.ListMoviesResponse
+	7,  // 21: gmoapi.v1.MoviesService.GetMovie:output_type -> gmoapi.v1.GetMovieResponse
+	9,  // 22: gmoapi.v1.MoviesService.CreateMovie:output_type -> gmoapi.v1.CreateMovieResponse
+	11, // 23: gmoapi.v1.MoviesService.UpdateMovie:output_type -> gmoapi.v1.UpdateMovieResponse
+	13, // 24: gmoapi.v1.MoviesService.DeleteMovie:output_type -> gmoapi.v1.DeleteMovieResponse
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_gmoapi_v1_movies_proto_init() }
+func file_gmoapi_v1_movies_proto_init() {
+	if File_gmoapi_v1_movies_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gmoapi_v1_movies_proto_rawDesc), len(file_gmoapi_v1_movies_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gmoapi_v1_movies_proto_goTypes,
+		DependencyIndexes: file_gmoapi_v1_movies_proto_depIdxs,
+		MessageInfos:      file_gmoapi_v1_movies_proto_msgTypes,
+	}.Build()
+	File_gmoapi_v1_movies_proto = out.File
+	file_gmoapi_v1_movies_proto_goTypes = nil
+	file_gmoapi_v1_movies_proto_depIdxs = nil
+}
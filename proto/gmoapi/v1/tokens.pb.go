@@ -0,0 +1,388 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: gmoapi/v1/tokens.proto
+
+package gmoapiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateAuthenticationTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthenticationTokenRequest) Reset() {
+	*x = CreateAuthenticationTokenRequest{}
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthenticationTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthenticationTokenRequest) ProtoMessage() {}
+
+func (x *CreateAuthenticationTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthenticationTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuthenticationTokenRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_tokens_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateAuthenticationTokenRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateAuthenticationTokenRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateAuthenticationTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Expiry        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthenticationTokenResponse) Reset() {
+	*x = CreateAuthenticationTokenResponse{}
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthenticationTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthenticationTokenResponse) ProtoMessage() {}
+
+func (x *CreateAuthenticationTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthenticationTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAuthenticationTokenResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_tokens_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAuthenticationTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateAuthenticationTokenResponse) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+type CreatePasswordResetTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePasswordResetTokenRequest) Reset() {
+	*x = CreatePasswordResetTokenRequest{}
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePasswordResetTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePasswordResetTokenRequest) ProtoMessage() {}
+
+func (x *CreatePasswordResetTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePasswordResetTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreatePasswordResetTokenRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_tokens_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePasswordResetTokenRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type CreatePasswordResetTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePasswordResetTokenResponse) Reset() {
+	*x = CreatePasswordResetTokenResponse{}
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePasswordResetTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePasswordResetTokenResponse) ProtoMessage() {}
+
+func (x *CreatePasswordResetTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePasswordResetTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreatePasswordResetTokenResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_tokens_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePasswordResetTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateActivationTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateActivationTokenRequest) Reset() {
+	*x = CreateActivationTokenRequest{}
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActivationTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActivationTokenRequest) ProtoMessage() {}
+
+func (x *CreateActivationTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActivationTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateActivationTokenRequest) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_tokens_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateActivationTokenRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type CreateActivationTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateActivationTokenResponse) Reset() {
+	*x = CreateActivationTokenResponse{}
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActivationTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActivationTokenResponse) ProtoMessage() {}
+
+func (x *CreateActivationTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gmoapi_v1_tokens_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActivationTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateActivationTokenResponse) Descriptor() ([]byte, []int) {
+	return file_gmoapi_v1_tokens_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateActivationTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_gmoapi_v1_tokens_proto protoreflect.FileDescriptor
+
+const file_gmoapi_v1_tokens_proto_rawDesc = "" +
+	"\n" +
+	"\x16gmoapi/v1/tokens.proto\x12\tgmoapi.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"T\n" +
+	" CreateAuthenticationTokenRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"m\n" +
+	"!CreateAuthenticationTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x122\n" +
+	"\x06expiry\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\"7\n" +
+	"\x1fCreatePasswordResetTokenRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"<\n" +
+	" CreatePasswordResetTokenResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"4\n" +
+	"\x1cCreateActivationTokenRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"9\n" +
+	"\x1dCreateActivationTokenResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage2\xe8\x02\n" +
+	"\rTokensService\x12v\n" +
+	"\x19CreateAuthenticationToken\x12+.gmoapi.v1.CreateAuthenticationTokenRequest\x1a,.gmoapi.v1.CreateAuthenticationTokenResponse\x12s\n" +
+	"\x18CreatePasswordResetToken\x12*.gmoapi.v1.CreatePasswordResetTokenRequest\x1a+.gmoapi.v1.CreatePasswordResetTokenResponse\x12j\n" +
+	"\x15CreateActivationToken\x12'.gmoapi.v1.CreateActivationTokenRequest\x1a(.gmoapi.v1.CreateActivationTokenResponseB5Z3github.com/ucok-man/gmoapi/proto/gmoapi/v1;gmoapiv1b\x06proto3"
+
+var (
+	file_gmoapi_v1_tokens_proto_rawDescOnce sync.Once
+	file_gmoapi_v1_tokens_proto_rawDescData []byte
+)
+
+func file_gmoapi_v1_tokens_proto_rawDescGZIP() []byte {
+	file_gmoapi_v1_tokens_proto_rawDescOnce.Do(func() {
+		file_gmoapi_v1_tokens_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gmoapi_v1_tokens_proto_rawDesc), len(file_gmoapi_v1_tokens_proto_rawDesc)))
+	})
+	return file_gmoapi_v1_tokens_proto_rawDescData
+}
+
+var file_gmoapi_v1_tokens_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_gmoapi_v1_tokens_proto_goTypes = []any{
+	(*CreateAuthenticationTokenRequest)(nil),  // 0: gmoapi.v1.CreateAuthenticationTokenRequest
+	(*CreateAuthenticationTokenResponse)(nil), // 1: gmoapi.v1.CreateAuthenticationTokenResponse
+	(*CreatePasswordResetTokenRequest)(nil),   // 2: gmoapi.v1.CreatePasswordResetTokenRequest
+	(*CreatePasswordResetTokenResponse)(nil),  // 3: gmoapi.v1.CreatePasswordResetTokenResponse
+	(*CreateActivationTokenRequest)(nil),      // 4: gmoapi.v1.CreateActivationTokenRequest
+	(*CreateActivationTokenResponse)(nil),     // 5: gmoapi.v1.CreateActivationTokenResponse
+	(*timestamppb.Timestamp)(nil),             // 6: google.protobuf.Timestamp
+}
+var file_gmoapi_v1_tokens_proto_depIdxs = []int32{
+	6, // 0: gmoapi.v1.CreateAuthenticationTokenResponse.expiry:type_name -> google.protobuf.Timestamp
+	0, // 1: gmoapi.v1.TokensService.CreateAuthenticationToken:input_type -> gmoapi.v1.CreateAuthenticationTokenRequest
+	2, // 2: gmoapi.v1.TokensService.CreatePasswordResetToken:input_type -> gmoapi.v1.CreatePasswordResetTokenRequest
+	4, // 3: gmoapi.v1.TokensService.CreateActivationToken:input_type -> gmoapi.v1.CreateActivationTokenRequest
+	1, // 4: gmoapi.v1.TokensService.CreateAuthenticationToken:output_type -> gmoapi.v1.CreateAuthenticationTokenResponse
+	3, // 5: gmoapi.v1.TokensService.CreatePasswordResetToken:output_type -> gmoapi.v1.CreatePasswordResetTokenResponse
+	5, // 6: gmoapi.v1.TokensService.CreateActivationToken:output_type -> gmoapi.v1.CreateActivationTokenResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_gmoapi_v1_tokens_proto_init() }
+func file_gmoapi_v1_tokens_proto_init() {
+	if File_gmoapi_v1_tokens_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gmoapi_v1_tokens_proto_rawDesc), len(file_gmoapi_v1_tokens_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gmoapi_v1_tokens_proto_goTypes,
+		DependencyIndexes: file_gmoapi_v1_tokens_proto_depIdxs,
+		MessageInfos:      file_gmoapi_v1_tokens_proto_msgTypes,
+	}.Build()
+	File_gmoapi_v1_tokens_proto = out.File
+	file_gmoapi_v1_tokens_proto_goTypes = nil
+	file_gmoapi_v1_tokens_proto_depIdxs = nil
+}
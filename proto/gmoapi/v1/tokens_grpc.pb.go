@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: gmoapi/v1/tokens.proto
+
+package gmoapiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TokensService_CreateAuthenticationToken_FullMethodName = "/gmoapi.v1.TokensService/CreateAuthenticationToken"
+	TokensService_CreatePasswordResetToken_FullMethodName  = "/gmoapi.v1.TokensService/CreatePasswordResetToken"
+	TokensService_CreateActivationToken_FullMethodName     = "/gmoapi.v1.TokensService/CreateActivationToken"
+)
+
+// TokensServiceClient is the client API for TokensService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TokensService issues tokens. Its calls don't require authentication.
+type TokensServiceClient interface {
+	// CreateAuthenticationToken exchanges the credentials of a user for a bearer token
+	// that is valid for 24 hours.
+	CreateAuthenticationToken(ctx context.Context, in *CreateAuthenticationTokenRequest, opts ...grpc.CallOption) (*CreateAuthenticationTokenResponse, error)
+	// CreatePasswordResetToken emails a password reset token to an activated user.
+	CreatePasswordResetToken(ctx context.Context, in *CreatePasswordResetTokenRequest, opts ...grpc.CallOption) (*CreatePasswordResetTokenResponse, error)
+	// CreateActivationToken emails a new activation token to a user that hasn't been
+	// activated yet.
+	CreateActivationToken(ctx context.Context, in *CreateActivationTokenRequest, opts ...grpc.CallOption) (*CreateActivationTokenResponse, error)
+}
+
+type tokensServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTokensServiceClient(cc grpc.ClientConnInterface) TokensServiceClient {
+	return &tokensServiceClient{cc}
+}
+
+func (c *tokensServiceClient) CreateAuthenticationToken(ctx context.Context, in *CreateAuthenticationTokenRequest, opts ...grpc.CallOption) (*CreateAuthenticationTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAuthenticationTokenResponse)
+	err := c.cc.Invoke(ctx, TokensService_CreateAuthenticationToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokensServiceClient) CreatePasswordResetToken(ctx context.Context, in *CreatePasswordResetTokenRequest, opts ...grpc.CallOption) (*CreatePasswordResetTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePasswordResetTokenResponse)
+	err := c.cc.Invoke(ctx, TokensService_CreatePasswordResetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokensServiceClient) CreateActivationToken(ctx context.Context, in *CreateActivationTokenRequest, opts ...grpc.CallOption) (*CreateActivationTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateActivationTokenResponse)
+	err := c.cc.Invoke(ctx, TokensService_CreateActivationToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokensServiceServer is the server API for TokensService service.
+// All implementations must embed UnimplementedTokensServiceServer
+// for forward compatibility.
+//
+// TokensService issues tokens. Its calls don't require authentication.
+type TokensServiceServer interface {
+	// CreateAuthenticationToken exchanges the credentials of a user for a bearer token
+	// that is valid for 24 hours.
+	CreateAuthenticationToken(context.Context, *CreateAuthenticationTokenRequest) (*CreateAuthenticationTokenResponse, error)
+	// CreatePasswordResetToken emails a password reset token to an activated user.
+	CreatePasswordResetToken(context.Context, *CreatePasswordResetTokenRequest) (*CreatePasswordResetTokenResponse, error)
+	// CreateActivationToken emails a new activation token to a user that hasn't been
+	// activated yet.
+	CreateActivationToken(context.Context, *CreateActivationTokenRequest) (*CreateActivationTokenResponse, error)
+	mustEmbedUnimplementedTokensServiceServer()
+}
+
+// UnimplementedTokensServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTokensServiceServer struct{}
+
+func (UnimplementedTokensServiceServer) CreateAuthenticationToken(context.Context, *CreateAuthenticationTokenRequest) (*CreateAuthenticationTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAuthenticationToken not implemented")
+}
+func (UnimplementedTokensServiceServer) CreatePasswordResetToken(context.Context, *CreatePasswordResetTokenRequest) (*CreatePasswordResetTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePasswordResetToken not implemented")
+}
+func (UnimplementedTokensServiceServer) CreateActivationToken(context.Context, *CreateActivationTokenRequest) (*CreateActivationTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateActivationToken not implemented")
+}
+func (UnimplementedTokensServiceServer) mustEmbedUnimplementedTokensServiceServer() {}
+func (UnimplementedTokensServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeTokensServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TokensServiceServer will
+// result in compilation errors.
+type UnsafeTokensServiceServer interface {
+	mustEmbedUnimplementedTokensServiceServer()
+}
+
+func RegisterTokensServiceServer(s grpc.ServiceRegistrar, srv TokensServiceServer) {
+	// If the following call panics, it indicates UnimplementedTokensServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TokensService_ServiceDesc, srv)
+}
+
+func _TokensService_CreateAuthenticationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuthenticationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokensServiceServer).CreateAuthenticationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TokensService_CreateAuthenticationToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokensServiceServer).CreateAuthenticationToken(ctx, req.(*CreateAuthenticationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TokensService_CreatePasswordResetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePasswordResetTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokensServiceServer).CreatePasswordResetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TokensService_CreatePasswordResetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokensServiceServer).CreatePasswordResetToken(ctx, req.(*CreatePasswordResetTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TokensService_CreateActivationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateActivationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokensServiceServer).CreateActivationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TokensService_CreateActivationToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokensServiceServer).CreateActivationToken(ctx, req.(*CreateActivationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TokensService_ServiceDesc is the grpc.ServiceDesc for TokensService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TokensService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gmoapi.v1.TokensService",
+	HandlerType: (*TokensServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAuthenticationToken",
+			Handler:    _TokensService_CreateAuthenticationToken_Handler,
+		},
+		{
+			MethodName: "CreatePasswordResetToken",
+			Handler:    _TokensService_CreatePasswordResetToken_Handler,
+		},
+		{
+			MethodName: "CreateActivationToken",
+			Handler:    _TokensService_CreateActivationToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gmoapi/v1/tokens.proto",
+}